@@ -0,0 +1,4064 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: users/v1/account.proto
+
+package v1
+
+import (
+	v1 "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MfaEnrollRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MfaEnrollRequest) Reset() {
+	*x = MfaEnrollRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MfaEnrollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MfaEnrollRequest) ProtoMessage() {}
+
+func (x *MfaEnrollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MfaEnrollRequest.ProtoReflect.Descriptor instead.
+func (*MfaEnrollRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{0}
+}
+
+type MfaEnrollResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*MfaEnrollResponse_Data
+	//	*MfaEnrollResponse_Error
+	Response      isMfaEnrollResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MfaEnrollResponse) Reset() {
+	*x = MfaEnrollResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MfaEnrollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MfaEnrollResponse) ProtoMessage() {}
+
+func (x *MfaEnrollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MfaEnrollResponse.ProtoReflect.Descriptor instead.
+func (*MfaEnrollResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MfaEnrollResponse) GetResponse() isMfaEnrollResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *MfaEnrollResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*MfaEnrollResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *MfaEnrollResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*MfaEnrollResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isMfaEnrollResponse_Response interface {
+	isMfaEnrollResponse_Response()
+}
+
+type MfaEnrollResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type MfaEnrollResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*MfaEnrollResponse_Data) isMfaEnrollResponse_Response() {}
+
+func (*MfaEnrollResponse_Error) isMfaEnrollResponse_Response() {}
+
+type MfaConfirmRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MfaConfirmRequest) Reset() {
+	*x = MfaConfirmRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MfaConfirmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MfaConfirmRequest) ProtoMessage() {}
+
+func (x *MfaConfirmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MfaConfirmRequest.ProtoReflect.Descriptor instead.
+func (*MfaConfirmRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MfaConfirmRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type MfaConfirmResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*MfaConfirmResponse_Data
+	//	*MfaConfirmResponse_Error
+	Response isMfaConfirmResponse_Response `protobuf_oneof:"response"`
+	// recovery_codes are set along with data, they're shown to the user only once
+	RecoveryCodes []string `protobuf:"bytes,3,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MfaConfirmResponse) Reset() {
+	*x = MfaConfirmResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MfaConfirmResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MfaConfirmResponse) ProtoMessage() {}
+
+func (x *MfaConfirmResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MfaConfirmResponse.ProtoReflect.Descriptor instead.
+func (*MfaConfirmResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MfaConfirmResponse) GetResponse() isMfaConfirmResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *MfaConfirmResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*MfaConfirmResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *MfaConfirmResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*MfaConfirmResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *MfaConfirmResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type isMfaConfirmResponse_Response interface {
+	isMfaConfirmResponse_Response()
+}
+
+type MfaConfirmResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type MfaConfirmResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*MfaConfirmResponse_Data) isMfaConfirmResponse_Response() {}
+
+func (*MfaConfirmResponse_Error) isMfaConfirmResponse_Response() {}
+
+type MfaDisableRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Password      string                 `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MfaDisableRequest) Reset() {
+	*x = MfaDisableRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MfaDisableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MfaDisableRequest) ProtoMessage() {}
+
+func (x *MfaDisableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MfaDisableRequest.ProtoReflect.Descriptor instead.
+func (*MfaDisableRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MfaDisableRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type MfaDisableResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*MfaDisableResponse_Data
+	//	*MfaDisableResponse_Error
+	Response      isMfaDisableResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MfaDisableResponse) Reset() {
+	*x = MfaDisableResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MfaDisableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MfaDisableResponse) ProtoMessage() {}
+
+func (x *MfaDisableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MfaDisableResponse.ProtoReflect.Descriptor instead.
+func (*MfaDisableResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MfaDisableResponse) GetResponse() isMfaDisableResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *MfaDisableResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*MfaDisableResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *MfaDisableResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*MfaDisableResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isMfaDisableResponse_Response interface {
+	isMfaDisableResponse_Response()
+}
+
+type MfaDisableResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type MfaDisableResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*MfaDisableResponse_Data) isMfaDisableResponse_Response() {}
+
+func (*MfaDisableResponse_Error) isMfaDisableResponse_Response() {}
+
+type MfaRecoveryCodesRegenerateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Password      string                 `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MfaRecoveryCodesRegenerateRequest) Reset() {
+	*x = MfaRecoveryCodesRegenerateRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MfaRecoveryCodesRegenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MfaRecoveryCodesRegenerateRequest) ProtoMessage() {}
+
+func (x *MfaRecoveryCodesRegenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MfaRecoveryCodesRegenerateRequest.ProtoReflect.Descriptor instead.
+func (*MfaRecoveryCodesRegenerateRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MfaRecoveryCodesRegenerateRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type MfaRecoveryCodesRegenerateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*MfaRecoveryCodesRegenerateResponse_Data
+	//	*MfaRecoveryCodesRegenerateResponse_Error
+	Response isMfaRecoveryCodesRegenerateResponse_Response `protobuf_oneof:"response"`
+	// recovery_codes are set along with data, they're shown to the user only once
+	RecoveryCodes []string `protobuf:"bytes,3,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MfaRecoveryCodesRegenerateResponse) Reset() {
+	*x = MfaRecoveryCodesRegenerateResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MfaRecoveryCodesRegenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MfaRecoveryCodesRegenerateResponse) ProtoMessage() {}
+
+func (x *MfaRecoveryCodesRegenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MfaRecoveryCodesRegenerateResponse.ProtoReflect.Descriptor instead.
+func (*MfaRecoveryCodesRegenerateResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MfaRecoveryCodesRegenerateResponse) GetResponse() isMfaRecoveryCodesRegenerateResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *MfaRecoveryCodesRegenerateResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*MfaRecoveryCodesRegenerateResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *MfaRecoveryCodesRegenerateResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*MfaRecoveryCodesRegenerateResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *MfaRecoveryCodesRegenerateResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type isMfaRecoveryCodesRegenerateResponse_Response interface {
+	isMfaRecoveryCodesRegenerateResponse_Response()
+}
+
+type MfaRecoveryCodesRegenerateResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type MfaRecoveryCodesRegenerateResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*MfaRecoveryCodesRegenerateResponse_Data) isMfaRecoveryCodesRegenerateResponse_Response() {}
+
+func (*MfaRecoveryCodesRegenerateResponse_Error) isMfaRecoveryCodesRegenerateResponse_Response() {}
+
+type WebauthnRegisterBeginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebauthnRegisterBeginRequest) Reset() {
+	*x = WebauthnRegisterBeginRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebauthnRegisterBeginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebauthnRegisterBeginRequest) ProtoMessage() {}
+
+func (x *WebauthnRegisterBeginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebauthnRegisterBeginRequest.ProtoReflect.Descriptor instead.
+func (*WebauthnRegisterBeginRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{8}
+}
+
+type WebauthnRegisterBeginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*WebauthnRegisterBeginResponse_Data
+	//	*WebauthnRegisterBeginResponse_Error
+	Response      isWebauthnRegisterBeginResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebauthnRegisterBeginResponse) Reset() {
+	*x = WebauthnRegisterBeginResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebauthnRegisterBeginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebauthnRegisterBeginResponse) ProtoMessage() {}
+
+func (x *WebauthnRegisterBeginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebauthnRegisterBeginResponse.ProtoReflect.Descriptor instead.
+func (*WebauthnRegisterBeginResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WebauthnRegisterBeginResponse) GetResponse() isWebauthnRegisterBeginResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *WebauthnRegisterBeginResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*WebauthnRegisterBeginResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *WebauthnRegisterBeginResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*WebauthnRegisterBeginResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isWebauthnRegisterBeginResponse_Response interface {
+	isWebauthnRegisterBeginResponse_Response()
+}
+
+type WebauthnRegisterBeginResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type WebauthnRegisterBeginResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*WebauthnRegisterBeginResponse_Data) isWebauthnRegisterBeginResponse_Response() {}
+
+func (*WebauthnRegisterBeginResponse_Error) isWebauthnRegisterBeginResponse_Response() {}
+
+type WebauthnRegisterFinishRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// credential is the JSON encoded PublicKeyCredential returned by navigator.credentials.create()
+	Credential    string `protobuf:"bytes,3,opt,name=credential,proto3" json:"credential,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebauthnRegisterFinishRequest) Reset() {
+	*x = WebauthnRegisterFinishRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebauthnRegisterFinishRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebauthnRegisterFinishRequest) ProtoMessage() {}
+
+func (x *WebauthnRegisterFinishRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebauthnRegisterFinishRequest.ProtoReflect.Descriptor instead.
+func (*WebauthnRegisterFinishRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WebauthnRegisterFinishRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *WebauthnRegisterFinishRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WebauthnRegisterFinishRequest) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+type WebauthnRegisterFinishResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*WebauthnRegisterFinishResponse_Data
+	//	*WebauthnRegisterFinishResponse_Error
+	Response      isWebauthnRegisterFinishResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebauthnRegisterFinishResponse) Reset() {
+	*x = WebauthnRegisterFinishResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebauthnRegisterFinishResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebauthnRegisterFinishResponse) ProtoMessage() {}
+
+func (x *WebauthnRegisterFinishResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebauthnRegisterFinishResponse.ProtoReflect.Descriptor instead.
+func (*WebauthnRegisterFinishResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WebauthnRegisterFinishResponse) GetResponse() isWebauthnRegisterFinishResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *WebauthnRegisterFinishResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*WebauthnRegisterFinishResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *WebauthnRegisterFinishResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*WebauthnRegisterFinishResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isWebauthnRegisterFinishResponse_Response interface {
+	isWebauthnRegisterFinishResponse_Response()
+}
+
+type WebauthnRegisterFinishResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type WebauthnRegisterFinishResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*WebauthnRegisterFinishResponse_Data) isWebauthnRegisterFinishResponse_Response() {}
+
+func (*WebauthnRegisterFinishResponse_Error) isWebauthnRegisterFinishResponse_Response() {}
+
+type WebauthnLoginBeginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebauthnLoginBeginRequest) Reset() {
+	*x = WebauthnLoginBeginRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebauthnLoginBeginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebauthnLoginBeginRequest) ProtoMessage() {}
+
+func (x *WebauthnLoginBeginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebauthnLoginBeginRequest.ProtoReflect.Descriptor instead.
+func (*WebauthnLoginBeginRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WebauthnLoginBeginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type WebauthnLoginBeginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*WebauthnLoginBeginResponse_Data
+	//	*WebauthnLoginBeginResponse_Error
+	Response      isWebauthnLoginBeginResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebauthnLoginBeginResponse) Reset() {
+	*x = WebauthnLoginBeginResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebauthnLoginBeginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebauthnLoginBeginResponse) ProtoMessage() {}
+
+func (x *WebauthnLoginBeginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebauthnLoginBeginResponse.ProtoReflect.Descriptor instead.
+func (*WebauthnLoginBeginResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WebauthnLoginBeginResponse) GetResponse() isWebauthnLoginBeginResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *WebauthnLoginBeginResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*WebauthnLoginBeginResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *WebauthnLoginBeginResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*WebauthnLoginBeginResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isWebauthnLoginBeginResponse_Response interface {
+	isWebauthnLoginBeginResponse_Response()
+}
+
+type WebauthnLoginBeginResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type WebauthnLoginBeginResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*WebauthnLoginBeginResponse_Data) isWebauthnLoginBeginResponse_Response() {}
+
+func (*WebauthnLoginBeginResponse_Error) isWebauthnLoginBeginResponse_Response() {}
+
+type WebauthnLoginFinishRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// credential is the JSON encoded PublicKeyCredential returned by navigator.credentials.get()
+	Credential     string `protobuf:"bytes,2,opt,name=credential,proto3" json:"credential,omitempty"`
+	LoginChallenge string `protobuf:"bytes,3,opt,name=login_challenge,json=loginChallenge,proto3" json:"login_challenge,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebauthnLoginFinishRequest) Reset() {
+	*x = WebauthnLoginFinishRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebauthnLoginFinishRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebauthnLoginFinishRequest) ProtoMessage() {}
+
+func (x *WebauthnLoginFinishRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebauthnLoginFinishRequest.ProtoReflect.Descriptor instead.
+func (*WebauthnLoginFinishRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WebauthnLoginFinishRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *WebauthnLoginFinishRequest) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+func (x *WebauthnLoginFinishRequest) GetLoginChallenge() string {
+	if x != nil {
+		return x.LoginChallenge
+	}
+	return ""
+}
+
+type WebauthnLoginFinishResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*WebauthnLoginFinishResponse_Data
+	//	*WebauthnLoginFinishResponse_Error
+	Response      isWebauthnLoginFinishResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebauthnLoginFinishResponse) Reset() {
+	*x = WebauthnLoginFinishResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebauthnLoginFinishResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebauthnLoginFinishResponse) ProtoMessage() {}
+
+func (x *WebauthnLoginFinishResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebauthnLoginFinishResponse.ProtoReflect.Descriptor instead.
+func (*WebauthnLoginFinishResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WebauthnLoginFinishResponse) GetResponse() isWebauthnLoginFinishResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *WebauthnLoginFinishResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*WebauthnLoginFinishResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *WebauthnLoginFinishResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*WebauthnLoginFinishResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isWebauthnLoginFinishResponse_Response interface {
+	isWebauthnLoginFinishResponse_Response()
+}
+
+type WebauthnLoginFinishResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type WebauthnLoginFinishResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*WebauthnLoginFinishResponse_Data) isWebauthnLoginFinishResponse_Response() {}
+
+func (*WebauthnLoginFinishResponse_Error) isWebauthnLoginFinishResponse_Response() {}
+
+type PasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenId       string                 `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasswordResetRequest) Reset() {
+	*x = PasswordResetRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordResetRequest) ProtoMessage() {}
+
+func (x *PasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*PasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PasswordResetRequest) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *PasswordResetRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PasswordResetRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type PasswordResetResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*PasswordResetResponse_Data
+	//	*PasswordResetResponse_Error
+	Response      isPasswordResetResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PasswordResetResponse) Reset() {
+	*x = PasswordResetResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordResetResponse) ProtoMessage() {}
+
+func (x *PasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*PasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PasswordResetResponse) GetResponse() isPasswordResetResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *PasswordResetResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*PasswordResetResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *PasswordResetResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*PasswordResetResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isPasswordResetResponse_Response interface {
+	isPasswordResetResponse_Response()
+}
+
+type PasswordResetResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type PasswordResetResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*PasswordResetResponse_Data) isPasswordResetResponse_Response() {}
+
+func (*PasswordResetResponse_Error) isPasswordResetResponse_Response() {}
+
+type ChangePasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPassword string                 `protobuf:"bytes,1,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	NewPassword     string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ChangePasswordRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ChangePasswordResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*ChangePasswordResponse_Data
+	//	*ChangePasswordResponse_Error
+	Response      isChangePasswordResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ChangePasswordResponse) GetResponse() isChangePasswordResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ChangePasswordResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*ChangePasswordResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *ChangePasswordResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*ChangePasswordResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isChangePasswordResponse_Response interface {
+	isChangePasswordResponse_Response()
+}
+
+type ChangePasswordResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type ChangePasswordResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*ChangePasswordResponse_Data) isChangePasswordResponse_Response() {}
+
+func (*ChangePasswordResponse_Error) isChangePasswordResponse_Response() {}
+
+type ResendVerificationEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationEmailRequest) Reset() {
+	*x = ResendVerificationEmailRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationEmailRequest) ProtoMessage() {}
+
+func (x *ResendVerificationEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendVerificationEmailRequest.ProtoReflect.Descriptor instead.
+func (*ResendVerificationEmailRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ResendVerificationEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ResendVerificationEmailResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*ResendVerificationEmailResponse_Data
+	//	*ResendVerificationEmailResponse_Error
+	Response      isResendVerificationEmailResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationEmailResponse) Reset() {
+	*x = ResendVerificationEmailResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationEmailResponse) ProtoMessage() {}
+
+func (x *ResendVerificationEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendVerificationEmailResponse.ProtoReflect.Descriptor instead.
+func (*ResendVerificationEmailResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResendVerificationEmailResponse) GetResponse() isResendVerificationEmailResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ResendVerificationEmailResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*ResendVerificationEmailResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *ResendVerificationEmailResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*ResendVerificationEmailResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isResendVerificationEmailResponse_Response interface {
+	isResendVerificationEmailResponse_Response()
+}
+
+type ResendVerificationEmailResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type ResendVerificationEmailResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*ResendVerificationEmailResponse_Data) isResendVerificationEmailResponse_Response() {}
+
+func (*ResendVerificationEmailResponse_Error) isResendVerificationEmailResponse_Response() {}
+
+type EmailLoginBeginRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Email          string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	LoginChallenge string                 `protobuf:"bytes,2,opt,name=login_challenge,json=loginChallenge,proto3" json:"login_challenge,omitempty"`
+	// link or code
+	Mode          string `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailLoginBeginRequest) Reset() {
+	*x = EmailLoginBeginRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailLoginBeginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailLoginBeginRequest) ProtoMessage() {}
+
+func (x *EmailLoginBeginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailLoginBeginRequest.ProtoReflect.Descriptor instead.
+func (*EmailLoginBeginRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *EmailLoginBeginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *EmailLoginBeginRequest) GetLoginChallenge() string {
+	if x != nil {
+		return x.LoginChallenge
+	}
+	return ""
+}
+
+func (x *EmailLoginBeginRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type EmailLoginBeginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*EmailLoginBeginResponse_Data
+	//	*EmailLoginBeginResponse_Error
+	Response      isEmailLoginBeginResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailLoginBeginResponse) Reset() {
+	*x = EmailLoginBeginResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailLoginBeginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailLoginBeginResponse) ProtoMessage() {}
+
+func (x *EmailLoginBeginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailLoginBeginResponse.ProtoReflect.Descriptor instead.
+func (*EmailLoginBeginResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EmailLoginBeginResponse) GetResponse() isEmailLoginBeginResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *EmailLoginBeginResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*EmailLoginBeginResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *EmailLoginBeginResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*EmailLoginBeginResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isEmailLoginBeginResponse_Response interface {
+	isEmailLoginBeginResponse_Response()
+}
+
+type EmailLoginBeginResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type EmailLoginBeginResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*EmailLoginBeginResponse_Data) isEmailLoginBeginResponse_Response() {}
+
+func (*EmailLoginBeginResponse_Error) isEmailLoginBeginResponse_Response() {}
+
+type EmailLoginFinishRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Email          string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	LoginChallenge string                 `protobuf:"bytes,2,opt,name=login_challenge,json=loginChallenge,proto3" json:"login_challenge,omitempty"`
+	// either the magic link (token_id and token) or the code
+	TokenId       string `protobuf:"bytes,3,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	Token         string `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	Code          string `protobuf:"bytes,5,opt,name=code,proto3" json:"code,omitempty"`
+	Mfa           string `protobuf:"bytes,6,opt,name=mfa,proto3" json:"mfa,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailLoginFinishRequest) Reset() {
+	*x = EmailLoginFinishRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailLoginFinishRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailLoginFinishRequest) ProtoMessage() {}
+
+func (x *EmailLoginFinishRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailLoginFinishRequest.ProtoReflect.Descriptor instead.
+func (*EmailLoginFinishRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EmailLoginFinishRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *EmailLoginFinishRequest) GetLoginChallenge() string {
+	if x != nil {
+		return x.LoginChallenge
+	}
+	return ""
+}
+
+func (x *EmailLoginFinishRequest) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *EmailLoginFinishRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *EmailLoginFinishRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *EmailLoginFinishRequest) GetMfa() string {
+	if x != nil {
+		return x.Mfa
+	}
+	return ""
+}
+
+type EmailLoginFinishResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*EmailLoginFinishResponse_Data
+	//	*EmailLoginFinishResponse_Error
+	Response      isEmailLoginFinishResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailLoginFinishResponse) Reset() {
+	*x = EmailLoginFinishResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailLoginFinishResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailLoginFinishResponse) ProtoMessage() {}
+
+func (x *EmailLoginFinishResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailLoginFinishResponse.ProtoReflect.Descriptor instead.
+func (*EmailLoginFinishResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *EmailLoginFinishResponse) GetResponse() isEmailLoginFinishResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *EmailLoginFinishResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*EmailLoginFinishResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *EmailLoginFinishResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*EmailLoginFinishResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isEmailLoginFinishResponse_Response interface {
+	isEmailLoginFinishResponse_Response()
+}
+
+type EmailLoginFinishResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type EmailLoginFinishResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*EmailLoginFinishResponse_Data) isEmailLoginFinishResponse_Response() {}
+
+func (*EmailLoginFinishResponse_Error) isEmailLoginFinishResponse_Response() {}
+
+type LogoutRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// all_devices ends every session of the user, not only the current one
+	AllDevices    bool `protobuf:"varint,1,opt,name=all_devices,json=allDevices,proto3" json:"all_devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LogoutRequest) GetAllDevices() bool {
+	if x != nil {
+		return x.AllDevices
+	}
+	return false
+}
+
+type LogoutResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*LogoutResponse_Data
+	//	*LogoutResponse_Error
+	Response      isLogoutResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LogoutResponse) GetResponse() isLogoutResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *LogoutResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*LogoutResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *LogoutResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*LogoutResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isLogoutResponse_Response interface {
+	isLogoutResponse_Response()
+}
+
+type LogoutResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type LogoutResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*LogoutResponse_Data) isLogoutResponse_Response() {}
+
+func (*LogoutResponse_Error) isLogoutResponse_Response() {}
+
+type ConsentGetRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConsentChallenge string                 `protobuf:"bytes,1,opt,name=consent_challenge,json=consentChallenge,proto3" json:"consent_challenge,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ConsentGetRequest) Reset() {
+	*x = ConsentGetRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentGetRequest) ProtoMessage() {}
+
+func (x *ConsentGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentGetRequest.ProtoReflect.Descriptor instead.
+func (*ConsentGetRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ConsentGetRequest) GetConsentChallenge() string {
+	if x != nil {
+		return x.ConsentChallenge
+	}
+	return ""
+}
+
+type ConsentGetResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*ConsentGetResponse_Data
+	//	*ConsentGetResponse_Error
+	Response      isConsentGetResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsentGetResponse) Reset() {
+	*x = ConsentGetResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentGetResponse) ProtoMessage() {}
+
+func (x *ConsentGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentGetResponse.ProtoReflect.Descriptor instead.
+func (*ConsentGetResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ConsentGetResponse) GetResponse() isConsentGetResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ConsentGetResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*ConsentGetResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *ConsentGetResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*ConsentGetResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isConsentGetResponse_Response interface {
+	isConsentGetResponse_Response()
+}
+
+type ConsentGetResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type ConsentGetResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*ConsentGetResponse_Data) isConsentGetResponse_Response() {}
+
+func (*ConsentGetResponse_Error) isConsentGetResponse_Response() {}
+
+type ConsentAcceptRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConsentChallenge string                 `protobuf:"bytes,1,opt,name=consent_challenge,json=consentChallenge,proto3" json:"consent_challenge,omitempty"`
+	GrantScope       []string               `protobuf:"bytes,2,rep,name=grant_scope,json=grantScope,proto3" json:"grant_scope,omitempty"`
+	// remember skips the consent page the next time the client asks for the same scopes
+	Remember      bool `protobuf:"varint,3,opt,name=remember,proto3" json:"remember,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsentAcceptRequest) Reset() {
+	*x = ConsentAcceptRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentAcceptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentAcceptRequest) ProtoMessage() {}
+
+func (x *ConsentAcceptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentAcceptRequest.ProtoReflect.Descriptor instead.
+func (*ConsentAcceptRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ConsentAcceptRequest) GetConsentChallenge() string {
+	if x != nil {
+		return x.ConsentChallenge
+	}
+	return ""
+}
+
+func (x *ConsentAcceptRequest) GetGrantScope() []string {
+	if x != nil {
+		return x.GrantScope
+	}
+	return nil
+}
+
+func (x *ConsentAcceptRequest) GetRemember() bool {
+	if x != nil {
+		return x.Remember
+	}
+	return false
+}
+
+type ConsentAcceptResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*ConsentAcceptResponse_Data
+	//	*ConsentAcceptResponse_Error
+	Response      isConsentAcceptResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsentAcceptResponse) Reset() {
+	*x = ConsentAcceptResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentAcceptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentAcceptResponse) ProtoMessage() {}
+
+func (x *ConsentAcceptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentAcceptResponse.ProtoReflect.Descriptor instead.
+func (*ConsentAcceptResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ConsentAcceptResponse) GetResponse() isConsentAcceptResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ConsentAcceptResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*ConsentAcceptResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *ConsentAcceptResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*ConsentAcceptResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isConsentAcceptResponse_Response interface {
+	isConsentAcceptResponse_Response()
+}
+
+type ConsentAcceptResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type ConsentAcceptResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*ConsentAcceptResponse_Data) isConsentAcceptResponse_Response() {}
+
+func (*ConsentAcceptResponse_Error) isConsentAcceptResponse_Response() {}
+
+type ConsentRejectRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConsentChallenge string                 `protobuf:"bytes,1,opt,name=consent_challenge,json=consentChallenge,proto3" json:"consent_challenge,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ConsentRejectRequest) Reset() {
+	*x = ConsentRejectRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentRejectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentRejectRequest) ProtoMessage() {}
+
+func (x *ConsentRejectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentRejectRequest.ProtoReflect.Descriptor instead.
+func (*ConsentRejectRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ConsentRejectRequest) GetConsentChallenge() string {
+	if x != nil {
+		return x.ConsentChallenge
+	}
+	return ""
+}
+
+type ConsentRejectResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*ConsentRejectResponse_Data
+	//	*ConsentRejectResponse_Error
+	Response      isConsentRejectResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsentRejectResponse) Reset() {
+	*x = ConsentRejectResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsentRejectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsentRejectResponse) ProtoMessage() {}
+
+func (x *ConsentRejectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsentRejectResponse.ProtoReflect.Descriptor instead.
+func (*ConsentRejectResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ConsentRejectResponse) GetResponse() isConsentRejectResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *ConsentRejectResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*ConsentRejectResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *ConsentRejectResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*ConsentRejectResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isConsentRejectResponse_Response interface {
+	isConsentRejectResponse_Response()
+}
+
+type ConsentRejectResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type ConsentRejectResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*ConsentRejectResponse_Data) isConsentRejectResponse_Response() {}
+
+func (*ConsentRejectResponse_Error) isConsentRejectResponse_Response() {}
+
+type IdentitiesListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentitiesListRequest) Reset() {
+	*x = IdentitiesListRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentitiesListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentitiesListRequest) ProtoMessage() {}
+
+func (x *IdentitiesListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentitiesListRequest.ProtoReflect.Descriptor instead.
+func (*IdentitiesListRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{34}
+}
+
+type IdentitiesListResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*IdentitiesListResponse_Data
+	//	*IdentitiesListResponse_Error
+	Response isIdentitiesListResponse_Response `protobuf_oneof:"response"`
+	// identities are set along with data, oldest first
+	Identities    []*Identity `protobuf:"bytes,3,rep,name=identities,proto3" json:"identities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentitiesListResponse) Reset() {
+	*x = IdentitiesListResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentitiesListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentitiesListResponse) ProtoMessage() {}
+
+func (x *IdentitiesListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentitiesListResponse.ProtoReflect.Descriptor instead.
+func (*IdentitiesListResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *IdentitiesListResponse) GetResponse() isIdentitiesListResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *IdentitiesListResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*IdentitiesListResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *IdentitiesListResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*IdentitiesListResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *IdentitiesListResponse) GetIdentities() []*Identity {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+type isIdentitiesListResponse_Response interface {
+	isIdentitiesListResponse_Response()
+}
+
+type IdentitiesListResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type IdentitiesListResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*IdentitiesListResponse_Data) isIdentitiesListResponse_Response() {}
+
+func (*IdentitiesListResponse_Error) isIdentitiesListResponse_Response() {}
+
+// Identity is an upstream provider account (E,g a Google account) that the user signs in with
+type Identity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Provider      string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Identity) Reset() {
+	*x = Identity{}
+	mi := &file_users_v1_account_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Identity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Identity) ProtoMessage() {}
+
+func (x *Identity) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Identity.ProtoReflect.Descriptor instead.
+func (*Identity) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Identity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Identity) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Identity) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Identity) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type IdentityLinkBeginRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Provider string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// password re-authenticates the user, the users without one rely on a recent login
+	Password      string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Mfa           string `protobuf:"bytes,3,opt,name=mfa,proto3" json:"mfa,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityLinkBeginRequest) Reset() {
+	*x = IdentityLinkBeginRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityLinkBeginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityLinkBeginRequest) ProtoMessage() {}
+
+func (x *IdentityLinkBeginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityLinkBeginRequest.ProtoReflect.Descriptor instead.
+func (*IdentityLinkBeginRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *IdentityLinkBeginRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *IdentityLinkBeginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *IdentityLinkBeginRequest) GetMfa() string {
+	if x != nil {
+		return x.Mfa
+	}
+	return ""
+}
+
+type IdentityLinkBeginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*IdentityLinkBeginResponse_Data
+	//	*IdentityLinkBeginResponse_Error
+	Response      isIdentityLinkBeginResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityLinkBeginResponse) Reset() {
+	*x = IdentityLinkBeginResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityLinkBeginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityLinkBeginResponse) ProtoMessage() {}
+
+func (x *IdentityLinkBeginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityLinkBeginResponse.ProtoReflect.Descriptor instead.
+func (*IdentityLinkBeginResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *IdentityLinkBeginResponse) GetResponse() isIdentityLinkBeginResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *IdentityLinkBeginResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*IdentityLinkBeginResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *IdentityLinkBeginResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*IdentityLinkBeginResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isIdentityLinkBeginResponse_Response interface {
+	isIdentityLinkBeginResponse_Response()
+}
+
+type IdentityLinkBeginResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type IdentityLinkBeginResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*IdentityLinkBeginResponse_Data) isIdentityLinkBeginResponse_Response() {}
+
+func (*IdentityLinkBeginResponse_Error) isIdentityLinkBeginResponse_Response() {}
+
+type IdentityLinkConfirmRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// link_id is handed to the identities page by the OAuth server after the provider sign in
+	LinkId        string `protobuf:"bytes,1,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityLinkConfirmRequest) Reset() {
+	*x = IdentityLinkConfirmRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityLinkConfirmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityLinkConfirmRequest) ProtoMessage() {}
+
+func (x *IdentityLinkConfirmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityLinkConfirmRequest.ProtoReflect.Descriptor instead.
+func (*IdentityLinkConfirmRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *IdentityLinkConfirmRequest) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+type IdentityLinkConfirmResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*IdentityLinkConfirmResponse_Data
+	//	*IdentityLinkConfirmResponse_Error
+	Response      isIdentityLinkConfirmResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityLinkConfirmResponse) Reset() {
+	*x = IdentityLinkConfirmResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityLinkConfirmResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityLinkConfirmResponse) ProtoMessage() {}
+
+func (x *IdentityLinkConfirmResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityLinkConfirmResponse.ProtoReflect.Descriptor instead.
+func (*IdentityLinkConfirmResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *IdentityLinkConfirmResponse) GetResponse() isIdentityLinkConfirmResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *IdentityLinkConfirmResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*IdentityLinkConfirmResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *IdentityLinkConfirmResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*IdentityLinkConfirmResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isIdentityLinkConfirmResponse_Response interface {
+	isIdentityLinkConfirmResponse_Response()
+}
+
+type IdentityLinkConfirmResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type IdentityLinkConfirmResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*IdentityLinkConfirmResponse_Data) isIdentityLinkConfirmResponse_Response() {}
+
+func (*IdentityLinkConfirmResponse_Error) isIdentityLinkConfirmResponse_Response() {}
+
+type IdentityUnlinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IdentityId    string                 `protobuf:"bytes,1,opt,name=identity_id,json=identityId,proto3" json:"identity_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityUnlinkRequest) Reset() {
+	*x = IdentityUnlinkRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityUnlinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityUnlinkRequest) ProtoMessage() {}
+
+func (x *IdentityUnlinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityUnlinkRequest.ProtoReflect.Descriptor instead.
+func (*IdentityUnlinkRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *IdentityUnlinkRequest) GetIdentityId() string {
+	if x != nil {
+		return x.IdentityId
+	}
+	return ""
+}
+
+type IdentityUnlinkResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*IdentityUnlinkResponse_Data
+	//	*IdentityUnlinkResponse_Error
+	Response      isIdentityUnlinkResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityUnlinkResponse) Reset() {
+	*x = IdentityUnlinkResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityUnlinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityUnlinkResponse) ProtoMessage() {}
+
+func (x *IdentityUnlinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityUnlinkResponse.ProtoReflect.Descriptor instead.
+func (*IdentityUnlinkResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *IdentityUnlinkResponse) GetResponse() isIdentityUnlinkResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *IdentityUnlinkResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*IdentityUnlinkResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *IdentityUnlinkResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*IdentityUnlinkResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isIdentityUnlinkResponse_Response interface {
+	isIdentityUnlinkResponse_Response()
+}
+
+type IdentityUnlinkResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type IdentityUnlinkResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*IdentityUnlinkResponse_Data) isIdentityUnlinkResponse_Response() {}
+
+func (*IdentityUnlinkResponse_Error) isIdentityUnlinkResponse_Response() {}
+
+type SessionsListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionsListRequest) Reset() {
+	*x = SessionsListRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionsListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionsListRequest) ProtoMessage() {}
+
+func (x *SessionsListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionsListRequest.ProtoReflect.Descriptor instead.
+func (*SessionsListRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{43}
+}
+
+type SessionsListResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*SessionsListResponse_Data
+	//	*SessionsListResponse_Error
+	Response isSessionsListResponse_Response `protobuf_oneof:"response"`
+	// sessions are set along with data, the last seen first
+	Sessions      []*Session `protobuf:"bytes,3,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionsListResponse) Reset() {
+	*x = SessionsListResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionsListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionsListResponse) ProtoMessage() {}
+
+func (x *SessionsListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionsListResponse.ProtoReflect.Descriptor instead.
+func (*SessionsListResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SessionsListResponse) GetResponse() isSessionsListResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *SessionsListResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*SessionsListResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *SessionsListResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*SessionsListResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *SessionsListResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type isSessionsListResponse_Response interface {
+	isSessionsListResponse_Response()
+}
+
+type SessionsListResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type SessionsListResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*SessionsListResponse_Data) isSessionsListResponse_Response() {}
+
+func (*SessionsListResponse_Error) isSessionsListResponse_Response() {}
+
+// Session is a login session of the user on a device
+type Session struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeviceId   string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	Platform   string                 `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	UserAgent  string                 `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	IpAddress  string                 `protobuf:"bytes,5,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	CreatedAt  int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastSeenAt int64                  `protobuf:"varint,7,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	// current is set for the session that the request was sent with
+	Current       bool `protobuf:"varint,8,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_users_v1_account_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Session) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Session) GetLastSeenAt() int64 {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return 0
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type SessionRevokeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRevokeRequest) Reset() {
+	*x = SessionRevokeRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRevokeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRevokeRequest) ProtoMessage() {}
+
+func (x *SessionRevokeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRevokeRequest.ProtoReflect.Descriptor instead.
+func (*SessionRevokeRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SessionRevokeRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type SessionRevokeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*SessionRevokeResponse_Data
+	//	*SessionRevokeResponse_Error
+	Response      isSessionRevokeResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionRevokeResponse) Reset() {
+	*x = SessionRevokeResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionRevokeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionRevokeResponse) ProtoMessage() {}
+
+func (x *SessionRevokeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionRevokeResponse.ProtoReflect.Descriptor instead.
+func (*SessionRevokeResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SessionRevokeResponse) GetResponse() isSessionRevokeResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *SessionRevokeResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*SessionRevokeResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *SessionRevokeResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*SessionRevokeResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isSessionRevokeResponse_Response interface {
+	isSessionRevokeResponse_Response()
+}
+
+type SessionRevokeResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type SessionRevokeResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*SessionRevokeResponse_Data) isSessionRevokeResponse_Response() {}
+
+func (*SessionRevokeResponse_Error) isSessionRevokeResponse_Response() {}
+
+type SessionsRevokeOthersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionsRevokeOthersRequest) Reset() {
+	*x = SessionsRevokeOthersRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionsRevokeOthersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionsRevokeOthersRequest) ProtoMessage() {}
+
+func (x *SessionsRevokeOthersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionsRevokeOthersRequest.ProtoReflect.Descriptor instead.
+func (*SessionsRevokeOthersRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{48}
+}
+
+type SessionsRevokeOthersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*SessionsRevokeOthersResponse_Data
+	//	*SessionsRevokeOthersResponse_Error
+	Response      isSessionsRevokeOthersResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionsRevokeOthersResponse) Reset() {
+	*x = SessionsRevokeOthersResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionsRevokeOthersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionsRevokeOthersResponse) ProtoMessage() {}
+
+func (x *SessionsRevokeOthersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionsRevokeOthersResponse.ProtoReflect.Descriptor instead.
+func (*SessionsRevokeOthersResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SessionsRevokeOthersResponse) GetResponse() isSessionsRevokeOthersResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *SessionsRevokeOthersResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*SessionsRevokeOthersResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *SessionsRevokeOthersResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*SessionsRevokeOthersResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isSessionsRevokeOthersResponse_Response interface {
+	isSessionsRevokeOthersResponse_Response()
+}
+
+type SessionsRevokeOthersResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type SessionsRevokeOthersResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*SessionsRevokeOthersResponse_Data) isSessionsRevokeOthersResponse_Response() {}
+
+func (*SessionsRevokeOthersResponse_Error) isSessionsRevokeOthersResponse_Response() {}
+
+type LoginNotMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenId       string                 `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginNotMeRequest) Reset() {
+	*x = LoginNotMeRequest{}
+	mi := &file_users_v1_account_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginNotMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginNotMeRequest) ProtoMessage() {}
+
+func (x *LoginNotMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginNotMeRequest.ProtoReflect.Descriptor instead.
+func (*LoginNotMeRequest) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *LoginNotMeRequest) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *LoginNotMeRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type LoginNotMeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*LoginNotMeResponse_Data
+	//	*LoginNotMeResponse_Error
+	Response      isLoginNotMeResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginNotMeResponse) Reset() {
+	*x = LoginNotMeResponse{}
+	mi := &file_users_v1_account_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginNotMeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginNotMeResponse) ProtoMessage() {}
+
+func (x *LoginNotMeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_v1_account_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginNotMeResponse.ProtoReflect.Descriptor instead.
+func (*LoginNotMeResponse) Descriptor() ([]byte, []int) {
+	return file_users_v1_account_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *LoginNotMeResponse) GetResponse() isLoginNotMeResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *LoginNotMeResponse) GetData() *v1.SuccessResponseData {
+	if x != nil {
+		if x, ok := x.Response.(*LoginNotMeResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *LoginNotMeResponse) GetError() *v1.AppError {
+	if x != nil {
+		if x, ok := x.Response.(*LoginNotMeResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isLoginNotMeResponse_Response interface {
+	isLoginNotMeResponse_Response()
+}
+
+type LoginNotMeResponse_Data struct {
+	Data *v1.SuccessResponseData `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type LoginNotMeResponse_Error struct {
+	Error *v1.AppError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*LoginNotMeResponse_Data) isLoginNotMeResponse_Response() {}
+
+func (*LoginNotMeResponse_Error) isLoginNotMeResponse_Response() {}
+
+var File_users_v1_account_proto protoreflect.FileDescriptor
+
+const file_users_v1_account_proto_rawDesc = "" +
+	"\n" +
+	"\x16users/v1/account.proto\x12\busers.v1\x1a\x15shared/v1/error.proto\x1a\x15shared/v1/types.proto\"\x12\n" +
+	"\x10MfaEnrollRequest\"\x82\x01\n" +
+	"\x11MfaEnrollResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"'\n" +
+	"\x11MfaConfirmRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\xaa\x01\n" +
+	"\x12MfaConfirmResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05error\x12%\n" +
+	"\x0erecovery_codes\x18\x03 \x03(\tR\rrecoveryCodesB\n" +
+	"\n" +
+	"\bresponse\"/\n" +
+	"\x11MfaDisableRequest\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\"\x83\x01\n" +
+	"\x12MfaDisableResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"?\n" +
+	"!MfaRecoveryCodesRegenerateRequest\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\"\xba\x01\n" +
+	"\"MfaRecoveryCodesRegenerateResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05error\x12%\n" +
+	"\x0erecovery_codes\x18\x03 \x03(\tR\rrecoveryCodesB\n" +
+	"\n" +
+	"\bresponse\"\x1e\n" +
+	"\x1cWebauthnRegisterBeginRequest\"\x8e\x01\n" +
+	"\x1dWebauthnRegisterBeginResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"r\n" +
+	"\x1dWebauthnRegisterFinishRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x03 \x01(\tR\n" +
+	"credential\"\x8f\x01\n" +
+	"\x1eWebauthnRegisterFinishResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"1\n" +
+	"\x19WebauthnLoginBeginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\x8b\x01\n" +
+	"\x1aWebauthnLoginBeginResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"\x84\x01\n" +
+	"\x1aWebauthnLoginFinishRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x02 \x01(\tR\n" +
+	"credential\x12'\n" +
+	"\x0flogin_challenge\x18\x03 \x01(\tR\x0eloginChallenge\"\x8c\x01\n" +
+	"\x1bWebauthnLoginFinishResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"c\n" +
+	"\x14PasswordResetRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\tR\atokenId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"\x86\x01\n" +
+	"\x15PasswordResetResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"e\n" +
+	"\x15ChangePasswordRequest\x12)\n" +
+	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"\x87\x01\n" +
+	"\x16ChangePasswordResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"6\n" +
+	"\x1eResendVerificationEmailRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\x90\x01\n" +
+	"\x1fResendVerificationEmailResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"k\n" +
+	"\x16EmailLoginBeginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12'\n" +
+	"\x0flogin_challenge\x18\x02 \x01(\tR\x0eloginChallenge\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\"\x88\x01\n" +
+	"\x17EmailLoginBeginResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"\xaf\x01\n" +
+	"\x17EmailLoginFinishRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12'\n" +
+	"\x0flogin_challenge\x18\x02 \x01(\tR\x0eloginChallenge\x12\x19\n" +
+	"\btoken_id\x18\x03 \x01(\tR\atokenId\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\x12\x12\n" +
+	"\x04code\x18\x05 \x01(\tR\x04code\x12\x10\n" +
+	"\x03mfa\x18\x06 \x01(\tR\x03mfa\"\x89\x01\n" +
+	"\x18EmailLoginFinishResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"0\n" +
+	"\rLogoutRequest\x12\x1f\n" +
+	"\vall_devices\x18\x01 \x01(\bR\n" +
+	"allDevices\"\x7f\n" +
+	"\x0eLogoutResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"@\n" +
+	"\x11ConsentGetRequest\x12+\n" +
+	"\x11consent_challenge\x18\x01 \x01(\tR\x10consentChallenge\"\x83\x01\n" +
+	"\x12ConsentGetResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"\x80\x01\n" +
+	"\x14ConsentAcceptRequest\x12+\n" +
+	"\x11consent_challenge\x18\x01 \x01(\tR\x10consentChallenge\x12\x1f\n" +
+	"\vgrant_scope\x18\x02 \x03(\tR\n" +
+	"grantScope\x12\x1a\n" +
+	"\bremember\x18\x03 \x01(\bR\bremember\"\x86\x01\n" +
+	"\x15ConsentAcceptResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"C\n" +
+	"\x14ConsentRejectRequest\x12+\n" +
+	"\x11consent_challenge\x18\x01 \x01(\tR\x10consentChallenge\"\x86\x01\n" +
+	"\x15ConsentRejectResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"\x17\n" +
+	"\x15IdentitiesListRequest\"\xbb\x01\n" +
+	"\x16IdentitiesListResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05error\x122\n" +
+	"\n" +
+	"identities\x18\x03 \x03(\v2\x12.users.v1.IdentityR\n" +
+	"identitiesB\n" +
+	"\n" +
+	"\bresponse\"k\n" +
+	"\bIdentity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\"d\n" +
+	"\x18IdentityLinkBeginRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x10\n" +
+	"\x03mfa\x18\x03 \x01(\tR\x03mfa\"\x8a\x01\n" +
+	"\x19IdentityLinkBeginResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"5\n" +
+	"\x1aIdentityLinkConfirmRequest\x12\x17\n" +
+	"\alink_id\x18\x01 \x01(\tR\x06linkId\"\x8c\x01\n" +
+	"\x1bIdentityLinkConfirmResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"8\n" +
+	"\x15IdentityUnlinkRequest\x12\x1f\n" +
+	"\videntity_id\x18\x01 \x01(\tR\n" +
+	"identityId\"\x87\x01\n" +
+	"\x16IdentityUnlinkResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"\x15\n" +
+	"\x13SessionsListRequest\"\xb4\x01\n" +
+	"\x14SessionsListResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05error\x12-\n" +
+	"\bsessions\x18\x03 \x03(\v2\x11.users.v1.SessionR\bsessionsB\n" +
+	"\n" +
+	"\bresponse\"\xeb\x01\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x1a\n" +
+	"\bplatform\x18\x03 \x01(\tR\bplatform\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x04 \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x05 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12 \n" +
+	"\flast_seen_at\x18\a \x01(\x03R\n" +
+	"lastSeenAt\x12\x18\n" +
+	"\acurrent\x18\b \x01(\bR\acurrent\"5\n" +
+	"\x14SessionRevokeRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"\x86\x01\n" +
+	"\x15SessionRevokeResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"\x1d\n" +
+	"\x1bSessionsRevokeOthersRequest\"\x8d\x01\n" +
+	"\x1cSessionsRevokeOthersResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse\"D\n" +
+	"\x11LoginNotMeRequest\x12\x19\n" +
+	"\btoken_id\x18\x01 \x01(\tR\atokenId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x83\x01\n" +
+	"\x12LoginNotMeResponse\x124\n" +
+	"\x04data\x18\x01 \x01(\v2\x1e.shared.v1.SuccessResponseDataH\x00R\x04data\x12+\n" +
+	"\x05error\x18\x02 \x01(\v2\x13.shared.v1.AppErrorH\x00R\x05errorB\n" +
+	"\n" +
+	"\bresponse2\xb3\x11\n" +
+	"\x13UsersAccountService\x12D\n" +
+	"\tMfaEnroll\x12\x1a.users.v1.MfaEnrollRequest\x1a\x1b.users.v1.MfaEnrollResponse\x12G\n" +
+	"\n" +
+	"MfaConfirm\x12\x1b.users.v1.MfaConfirmRequest\x1a\x1c.users.v1.MfaConfirmResponse\x12G\n" +
+	"\n" +
+	"MfaDisable\x12\x1b.users.v1.MfaDisableRequest\x1a\x1c.users.v1.MfaDisableResponse\x12w\n" +
+	"\x1aMfaRecoveryCodesRegenerate\x12+.users.v1.MfaRecoveryCodesRegenerateRequest\x1a,.users.v1.MfaRecoveryCodesRegenerateResponse\x12h\n" +
+	"\x15WebauthnRegisterBegin\x12&.users.v1.WebauthnRegisterBeginRequest\x1a'.users.v1.WebauthnRegisterBeginResponse\x12k\n" +
+	"\x16WebauthnRegisterFinish\x12'.users.v1.WebauthnRegisterFinishRequest\x1a(.users.v1.WebauthnRegisterFinishResponse\x12_\n" +
+	"\x12WebauthnLoginBegin\x12#.users.v1.WebauthnLoginBeginRequest\x1a$.users.v1.WebauthnLoginBeginResponse\x12b\n" +
+	"\x13WebauthnLoginFinish\x12$.users.v1.WebauthnLoginFinishRequest\x1a%.users.v1.WebauthnLoginFinishResponse\x12P\n" +
+	"\rPasswordReset\x12\x1e.users.v1.PasswordResetRequest\x1a\x1f.users.v1.PasswordResetResponse\x12S\n" +
+	"\x0eChangePassword\x12\x1f.users.v1.ChangePasswordRequest\x1a .users.v1.ChangePasswordResponse\x12n\n" +
+	"\x17ResendVerificationEmail\x12(.users.v1.ResendVerificationEmailRequest\x1a).users.v1.ResendVerificationEmailResponse\x12V\n" +
+	"\x0fEmailLoginBegin\x12 .users.v1.EmailLoginBeginRequest\x1a!.users.v1.EmailLoginBeginResponse\x12Y\n" +
+	"\x10EmailLoginFinish\x12!.users.v1.EmailLoginFinishRequest\x1a\".users.v1.EmailLoginFinishResponse\x12;\n" +
+	"\x06Logout\x12\x17.users.v1.LogoutRequest\x1a\x18.users.v1.LogoutResponse\x12G\n" +
+	"\n" +
+	"ConsentGet\x12\x1b.users.v1.ConsentGetRequest\x1a\x1c.users.v1.ConsentGetResponse\x12P\n" +
+	"\rConsentAccept\x12\x1e.users.v1.ConsentAcceptRequest\x1a\x1f.users.v1.ConsentAcceptResponse\x12P\n" +
+	"\rConsentReject\x12\x1e.users.v1.ConsentRejectRequest\x1a\x1f.users.v1.ConsentRejectResponse\x12S\n" +
+	"\x0eIdentitiesList\x12\x1f.users.v1.IdentitiesListRequest\x1a .users.v1.IdentitiesListResponse\x12\\\n" +
+	"\x11IdentityLinkBegin\x12\".users.v1.IdentityLinkBeginRequest\x1a#.users.v1.IdentityLinkBeginResponse\x12b\n" +
+	"\x13IdentityLinkConfirm\x12$.users.v1.IdentityLinkConfirmRequest\x1a%.users.v1.IdentityLinkConfirmResponse\x12S\n" +
+	"\x0eIdentityUnlink\x12\x1f.users.v1.IdentityUnlinkRequest\x1a .users.v1.IdentityUnlinkResponse\x12M\n" +
+	"\fSessionsList\x12\x1d.users.v1.SessionsListRequest\x1a\x1e.users.v1.SessionsListResponse\x12P\n" +
+	"\rSessionRevoke\x12\x1e.users.v1.SessionRevokeRequest\x1a\x1f.users.v1.SessionRevokeResponse\x12e\n" +
+	"\x14SessionsRevokeOthers\x12%.users.v1.SessionsRevokeOthersRequest\x1a&.users.v1.SessionsRevokeOthersResponse\x12G\n" +
+	"\n" +
+	"LoginNotMe\x12\x1b.users.v1.LoginNotMeRequest\x1a\x1c.users.v1.LoginNotMeResponseBo\n" +
+	"\x19org.megacommerce.users.v1B\fAccountProtoZAgithub.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1;v1\xf8\x01\x01b\x06proto3"
+
+var (
+	file_users_v1_account_proto_rawDescOnce sync.Once
+	file_users_v1_account_proto_rawDescData []byte
+)
+
+func file_users_v1_account_proto_rawDescGZIP() []byte {
+	file_users_v1_account_proto_rawDescOnce.Do(func() {
+		file_users_v1_account_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_users_v1_account_proto_rawDesc), len(file_users_v1_account_proto_rawDesc)))
+	})
+	return file_users_v1_account_proto_rawDescData
+}
+
+var file_users_v1_account_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
+var file_users_v1_account_proto_goTypes = []any{
+	(*MfaEnrollRequest)(nil),                   // 0: users.v1.MfaEnrollRequest
+	(*MfaEnrollResponse)(nil),                  // 1: users.v1.MfaEnrollResponse
+	(*MfaConfirmRequest)(nil),                  // 2: users.v1.MfaConfirmRequest
+	(*MfaConfirmResponse)(nil),                 // 3: users.v1.MfaConfirmResponse
+	(*MfaDisableRequest)(nil),                  // 4: users.v1.MfaDisableRequest
+	(*MfaDisableResponse)(nil),                 // 5: users.v1.MfaDisableResponse
+	(*MfaRecoveryCodesRegenerateRequest)(nil),  // 6: users.v1.MfaRecoveryCodesRegenerateRequest
+	(*MfaRecoveryCodesRegenerateResponse)(nil), // 7: users.v1.MfaRecoveryCodesRegenerateResponse
+	(*WebauthnRegisterBeginRequest)(nil),       // 8: users.v1.WebauthnRegisterBeginRequest
+	(*WebauthnRegisterBeginResponse)(nil),      // 9: users.v1.WebauthnRegisterBeginResponse
+	(*WebauthnRegisterFinishRequest)(nil),      // 10: users.v1.WebauthnRegisterFinishRequest
+	(*WebauthnRegisterFinishResponse)(nil),     // 11: users.v1.WebauthnRegisterFinishResponse
+	(*WebauthnLoginBeginRequest)(nil),          // 12: users.v1.WebauthnLoginBeginRequest
+	(*WebauthnLoginBeginResponse)(nil),         // 13: users.v1.WebauthnLoginBeginResponse
+	(*WebauthnLoginFinishRequest)(nil),         // 14: users.v1.WebauthnLoginFinishRequest
+	(*WebauthnLoginFinishResponse)(nil),        // 15: users.v1.WebauthnLoginFinishResponse
+	(*PasswordResetRequest)(nil),               // 16: users.v1.PasswordResetRequest
+	(*PasswordResetResponse)(nil),              // 17: users.v1.PasswordResetResponse
+	(*ChangePasswordRequest)(nil),              // 18: users.v1.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),             // 19: users.v1.ChangePasswordResponse
+	(*ResendVerificationEmailRequest)(nil),     // 20: users.v1.ResendVerificationEmailRequest
+	(*ResendVerificationEmailResponse)(nil),    // 21: users.v1.ResendVerificationEmailResponse
+	(*EmailLoginBeginRequest)(nil),             // 22: users.v1.EmailLoginBeginRequest
+	(*EmailLoginBeginResponse)(nil),            // 23: users.v1.EmailLoginBeginResponse
+	(*EmailLoginFinishRequest)(nil),            // 24: users.v1.EmailLoginFinishRequest
+	(*EmailLoginFinishResponse)(nil),           // 25: users.v1.EmailLoginFinishResponse
+	(*LogoutRequest)(nil),                      // 26: users.v1.LogoutRequest
+	(*LogoutResponse)(nil),                     // 27: users.v1.LogoutResponse
+	(*ConsentGetRequest)(nil),                  // 28: users.v1.ConsentGetRequest
+	(*ConsentGetResponse)(nil),                 // 29: users.v1.ConsentGetResponse
+	(*ConsentAcceptRequest)(nil),               // 30: users.v1.ConsentAcceptRequest
+	(*ConsentAcceptResponse)(nil),              // 31: users.v1.ConsentAcceptResponse
+	(*ConsentRejectRequest)(nil),               // 32: users.v1.ConsentRejectRequest
+	(*ConsentRejectResponse)(nil),              // 33: users.v1.ConsentRejectResponse
+	(*IdentitiesListRequest)(nil),              // 34: users.v1.IdentitiesListRequest
+	(*IdentitiesListResponse)(nil),             // 35: users.v1.IdentitiesListResponse
+	(*Identity)(nil),                           // 36: users.v1.Identity
+	(*IdentityLinkBeginRequest)(nil),           // 37: users.v1.IdentityLinkBeginRequest
+	(*IdentityLinkBeginResponse)(nil),          // 38: users.v1.IdentityLinkBeginResponse
+	(*IdentityLinkConfirmRequest)(nil),         // 39: users.v1.IdentityLinkConfirmRequest
+	(*IdentityLinkConfirmResponse)(nil),        // 40: users.v1.IdentityLinkConfirmResponse
+	(*IdentityUnlinkRequest)(nil),              // 41: users.v1.IdentityUnlinkRequest
+	(*IdentityUnlinkResponse)(nil),             // 42: users.v1.IdentityUnlinkResponse
+	(*SessionsListRequest)(nil),                // 43: users.v1.SessionsListRequest
+	(*SessionsListResponse)(nil),               // 44: users.v1.SessionsListResponse
+	(*Session)(nil),                            // 45: users.v1.Session
+	(*SessionRevokeRequest)(nil),               // 46: users.v1.SessionRevokeRequest
+	(*SessionRevokeResponse)(nil),              // 47: users.v1.SessionRevokeResponse
+	(*SessionsRevokeOthersRequest)(nil),        // 48: users.v1.SessionsRevokeOthersRequest
+	(*SessionsRevokeOthersResponse)(nil),       // 49: users.v1.SessionsRevokeOthersResponse
+	(*LoginNotMeRequest)(nil),                  // 50: users.v1.LoginNotMeRequest
+	(*LoginNotMeResponse)(nil),                 // 51: users.v1.LoginNotMeResponse
+	(*v1.SuccessResponseData)(nil),             // 52: shared.v1.SuccessResponseData
+	(*v1.AppError)(nil),                        // 53: shared.v1.AppError
+}
+var file_users_v1_account_proto_depIdxs = []int32{
+	52, // 0: users.v1.MfaEnrollResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 1: users.v1.MfaEnrollResponse.error:type_name -> shared.v1.AppError
+	52, // 2: users.v1.MfaConfirmResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 3: users.v1.MfaConfirmResponse.error:type_name -> shared.v1.AppError
+	52, // 4: users.v1.MfaDisableResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 5: users.v1.MfaDisableResponse.error:type_name -> shared.v1.AppError
+	52, // 6: users.v1.MfaRecoveryCodesRegenerateResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 7: users.v1.MfaRecoveryCodesRegenerateResponse.error:type_name -> shared.v1.AppError
+	52, // 8: users.v1.WebauthnRegisterBeginResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 9: users.v1.WebauthnRegisterBeginResponse.error:type_name -> shared.v1.AppError
+	52, // 10: users.v1.WebauthnRegisterFinishResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 11: users.v1.WebauthnRegisterFinishResponse.error:type_name -> shared.v1.AppError
+	52, // 12: users.v1.WebauthnLoginBeginResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 13: users.v1.WebauthnLoginBeginResponse.error:type_name -> shared.v1.AppError
+	52, // 14: users.v1.WebauthnLoginFinishResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 15: users.v1.WebauthnLoginFinishResponse.error:type_name -> shared.v1.AppError
+	52, // 16: users.v1.PasswordResetResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 17: users.v1.PasswordResetResponse.error:type_name -> shared.v1.AppError
+	52, // 18: users.v1.ChangePasswordResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 19: users.v1.ChangePasswordResponse.error:type_name -> shared.v1.AppError
+	52, // 20: users.v1.ResendVerificationEmailResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 21: users.v1.ResendVerificationEmailResponse.error:type_name -> shared.v1.AppError
+	52, // 22: users.v1.EmailLoginBeginResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 23: users.v1.EmailLoginBeginResponse.error:type_name -> shared.v1.AppError
+	52, // 24: users.v1.EmailLoginFinishResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 25: users.v1.EmailLoginFinishResponse.error:type_name -> shared.v1.AppError
+	52, // 26: users.v1.LogoutResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 27: users.v1.LogoutResponse.error:type_name -> shared.v1.AppError
+	52, // 28: users.v1.ConsentGetResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 29: users.v1.ConsentGetResponse.error:type_name -> shared.v1.AppError
+	52, // 30: users.v1.ConsentAcceptResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 31: users.v1.ConsentAcceptResponse.error:type_name -> shared.v1.AppError
+	52, // 32: users.v1.ConsentRejectResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 33: users.v1.ConsentRejectResponse.error:type_name -> shared.v1.AppError
+	52, // 34: users.v1.IdentitiesListResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 35: users.v1.IdentitiesListResponse.error:type_name -> shared.v1.AppError
+	36, // 36: users.v1.IdentitiesListResponse.identities:type_name -> users.v1.Identity
+	52, // 37: users.v1.IdentityLinkBeginResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 38: users.v1.IdentityLinkBeginResponse.error:type_name -> shared.v1.AppError
+	52, // 39: users.v1.IdentityLinkConfirmResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 40: users.v1.IdentityLinkConfirmResponse.error:type_name -> shared.v1.AppError
+	52, // 41: users.v1.IdentityUnlinkResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 42: users.v1.IdentityUnlinkResponse.error:type_name -> shared.v1.AppError
+	52, // 43: users.v1.SessionsListResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 44: users.v1.SessionsListResponse.error:type_name -> shared.v1.AppError
+	45, // 45: users.v1.SessionsListResponse.sessions:type_name -> users.v1.Session
+	52, // 46: users.v1.SessionRevokeResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 47: users.v1.SessionRevokeResponse.error:type_name -> shared.v1.AppError
+	52, // 48: users.v1.SessionsRevokeOthersResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 49: users.v1.SessionsRevokeOthersResponse.error:type_name -> shared.v1.AppError
+	52, // 50: users.v1.LoginNotMeResponse.data:type_name -> shared.v1.SuccessResponseData
+	53, // 51: users.v1.LoginNotMeResponse.error:type_name -> shared.v1.AppError
+	0,  // 52: users.v1.UsersAccountService.MfaEnroll:input_type -> users.v1.MfaEnrollRequest
+	2,  // 53: users.v1.UsersAccountService.MfaConfirm:input_type -> users.v1.MfaConfirmRequest
+	4,  // 54: users.v1.UsersAccountService.MfaDisable:input_type -> users.v1.MfaDisableRequest
+	6,  // 55: users.v1.UsersAccountService.MfaRecoveryCodesRegenerate:input_type -> users.v1.MfaRecoveryCodesRegenerateRequest
+	8,  // 56: users.v1.UsersAccountService.WebauthnRegisterBegin:input_type -> users.v1.WebauthnRegisterBeginRequest
+	10, // 57: users.v1.UsersAccountService.WebauthnRegisterFinish:input_type -> users.v1.WebauthnRegisterFinishRequest
+	12, // 58: users.v1.UsersAccountService.WebauthnLoginBegin:input_type -> users.v1.WebauthnLoginBeginRequest
+	14, // 59: users.v1.UsersAccountService.WebauthnLoginFinish:input_type -> users.v1.WebauthnLoginFinishRequest
+	16, // 60: users.v1.UsersAccountService.PasswordReset:input_type -> users.v1.PasswordResetRequest
+	18, // 61: users.v1.UsersAccountService.ChangePassword:input_type -> users.v1.ChangePasswordRequest
+	20, // 62: users.v1.UsersAccountService.ResendVerificationEmail:input_type -> users.v1.ResendVerificationEmailRequest
+	22, // 63: users.v1.UsersAccountService.EmailLoginBegin:input_type -> users.v1.EmailLoginBeginRequest
+	24, // 64: users.v1.UsersAccountService.EmailLoginFinish:input_type -> users.v1.EmailLoginFinishRequest
+	26, // 65: users.v1.UsersAccountService.Logout:input_type -> users.v1.LogoutRequest
+	28, // 66: users.v1.UsersAccountService.ConsentGet:input_type -> users.v1.ConsentGetRequest
+	30, // 67: users.v1.UsersAccountService.ConsentAccept:input_type -> users.v1.ConsentAcceptRequest
+	32, // 68: users.v1.UsersAccountService.ConsentReject:input_type -> users.v1.ConsentRejectRequest
+	34, // 69: users.v1.UsersAccountService.IdentitiesList:input_type -> users.v1.IdentitiesListRequest
+	37, // 70: users.v1.UsersAccountService.IdentityLinkBegin:input_type -> users.v1.IdentityLinkBeginRequest
+	39, // 71: users.v1.UsersAccountService.IdentityLinkConfirm:input_type -> users.v1.IdentityLinkConfirmRequest
+	41, // 72: users.v1.UsersAccountService.IdentityUnlink:input_type -> users.v1.IdentityUnlinkRequest
+	43, // 73: users.v1.UsersAccountService.SessionsList:input_type -> users.v1.SessionsListRequest
+	46, // 74: users.v1.UsersAccountService.SessionRevoke:input_type -> users.v1.SessionRevokeRequest
+	48, // 75: users.v1.UsersAccountService.SessionsRevokeOthers:input_type -> users.v1.SessionsRevokeOthersRequest
+	50, // 76: users.v1.UsersAccountService.LoginNotMe:input_type -> users.v1.LoginNotMeRequest
+	1,  // 77: users.v1.UsersAccountService.MfaEnroll:output_type -> users.v1.MfaEnrollResponse
+	3,  // 78: users.v1.UsersAccountService.MfaConfirm:output_type -> users.v1.MfaConfirmResponse
+	5,  // 79: users.v1.UsersAccountService.MfaDisable:output_type -> users.v1.MfaDisableResponse
+	7,  // 80: users.v1.UsersAccountService.MfaRecoveryCodesRegenerate:output_type -> users.v1.MfaRecoveryCodesRegenerateResponse
+	9,  // 81: users.v1.UsersAccountService.WebauthnRegisterBegin:output_type -> users.v1.WebauthnRegisterBeginResponse
+	11, // 82: users.v1.UsersAccountService.WebauthnRegisterFinish:output_type -> users.v1.WebauthnRegisterFinishResponse
+	13, // 83: users.v1.UsersAccountService.WebauthnLoginBegin:output_type -> users.v1.WebauthnLoginBeginResponse
+	15, // 84: users.v1.UsersAccountService.WebauthnLoginFinish:output_type -> users.v1.WebauthnLoginFinishResponse
+	17, // 85: users.v1.UsersAccountService.PasswordReset:output_type -> users.v1.PasswordResetResponse
+	19, // 86: users.v1.UsersAccountService.ChangePassword:output_type -> users.v1.ChangePasswordResponse
+	21, // 87: users.v1.UsersAccountService.ResendVerificationEmail:output_type -> users.v1.ResendVerificationEmailResponse
+	23, // 88: users.v1.UsersAccountService.EmailLoginBegin:output_type -> users.v1.EmailLoginBeginResponse
+	25, // 89: users.v1.UsersAccountService.EmailLoginFinish:output_type -> users.v1.EmailLoginFinishResponse
+	27, // 90: users.v1.UsersAccountService.Logout:output_type -> users.v1.LogoutResponse
+	29, // 91: users.v1.UsersAccountService.ConsentGet:output_type -> users.v1.ConsentGetResponse
+	31, // 92: users.v1.UsersAccountService.ConsentAccept:output_type -> users.v1.ConsentAcceptResponse
+	33, // 93: users.v1.UsersAccountService.ConsentReject:output_type -> users.v1.ConsentRejectResponse
+	35, // 94: users.v1.UsersAccountService.IdentitiesList:output_type -> users.v1.IdentitiesListResponse
+	38, // 95: users.v1.UsersAccountService.IdentityLinkBegin:output_type -> users.v1.IdentityLinkBeginResponse
+	40, // 96: users.v1.UsersAccountService.IdentityLinkConfirm:output_type -> users.v1.IdentityLinkConfirmResponse
+	42, // 97: users.v1.UsersAccountService.IdentityUnlink:output_type -> users.v1.IdentityUnlinkResponse
+	44, // 98: users.v1.UsersAccountService.SessionsList:output_type -> users.v1.SessionsListResponse
+	47, // 99: users.v1.UsersAccountService.SessionRevoke:output_type -> users.v1.SessionRevokeResponse
+	49, // 100: users.v1.UsersAccountService.SessionsRevokeOthers:output_type -> users.v1.SessionsRevokeOthersResponse
+	51, // 101: users.v1.UsersAccountService.LoginNotMe:output_type -> users.v1.LoginNotMeResponse
+	77, // [77:102] is the sub-list for method output_type
+	52, // [52:77] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
+}
+
+func init() { file_users_v1_account_proto_init() }
+func file_users_v1_account_proto_init() {
+	if File_users_v1_account_proto != nil {
+		return
+	}
+	file_users_v1_account_proto_msgTypes[1].OneofWrappers = []any{
+		(*MfaEnrollResponse_Data)(nil),
+		(*MfaEnrollResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[3].OneofWrappers = []any{
+		(*MfaConfirmResponse_Data)(nil),
+		(*MfaConfirmResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[5].OneofWrappers = []any{
+		(*MfaDisableResponse_Data)(nil),
+		(*MfaDisableResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[7].OneofWrappers = []any{
+		(*MfaRecoveryCodesRegenerateResponse_Data)(nil),
+		(*MfaRecoveryCodesRegenerateResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[9].OneofWrappers = []any{
+		(*WebauthnRegisterBeginResponse_Data)(nil),
+		(*WebauthnRegisterBeginResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[11].OneofWrappers = []any{
+		(*WebauthnRegisterFinishResponse_Data)(nil),
+		(*WebauthnRegisterFinishResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[13].OneofWrappers = []any{
+		(*WebauthnLoginBeginResponse_Data)(nil),
+		(*WebauthnLoginBeginResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[15].OneofWrappers = []any{
+		(*WebauthnLoginFinishResponse_Data)(nil),
+		(*WebauthnLoginFinishResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[17].OneofWrappers = []any{
+		(*PasswordResetResponse_Data)(nil),
+		(*PasswordResetResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[19].OneofWrappers = []any{
+		(*ChangePasswordResponse_Data)(nil),
+		(*ChangePasswordResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[21].OneofWrappers = []any{
+		(*ResendVerificationEmailResponse_Data)(nil),
+		(*ResendVerificationEmailResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[23].OneofWrappers = []any{
+		(*EmailLoginBeginResponse_Data)(nil),
+		(*EmailLoginBeginResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[25].OneofWrappers = []any{
+		(*EmailLoginFinishResponse_Data)(nil),
+		(*EmailLoginFinishResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[27].OneofWrappers = []any{
+		(*LogoutResponse_Data)(nil),
+		(*LogoutResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[29].OneofWrappers = []any{
+		(*ConsentGetResponse_Data)(nil),
+		(*ConsentGetResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[31].OneofWrappers = []any{
+		(*ConsentAcceptResponse_Data)(nil),
+		(*ConsentAcceptResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[33].OneofWrappers = []any{
+		(*ConsentRejectResponse_Data)(nil),
+		(*ConsentRejectResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[35].OneofWrappers = []any{
+		(*IdentitiesListResponse_Data)(nil),
+		(*IdentitiesListResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[38].OneofWrappers = []any{
+		(*IdentityLinkBeginResponse_Data)(nil),
+		(*IdentityLinkBeginResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[40].OneofWrappers = []any{
+		(*IdentityLinkConfirmResponse_Data)(nil),
+		(*IdentityLinkConfirmResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[42].OneofWrappers = []any{
+		(*IdentityUnlinkResponse_Data)(nil),
+		(*IdentityUnlinkResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[44].OneofWrappers = []any{
+		(*SessionsListResponse_Data)(nil),
+		(*SessionsListResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[47].OneofWrappers = []any{
+		(*SessionRevokeResponse_Data)(nil),
+		(*SessionRevokeResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[49].OneofWrappers = []any{
+		(*SessionsRevokeOthersResponse_Data)(nil),
+		(*SessionsRevokeOthersResponse_Error)(nil),
+	}
+	file_users_v1_account_proto_msgTypes[51].OneofWrappers = []any{
+		(*LoginNotMeResponse_Data)(nil),
+		(*LoginNotMeResponse_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_users_v1_account_proto_rawDesc), len(file_users_v1_account_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   52,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_users_v1_account_proto_goTypes,
+		DependencyIndexes: file_users_v1_account_proto_depIdxs,
+		MessageInfos:      file_users_v1_account_proto_msgTypes,
+	}.Build()
+	File_users_v1_account_proto = out.File
+	file_users_v1_account_proto_goTypes = nil
+	file_users_v1_account_proto_depIdxs = nil
+}
@@ -0,0 +1,57 @@
+// Package captcha verifies the bot challenge tokens solved by the clients, through the
+// siteverify API of the configured provider (Turnstile, hCaptcha or reCAPTCHA)
+package captcha
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+// the supported providers, ProviderStub accepts a fixed token without any HTTP call, it is
+// meant for the tests and the local environments
+const (
+	ProviderTurnstile = "turnstile"
+	ProviderHCaptcha  = "hcaptcha"
+	ProviderReCaptcha = "recaptcha"
+	ProviderStub      = "stub"
+)
+
+// ErrInvalid is returned when the provider rejects the token (E,g expired, already used,
+// or the reCAPTCHA score is too low), the other errors mean the token couldn't be checked
+var ErrInvalid = errors.New("the challenge token is invalid")
+
+// Verifier checks a challenge token solved by the client at remoteIP
+type Verifier interface {
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+var verifyURLs = map[string]string{
+	ProviderTurnstile: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+	ProviderHCaptcha:  "https://api.hcaptcha.com/siteverify",
+	ProviderReCaptcha: "https://www.google.com/recaptcha/api/siteverify",
+}
+
+// NewVerifier returns the verifier of the configured provider, or nil if the challenges
+// are disabled (no provider configured)
+func NewVerifier(cfg *intModels.Captcha, client *http.Client) (Verifier, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case ProviderStub:
+		return &StubVerifier{Token: cfg.StubToken}, nil
+	case ProviderTurnstile, ProviderHCaptcha, ProviderReCaptcha:
+		if cfg.Secret == "" {
+			return nil, fmt.Errorf("the captcha secret of the provider %s is missing", cfg.Provider)
+		}
+		url := cfg.VerifyURL
+		if url == "" {
+			url = verifyURLs[cfg.Provider]
+		}
+		return &HTTPVerifier{client: client, url: url, secret: cfg.Secret, minScore: cfg.MinScore}, nil
+	}
+	return nil, fmt.Errorf("unknown captcha provider %q", cfg.Provider)
+}
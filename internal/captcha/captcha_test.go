@@ -0,0 +1,90 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPVerifier(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "secret", r.PostForm.Get("secret"))
+		require.Equal(t, "203.0.113.1", r.PostForm.Get("remoteip"))
+
+		switch r.PostForm.Get("response") {
+		case "valid":
+			w.Write([]byte(`{"success": true}`))
+		case "high-score":
+			w.Write([]byte(`{"success": true, "score": 0.9}`))
+		case "low-score":
+			w.Write([]byte(`{"success": true, "score": 0.1}`))
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte(`{"success": false, "error-codes": ["invalid-input-response"]}`))
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &intModels.Captcha{Provider: ProviderReCaptcha, Secret: "secret", VerifyURL: srv.URL, MinScore: 0.5}
+	v, err := NewVerifier(cfg, srv.Client())
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		token   string
+		invalid bool
+		fails   bool
+	}{
+		"valid token":        {token: "valid"},
+		"high score":         {token: "high-score"},
+		"low score":          {token: "low-score", invalid: true},
+		"rejected token":     {token: "expired", invalid: true},
+		"empty token":        {token: "", invalid: true},
+		"provider is broken": {token: "broken", fails: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := v.Verify(context.Background(), tc.token, "203.0.113.1")
+			switch {
+			case tc.invalid:
+				require.ErrorIs(t, err, ErrInvalid)
+			case tc.fails:
+				require.Error(t, err)
+				require.NotErrorIs(t, err, ErrInvalid)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStubVerifier(t *testing.T) {
+	v, err := NewVerifier(&intModels.Captcha{Provider: ProviderStub, StubToken: "pass"}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, v.Verify(context.Background(), "pass", ""))
+	require.ErrorIs(t, v.Verify(context.Background(), "nope", ""), ErrInvalid)
+	require.ErrorIs(t, (&StubVerifier{}).Verify(context.Background(), "", ""), ErrInvalid)
+}
+
+func TestNewVerifier(t *testing.T) {
+	v, err := NewVerifier(&intModels.Captcha{}, nil)
+	require.NoError(t, err)
+	require.Nil(t, v, "no provider disables the challenges")
+
+	_, err = NewVerifier(&intModels.Captcha{Provider: ProviderTurnstile}, nil)
+	require.Error(t, err, "the secret is required")
+
+	_, err = NewVerifier(&intModels.Captcha{Provider: "friendly-captcha", Secret: "s"}, nil)
+	require.Error(t, err)
+
+	v, err = NewVerifier(&intModels.Captcha{Provider: ProviderHCaptcha, Secret: "s"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, verifyURLs[ProviderHCaptcha], v.(*HTTPVerifier).url)
+}
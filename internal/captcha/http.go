@@ -0,0 +1,68 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HTTPVerifier verifies the tokens through the siteverify endpoint, Turnstile, hCaptcha and
+// reCAPTCHA share the same form request and JSON response
+type HTTPVerifier struct {
+	client *http.Client
+	url    string
+	secret string
+	// minScore is checked against the score of the providers returning one (reCAPTCHA v3)
+	minScore float64
+}
+
+type verifyResponse struct {
+	Success    bool     `json:"success"`
+	Score      *float64 `json:"score"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+func (v *HTTPVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	if token == "" {
+		return ErrInvalid
+	}
+
+	form := url.Values{}
+	form.Set("secret", v.secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build the captcha verification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send the captcha verification request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from the captcha verification", resp.StatusCode)
+	}
+
+	var res verifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return fmt.Errorf("failed to decode the captcha verification response: %w", err)
+	}
+
+	if !res.Success {
+		return fmt.Errorf("%w: %s", ErrInvalid, strings.Join(res.ErrorCodes, ", "))
+	}
+	if res.Score != nil && *res.Score < v.minScore {
+		return fmt.Errorf("%w: the score %.2f is below %.2f", ErrInvalid, *res.Score, v.minScore)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package captcha
+
+import (
+	"context"
+	"crypto/subtle"
+)
+
+// StubVerifier accepts only Token, it lets the tests and the local environments exercise
+// the challenges without a provider account
+type StubVerifier struct {
+	Token string
+}
+
+func (v *StubVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	if v.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(v.Token)) != 1 {
+		return ErrInvalid
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/captcha"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// captchaRequired tells whether the method requires a bot challenge
+func (c *Controller) captchaRequired(method string) bool {
+	return c.captcha != nil && intModels.CaptchaMethodRequired(c.srvCfg.Captcha.Methods, method)
+}
+
+// captchaCheck verifies the challenge token sent in the request metadata if the method
+// requires a challenge, an unreachable provider fails the request, bots shouldn't get
+// through during an outage of the provider
+func (c *Controller) captchaCheck(ctx *models.Context, context context.Context, method string) *models.AppError {
+	if !c.captchaRequired(method) {
+		return nil
+	}
+	return c.captchaVerify(ctx, context)
+}
+
+func (c *Controller) captchaVerify(ctx *models.Context, context context.Context) *models.AppError {
+	path := "users.controller.captchaVerify"
+	errBuilder := func(id string, err error) *models.AppError {
+		errors := &models.AppErrorErrorsArgs{Err: err, ErrorsInternal: map[string]*models.AppErrorError{"captcha": {ID: id}}}
+		return models.NewAppError(ctx, path, id, nil, "", int(codes.InvalidArgument), errors)
+	}
+
+	md, _ := metadata.FromIncomingContext(context)
+	token := intModels.CaptchaTokenGet(md)
+	if token == "" {
+		return errBuilder("user.captcha.required", nil)
+	}
+
+	if err := c.captcha.Verify(ctx.Context, token, intModels.ClientIPAddress(ctx, c.srvCfg.Service.TrustedProxies)); err != nil {
+		if errors.Is(err, captcha.ErrInvalid) {
+			return errBuilder("user.captcha.invalid", err)
+		}
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, "failed to verify the challenge token", int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+	return nil
+}
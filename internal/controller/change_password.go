@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"math"
+	"time"
+
+	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/worker"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/hibiken/asynq"
+	"google.golang.org/grpc/codes"
+)
+
+// ChangePassword updates the password of the authenticated user (intModels.PermissionPasswordUpdate),
+// logs the user out of every other device, and notifies the user by email
+func (c *Controller) ChangePassword(context context.Context, req *pbAcc.ChangePasswordRequest) (*pbAcc.ChangePasswordResponse, error) {
+	start := time.Now()
+	path := "users.controller.ChangePassword"
+	errBuilder := func(e *models.AppError) (*pbAcc.ChangePasswordResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordPasswordChangeRequest(false, duration)
+		return &pbAcc.ChangePasswordResponse{Response: &pbAcc.ChangePasswordResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+	internalErr := func(ctx *models.Context, err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNamePasswordChange, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+	models.AuditEventDataParameter(ar, "permission", intModels.PermissionPasswordUpdate.ID)
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil))
+	}
+
+	if err := intModels.ChangePasswordRequestIsValid(ctx, req, c.config().Password, c.passwordHasher, c.breachedPasswords); err != nil {
+		return errBuilder(err)
+	}
+
+	user, dbErr := c.store.UsersGetByID(ctx, userID)
+	if dbErr != nil {
+		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			return errBuilder(models.NewAppError(ctx, path, "error.not_found", nil, "user not found", int(codes.NotFound), nil))
+		}
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	if err := passwordUpdateAllowed(ctx, path, user); err != nil {
+		return errBuilder(err)
+	}
+
+	if err := c.identityPasswordlessError(ctx, path, user); err != nil {
+		return errBuilder(err)
+	}
+
+	// the current password is guessed against the same lockout as the login
+	lockedUntil, dbErr := c.store.UsersGetLockedUntil(ctx, userID)
+	if dbErr != nil {
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+	if remaining := time.Until(time.UnixMilli(lockedUntil)); remaining > 0 {
+		params := map[string]any{"Minutes": int(math.Ceil(remaining.Minutes()))}
+		return errBuilder(models.NewAppError(ctx, path, "user.login.locked.error", params, "", int(codes.PermissionDenied), nil))
+	}
+
+	if _, err := intModels.PasswordCheck(c.passwordHasher, user.GetPassword(), req.GetCurrentPassword()); err != nil {
+		if lockErr := c.loginLockIfExceeded(ctx, user); lockErr != nil {
+			return errBuilder(lockErr)
+		}
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"current_password": {ID: "user.login.password.error"}}}
+		return errBuilder(models.NewAppError(ctx, path, "user.login.password.error", nil, "", int(codes.InvalidArgument), errors))
+	}
+
+	if err := c.passwordHistoryCheck(ctx, path, user, req.GetNewPassword()); err != nil {
+		return errBuilder(err)
+	}
+
+	hash, hashErr := intModels.PasswordHash(c.passwordHasher, req.GetNewPassword())
+	if hashErr != nil {
+		return errBuilder(internalErr(ctx, hashErr, "failed to hash the new password"))
+	}
+
+	if err := c.store.UsersPasswordUpdate(ctx, userID, hash, c.passwordHistorySize(user)); err != nil {
+		return errBuilder(internalErr(ctx, err, err.Details))
+	}
+
+	// whoever knew the old password may still be logged in elsewhere, the device of the request stays
+	if _, err := c.sessionsRevokeOthers(ctx, userID, ctx.Session.ID); err != nil {
+		// the password is already changed at this point, so don't fail the request
+		c.log.ErrorStruct("failed to revoke the other sessions after a password change", err)
+	}
+
+	options := []asynq.Option{asynq.MaxRetry(10), asynq.Queue(worker.QueuePriorityCritical)}
+	pay := &intModels.TaskSendPasswordChangedEmailPayload{Ctx: ctx, Email: user.GetEmail()}
+	if err := c.tasker.SendPasswordChangedEmail(context, pay, options...); err != nil {
+		c.log.ErrorStruct("failed to enqueue the password changed email", err)
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordPasswordChangeRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "password_change.changed_successfully", nil)
+	return &pbAcc.ChangePasswordResponse{Response: &pbAcc.ChangePasswordResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}}, nil
+}
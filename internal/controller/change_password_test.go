@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+func TestChangePassword(t *testing.T) {
+	hydra := newFakeHydra(t, "")
+	th := NewOfflineTestHelper(t, testConfig(hydra.URL),
+		"password_change.changed_successfully",
+		"user.login.password.error",
+		"user.login.locked.error",
+		"error.permission_denied",
+		"password.min_length",
+	)
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	th.Customer1.Ctx.Session.ID = "session-current"
+	ctx := th.withUser(t, th.Customer1, "current-pass1")
+
+	th.store.On("UsersGetByID", mock.Anything, user.GetId()).Return(user, nil)
+
+	t.Run("the password is changed and the other sessions revoked", func(t *testing.T) {
+		sessions := []*intModels.UserSession{{ID: "session-current", UserID: user.GetId()}, {ID: "session-other", UserID: user.GetId()}}
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil).Once()
+		th.store.On("UsersPasswordHistoryGet", mock.Anything, user.GetId(), mock.Anything).Return([]string{}, nil).Once()
+		th.store.On("UsersPasswordUpdate", mock.Anything, user.GetId(), mock.AnythingOfType("string"), th.controller.passwordHistorySize(user)).Return(nil).Once()
+		th.store.On("UserSessionsGetByUserID", mock.Anything, user.GetId()).Return(sessions, nil).Once()
+		th.store.On("UserSessionsDelete", mock.Anything, user.GetId(), "session-other").Return(nil).Once()
+		th.tasker.On("SendPasswordChangedEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		res, err := th.controller.ChangePassword(ctx, &pbAcc.ChangePasswordRequest{CurrentPassword: "current-pass1", NewPassword: "brand-new-pass1"})
+		require.NoError(t, err)
+		require.Nil(t, res.GetError())
+		require.Equal(t, "password_change.changed_successfully", res.GetData().GetMessage())
+		th.store.AssertCalled(t, "UserSessionsDelete", mock.Anything, user.GetId(), "session-other")
+		for _, call := range th.store.Calls {
+			if call.Method == "UserSessionsDelete" {
+				require.NotEqual(t, "session-current", call.Arguments.String(2), "the session of the request is kept")
+			}
+		}
+	})
+
+	t.Run("a wrong current password is counted as a failed attempt", func(t *testing.T) {
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil).Once()
+		th.store.On("UsersFailedAttemptsIncrement", mock.Anything, user.GetId()).Return(int32(1), nil).Once()
+
+		res, err := th.controller.ChangePassword(ctx, &pbAcc.ChangePasswordRequest{CurrentPassword: "wrong-pass1", NewPassword: "brand-new-pass1"})
+		require.NoError(t, err)
+		require.Equal(t, "user.login.password.error", res.GetError().GetId())
+	})
+
+	t.Run("the account is locked once the attempts exceed the maximum", func(t *testing.T) {
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil).Once()
+		th.store.On("UsersFailedAttemptsIncrement", mock.Anything, user.GetId()).Return(int32(6), nil).Once()
+		th.store.On("UsersLock", mock.Anything, user.GetId(), mock.AnythingOfType("int64")).Return(nil).Once()
+		th.tasker.On("SendAccountLockedEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		res, err := th.controller.ChangePassword(ctx, &pbAcc.ChangePasswordRequest{CurrentPassword: "wrong-pass1", NewPassword: "brand-new-pass1"})
+		require.NoError(t, err)
+		require.Equal(t, "user.login.locked.error", res.GetError().GetId())
+	})
+
+	t.Run("a locked account can't check its password", func(t *testing.T) {
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(time.Now().Add(time.Hour).UnixMilli(), nil).Once()
+
+		calls := len(th.store.Calls)
+		res, err := th.controller.ChangePassword(ctx, &pbAcc.ChangePasswordRequest{CurrentPassword: "current-pass1", NewPassword: "brand-new-pass1"})
+		require.NoError(t, err)
+		require.Equal(t, "user.login.locked.error", res.GetError().GetId())
+		require.Len(t, th.store.Calls, calls+2, "only the user and the lock are read")
+	})
+
+	t.Run("the password_update permission is required", func(t *testing.T) {
+		roles := user.Roles
+		user.Roles = []string{"unknown_role"}
+		defer func() { user.Roles = roles }()
+
+		res, err := th.controller.ChangePassword(ctx, &pbAcc.ChangePasswordRequest{CurrentPassword: "current-pass1", NewPassword: "brand-new-pass1"})
+		require.NoError(t, err)
+		require.Equal(t, "error.permission_denied", res.GetError().GetId())
+	})
+
+	t.Run("the invalid password isn't disclosed in the error", func(t *testing.T) {
+		res, err := th.controller.ChangePassword(ctx, &pbAcc.ChangePasswordRequest{CurrentPassword: "current-pass1", NewPassword: "short1"})
+		require.NoError(t, err)
+		require.Equal(t, "password.min_length", res.GetError().GetId())
+		require.NotContains(t, res.GetError().GetDetailedError(), "short1")
+	})
+}
@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"google.golang.org/grpc/codes"
+)
+
+// ConsentGet returns the client and the requested scopes of a consent request, for the
+// frontend consent page of the third party clients
+func (c *Controller) ConsentGet(context context.Context, req *pbAcc.ConsentGetRequest) (*pbAcc.ConsentGetResponse, error) {
+	start := time.Now()
+	errBuilder := func(e *models.AppError) (*pbAcc.ConsentGetResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordConsentRequest(false, duration)
+		return &pbAcc.ConsentGetResponse{Response: &pbAcc.ConsentGetResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	if err := intModels.OAuthConsentChallengeIsValid(ctx, req.GetConsentChallenge()); err != nil {
+		return errBuilder(err)
+	}
+
+	cr, err := c.oauthConsentGet(ctx, req.GetConsentChallenge())
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordConsentRequest(true, duration)
+
+	meta := map[string]string{
+		"client_id":       cr.Client.ClientID,
+		"client_name":     cr.Client.ClientName,
+		"requested_scope": strings.Join(cr.RequestedScope, " "),
+	}
+	return &pbAcc.ConsentGetResponse{Response: &pbAcc.ConsentGetResponse_Data{Data: &pbSh.SuccessResponseData{Metadata: meta}}}, nil
+}
+
+// ConsentAccept grants the scopes chosen by the user on the frontend consent page, which must
+// be a subset of the requested ones, and returns the url the user should be redirected to
+func (c *Controller) ConsentAccept(context context.Context, req *pbAcc.ConsentAcceptRequest) (*pbAcc.ConsentAcceptResponse, error) {
+	start := time.Now()
+	path := "users.controller.ConsentAccept"
+	errBuilder := func(e *models.AppError) (*pbAcc.ConsentAcceptResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordConsentRequest(false, duration)
+		return &pbAcc.ConsentAcceptResponse{Response: &pbAcc.ConsentAcceptResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameConsentAccept, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	if err := intModels.OAuthConsentChallengeIsValid(ctx, req.GetConsentChallenge()); err != nil {
+		return errBuilder(err)
+	}
+
+	cr, err := c.oauthConsentGet(ctx, req.GetConsentChallenge())
+	if err != nil {
+		return errBuilder(err)
+	}
+	models.AuditEventDataParameter(ar, "client_id", cr.Client.ClientID)
+	models.AuditEventDataParameter(ar, "grant_scope", strings.Join(req.GetGrantScope(), " "))
+
+	if !intModels.OAuthScopesAreSubset(cr.RequestedScope, req.GetGrantScope()) {
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"grant_scope": {ID: "oauth.invalid_scope"}}}
+		return errBuilder(models.NewAppError(ctx, path, "oauth.invalid_scope", nil, "granted scopes must be requested", int(codes.InvalidArgument), errors))
+	}
+
+	// the claims are loaded fresh, the consent can be given long after the login step
+	user, dbErr := c.store.UsersGetByID(ctx, cr.Subject)
+	if dbErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, dbErr.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: dbErr}))
+	}
+
+	// the consent is remembered only when the user asked so and the login was, for the
+	// lifetime of its platform
+	security := c.config().Security
+	session := intModels.LoginSessionOptionsFromContext(cr.Context)
+	session.Remember = session.Remember && req.GetRemember()
+	body := map[string]any{
+		"grant_scope":                 req.GetGrantScope(),
+		"grant_access_token_audience": c.config().Oauth.GetOauthGrantAccessTokenAudience(),
+		"remember":                    session.Remember,
+		"remember_for":                session.RememberFor(security.GetAccessTokenExpiryWebInHours(), security.GetAccessTokenExpiryMobileInHours()),
+		"session":                     intModels.OAuthConsentSession(user, req.GetGrantScope()),
+	}
+
+	var result struct {
+		RedirectTo string `json:"redirect_to"`
+	}
+	endpoint := fmt.Sprintf("%s/oauth2/auth/requests/consent/accept?consent_challenge=%s", c.config().Oauth.GetOauthAdminUrl(), url.QueryEscape(req.GetConsentChallenge()))
+	if err := c.oauthAdminRequest(ctx, http.MethodPut, endpoint, body, &result); err != nil {
+		return errBuilder(err)
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordConsentRequest(true, duration)
+
+	return &pbAcc.ConsentAcceptResponse{Response: &pbAcc.ConsentAcceptResponse_Data{Data: &pbSh.SuccessResponseData{Metadata: map[string]string{"redirect_to": result.RedirectTo}}}}, nil
+}
+
+// ConsentReject denies the consent request, and returns the url the user should be redirected to
+func (c *Controller) ConsentReject(context context.Context, req *pbAcc.ConsentRejectRequest) (*pbAcc.ConsentRejectResponse, error) {
+	start := time.Now()
+	errBuilder := func(e *models.AppError) (*pbAcc.ConsentRejectResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordConsentRequest(false, duration)
+		return &pbAcc.ConsentRejectResponse{Response: &pbAcc.ConsentRejectResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameConsentReject, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	if err := intModels.OAuthConsentChallengeIsValid(ctx, req.GetConsentChallenge()); err != nil {
+		return errBuilder(err)
+	}
+
+	body := map[string]any{
+		"error":             "access_denied",
+		"error_description": "The resource owner denied the request",
+	}
+
+	var result struct {
+		RedirectTo string `json:"redirect_to"`
+	}
+	endpoint := fmt.Sprintf("%s/oauth2/auth/requests/consent/reject?consent_challenge=%s", c.config().Oauth.GetOauthAdminUrl(), url.QueryEscape(req.GetConsentChallenge()))
+	if err := c.oauthAdminRequest(ctx, http.MethodPut, endpoint, body, &result); err != nil {
+		return errBuilder(err)
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordConsentRequest(true, duration)
+
+	return &pbAcc.ConsentRejectResponse{Response: &pbAcc.ConsentRejectResponse_Data{Data: &pbSh.SuccessResponseData{Metadata: map[string]string{"redirect_to": result.RedirectTo}}}}, nil
+}
+
+func (c *Controller) oauthConsentGet(ctx *models.Context, challenge string) (*intModels.OAuthConsentRequest, *models.AppError) {
+	var cr intModels.OAuthConsentRequest
+	endpoint := fmt.Sprintf("%s/oauth2/auth/requests/consent?consent_challenge=%s", c.config().Oauth.GetOauthAdminUrl(), url.QueryEscape(challenge))
+	if err := c.oauthAdminRequest(ctx, http.MethodGet, endpoint, nil, &cr); err != nil {
+		return nil, err
+	}
+	return &cr, nil
+}
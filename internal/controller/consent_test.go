@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	com "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/common/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+// newFakeConsentHydra serves a consent request of the session, and hands the accepted body to the test
+func newFakeConsentHydra(t *testing.T, subject string, session intModels.LoginSessionOptions, accepted chan<- map[string]any) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/oauth2/auth/requests/consent":
+			json.NewEncoder(w).Encode(intModels.OAuthConsentRequest{
+				Subject:        subject,
+				RequestedScope: []string{"openid", "email"},
+				Client:         intModels.OAuthConsentClient{ClientID: "third-party"},
+				Context:        session.Context(map[string]any{}),
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/oauth2/auth/requests/consent/accept":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			accepted <- body
+			json.NewEncoder(w).Encode(map[string]string{"redirect_to": "http://hydra.local/done"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConsentAccept(t *testing.T) {
+	tests := map[string]struct {
+		session     intModels.LoginSessionOptions
+		remember    bool
+		rememberFor float64
+	}{
+		"a remembered mobile login remembers the consent for the mobile lifetime": {
+			session:     intModels.LoginSessionOptions{Platform: intModels.ClientPlatformMobile, Remember: true},
+			remember:    true,
+			rememberFor: 720 * 60 * 60,
+		},
+		"a login not remembered doesn't remember the consent": {
+			session:  intModels.LoginSessionOptions{Platform: intModels.ClientPlatformWeb},
+			remember: true,
+		},
+		"the consent isn't remembered unless asked": {
+			session: intModels.LoginSessionOptions{Platform: intModels.ClientPlatformWeb, Remember: true},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			accepted := make(chan map[string]any, 1)
+			hydra := newFakeConsentHydra(t, "fake-subject", tc.session, accepted)
+			cfg := testConfig(hydra.URL, func(cfg *com.Config) {
+				cfg.Security.AccessTokenExpiryMobileInHours = utils.NewPointer(int32(720))
+			})
+			th := NewOfflineTestHelper(t, cfg)
+			defer th.TearDown()
+
+			th.store.On("UsersGetByID", mock.Anything, "fake-subject").Return(th.Customer1.User, nil).Once()
+
+			req := &pbAcc.ConsentAcceptRequest{ConsentChallenge: "fake-challenge", GrantScope: []string{"openid"}, Remember: tc.remember}
+			res, err := th.controller.ConsentAccept(th.withContext(context.Background()), req)
+			require.NoError(t, err)
+			require.Nil(t, res.GetError())
+			require.Equal(t, "http://hydra.local/done", res.GetData().GetMetadata()["redirect_to"])
+
+			body := <-accepted
+			require.Equal(t, tc.rememberFor != 0, body["remember"])
+			require.EqualValues(t, tc.rememberFor, body["remember_for"])
+		})
+	}
+}
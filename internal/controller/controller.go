@@ -4,15 +4,22 @@ package controller
 import (
 	"net"
 	"net/http"
+	"sync"
+	"sync/atomic"
 
 	common "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/common/v1"
 	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/logger"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/captcha"
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/otel"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/ratelimit"
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/store"
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/worker"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/minio/minio-go/v7"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -22,6 +29,7 @@ import (
 
 type Controller struct {
 	pb.UnimplementedUsersServiceServer
+	pbAcc.UnimplementedUsersAccountServiceServer
 	store            store.UsersStore
 	objStorage       *minio.Client
 	config           func() *common.Config
@@ -30,6 +38,22 @@ type Controller struct {
 	tasker           worker.TaskDistributor
 	httpClient       *http.Client
 	metricsCollector *MetricsCollector
+	srvCfg           *intModels.Config
+	webauthn         *webauthn.WebAuthn
+	// captcha is nil if the bot challenges are disabled
+	captcha captcha.Verifier
+	// passwordHasher hashes the new passwords, the older hashes are checked by their own algorithm
+	passwordHasher intModels.PasswordHasher
+	// breachedPasswords is nil if the breached passwords screening is disabled
+	breachedPasswords *intModels.BreachedPasswords
+	// emailDomainPolicy returns the current email domains policy of the signups, it's reloaded
+	// with the service config file
+	emailDomainPolicy func() *intModels.EmailDomainPolicy
+	// sessionTouches holds the last time (unix millis) each session was touched, by session id
+	sessionTouches         sync.Map
+	sessionTouchesPrunedAt atomic.Int64
+	dummyPasswordOnce      sync.Once
+	dummyPasswordHash      string
 }
 
 type ControllerArgs struct {
@@ -39,6 +63,15 @@ type ControllerArgs struct {
 	TracerProvider *sdktrace.TracerProvider
 	Log            *logger.Logger
 	Tasker         worker.TaskDistributor
+	SrvCfg         *intModels.Config
+	// RateLimiter limits the rate of the grpc requests, nil disables the rate limits
+	RateLimiter *ratelimit.Limiter
+	// PasswordHasher hashes the new passwords, nil defaults to argon2id
+	PasswordHasher intModels.PasswordHasher
+	// BreachedPasswords is the corpus the new passwords are screened against, nil disables it
+	BreachedPasswords *intModels.BreachedPasswords
+	// EmailDomainPolicy returns the email domains policy of the signups, nil accepts any domain
+	EmailDomainPolicy func() *intModels.EmailDomainPolicy
 }
 
 func NewController(ca *ControllerArgs) (*Controller, *models.InternalError) {
@@ -50,29 +83,65 @@ func NewController(ca *ControllerArgs) (*Controller, *models.InternalError) {
 	otel.SetupPrometheusMetrics("8062")
 
 	c := &Controller{
-		config:           ca.Config,
-		store:            ca.Store,
-		objStorage:       ca.ObjStorage,
-		tracerProvider:   ca.TracerProvider,
-		log:              ca.Log,
-		tasker:           ca.Tasker,
-		metricsCollector: NewMetricsCollector(),
+		config:            ca.Config,
+		store:             ca.Store,
+		objStorage:        ca.ObjStorage,
+		tracerProvider:    ca.TracerProvider,
+		log:               ca.Log,
+		tasker:            ca.Tasker,
+		metricsCollector:  NewMetricsCollector(),
+		srvCfg:            ca.SrvCfg,
+		passwordHasher:    ca.PasswordHasher,
+		breachedPasswords: ca.BreachedPasswords,
+		emailDomainPolicy: ca.EmailDomainPolicy,
+	}
+
+	if c.passwordHasher == nil {
+		c.passwordHasher = intModels.NewArgon2idHasher()
+	}
+	if c.emailDomainPolicy == nil {
+		c.emailDomainPolicy = func() *intModels.EmailDomainPolicy { return nil }
 	}
 
 	c.httpClient = utils.GetHTTPClient()
 
+	wa, err := webauthn.New(&webauthn.Config{
+		RPID:          c.srvCfg.WebAuthn.RPID,
+		RPDisplayName: c.srvCfg.WebAuthn.RPDisplayName,
+		RPOrigins:     c.srvCfg.WebAuthn.RPOrigins,
+	})
+	if err != nil {
+		return nil, &models.InternalError{Path: "user.controller.NewController", Err: err, Msg: "failed to initialize webauthn"}
+	}
+	c.webauthn = wa
+
+	verifier, err := captcha.NewVerifier(&c.srvCfg.Captcha, c.httpClient)
+	if err != nil {
+		return nil, &models.InternalError{Path: "user.controller.NewController", Err: err, Msg: "failed to initialize the captcha verifier"}
+	}
+	c.captcha = verifier
+
 	defaultLang := c.config().Localization.GetDefaultClientLocale()
 	availableLangs := c.config().GetLocalization().GetAvailableLocales()
 
+	unary := []grpc.UnaryServerInterceptor{
+		models.ResponseInterceptor(defaultLang, availableLangs),
+		models.UnaryMetadataInterceptor(defaultLang, availableLangs),
+	}
+	// the rejected requests shouldn't reach the other interceptors (E,g write the session's last seen time)
+	if ca.RateLimiter != nil {
+		unary = append(unary, ca.RateLimiter.UnaryServerInterceptor())
+	}
+	unary = append(unary,
+		c.sessionTouchInterceptor(),
+		// c.metrics.UnaryServerInterceptor(grpcprom.WithExemplarFromContext(traceID)),
+		// selector.UnaryServerInterceptor(auth.UnaryServerInterceptor(authMiddleware), selector.MatchFunc(authMatcher)),
+	)
+
 	s := grpc.NewServer(
 		grpc.MaxRecvMsgSize(int(c.config().Services.GetUsersServiceMaxReceiveMessageSizeBytes())),
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
-		grpc.ChainUnaryInterceptor(
-			models.ResponseInterceptor(defaultLang, availableLangs),
-			models.UnaryMetadataInterceptor(defaultLang, availableLangs),
-			// c.metrics.UnaryServerInterceptor(grpcprom.WithExemplarFromContext(traceID)),
-			// selector.UnaryServerInterceptor(auth.UnaryServerInterceptor(authMiddleware), selector.MatchFunc(authMatcher)),
-		),
+		grpc.ChainUnaryInterceptor(unary...),
 		grpc.ChainStreamInterceptor(
 			models.StreamMetadataInterceptor(defaultLang, availableLangs),
 			// c.metrics.StreamServerInterceptor(grpcprom.WithExemplarFromContext(traceID)),
@@ -88,6 +157,7 @@ func NewController(ca *ControllerArgs) (*Controller, *models.InternalError) {
 
 	reflection.Register(s)
 	pb.RegisterUsersServiceServer(s, c)
+	pbAcc.RegisterUsersAccountServiceServer(s, c)
 
 	go func() {
 		c.log.Infof("grpc user service is running on %s", addr)
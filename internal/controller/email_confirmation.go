@@ -50,6 +50,13 @@ func (c *Controller) EmailConfirmation(context context.Context, req *pb.EmailCon
 		}
 	}
 
+	// an other token type (E,g a password reset one) can't confirm the email, the store checks the type too
+	if token.GetType() != string(intModels.TokenTypeEmailConfirmation) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordEmailConfirmationRequest(false, duration)
+		return errBuilder(models.NewAppError(ctx, path, "email_confirm.token.not_found", nil, "the token isn't an email confirmation one", int(codes.NotFound), nil))
+	}
+
 	if token.Used {
 		duration := time.Since(start).Seconds()
 		c.metricsCollector.RecordEmailConfirmationRequest(true, duration)
@@ -70,9 +77,12 @@ func (c *Controller) EmailConfirmation(context context.Context, req *pb.EmailCon
 		return sucBuilder(&pbSh.SuccessResponseData{Message: &msg})
 	}
 
-	if err := c.store.MarkEmailAsConfirmed(ctx, req.TokenId); err != nil {
+	if err := c.store.MarkEmailAsConfirmed(ctx, req.TokenId, req.GetEmail()); err != nil {
 		duration := time.Since(start).Seconds()
 		c.metricsCollector.RecordEmailConfirmationRequest(false, duration)
+		if err.ErrType == models.DBErrorTypeNoRows {
+			return errBuilder(models.NewAppError(ctx, path, "email_confirm.token.not_found", nil, "the token doesn't belong to the given email", int(codes.NotFound), &models.AppErrorErrorsArgs{Err: err}))
+		}
 		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err}))
 	}
 
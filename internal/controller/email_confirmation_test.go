@@ -131,7 +131,7 @@ func TestEmailConfirmation(t *testing.T) {
 		valid := getToken(t, req)
 
 		th.store.On("TokensGet", mock.Anything, req.TokenId).Return(valid, nil)
-		th.store.On("MarkEmailAsConfirmed", mock.Anything, req.TokenId).Return(nil)
+		th.store.On("MarkEmailAsConfirmed", mock.Anything, req.TokenId, req.Email).Return(nil)
 
 		res, err := th.controller.EmailConfirmation(th.withContext(context.Background()), req)
 		require.NoError(t, err)
@@ -146,7 +146,7 @@ func TestEmailConfirmation(t *testing.T) {
 		valid := getToken(t, req)
 
 		th.store.On("TokensGet", mock.Anything, req.TokenId).Return(valid, nil)
-		th.store.On("MarkEmailAsConfirmed", mock.Anything, req.TokenId).
+		th.store.On("MarkEmailAsConfirmed", mock.Anything, req.TokenId, req.Email).
 			Return(&models.DBError{
 				Path: "users.store.MarkEmailAsConfirmed",
 				Msg:  "db failed",
@@ -160,3 +160,47 @@ func TestEmailConfirmation(t *testing.T) {
 		require.Equal(t, models.ErrMsgInternal, errRes.Error.Id)
 	})
 }
+
+func TestEmailConfirmationToken(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""),
+		"email_confirm.confirmed_successfully",
+		"email_confirm.token.not_found",
+	)
+	defer th.TearDown()
+
+	ctx := th.withContext(context.Background())
+
+	t.Run("the email is confirmed", func(t *testing.T) {
+		req := getValidEmailConfirmationRequest(t, 1)
+		th.store.On("TokensGet", mock.Anything, req.TokenId).Return(getToken(t, req), nil).Once()
+		th.store.On("MarkEmailAsConfirmed", mock.Anything, req.TokenId, req.Email).Return(nil).Once()
+
+		res, err := th.controller.EmailConfirmation(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "email_confirm.confirmed_successfully", res.GetData().GetMessage())
+	})
+
+	t.Run("a token of another type is refused", func(t *testing.T) {
+		req := getValidEmailConfirmationRequest(t, 1)
+		token := getToken(t, req, func(token *pb.Token) { token.Type = string(intModels.TokenTypePasswordReset) })
+		th.store.On("TokensGet", mock.Anything, req.TokenId).Return(token, nil).Once()
+
+		calls := len(th.store.Calls)
+		res, err := th.controller.EmailConfirmation(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "email_confirm.token.not_found", res.GetError().GetId())
+		require.Len(t, th.store.Calls, calls+1, "the token isn't consumed")
+	})
+
+	t.Run("a token not matching the store is refused", func(t *testing.T) {
+		// the store finds no confirmation token of the user having the email (E,g another user's token)
+		req := getValidEmailConfirmationRequest(t, 1)
+		th.store.On("TokensGet", mock.Anything, req.TokenId).Return(getToken(t, req), nil).Once()
+		th.store.On("MarkEmailAsConfirmed", mock.Anything, req.TokenId, req.Email).
+			Return(&models.DBError{ErrType: models.DBErrorTypeNoRows, Path: "users.store.MarkEmailAsConfirmed"}).Once()
+
+		res, err := th.controller.EmailConfirmation(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "email_confirm.token.not_found", res.GetError().GetId())
+	})
+}
@@ -0,0 +1,319 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+
+	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/worker"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/hibiken/asynq"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+)
+
+// EmailLoginBegin sends a magic link or a one time code to the user's email, the response
+// is the same whether the email is registered or not
+func (c *Controller) EmailLoginBegin(context context.Context, req *pbAcc.EmailLoginBeginRequest) (*pbAcc.EmailLoginBeginResponse, error) {
+	start := time.Now()
+	path := "users.controller.EmailLoginBegin"
+	errBuilder := func(e *models.AppError) (*pbAcc.EmailLoginBeginResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordEmailLoginBeginRequest(false, duration)
+		return &pbAcc.EmailLoginBeginResponse{Response: &pbAcc.EmailLoginBeginResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+	internalErr := func(ctx *models.Context, err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+	sucBuilder := func() (*pbAcc.EmailLoginBeginResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordEmailLoginBeginRequest(true, duration)
+		msg := models.Tr(ctx.AcceptLanguage, "user.login.email.sent", map[string]any{"Email": req.GetEmail()})
+		return &pbAcc.EmailLoginBeginResponse{Response: &pbAcc.EmailLoginBeginResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}}, nil
+	}
+
+	if err := intModels.EmailLoginBeginRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameEmailLoginBegin, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+	models.AuditEventDataParameter(ar, "email", req.GetEmail())
+	models.AuditEventDataParameter(ar, "mode", req.GetMode())
+
+	user, dbErr := c.store.UsersGetByEmail(ctx, req.GetEmail())
+	if dbErr != nil {
+		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			return sucBuilder()
+		}
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	if user.GetPassword() == "" {
+		return sucBuilder()
+	}
+
+	// the old tokens are kept by TokensRotate, so they count towards the limits, the limits
+	// work like the verification resend ones
+	auth := c.srvCfg.Auth
+	since := time.Now().Add(-time.Hour * 24).UnixMilli()
+	issued, dbErr := c.store.TokensGetCreatedAtSince(ctx, user.GetId(), intModels.TokenTypeEmailLogin, since)
+	if dbErr != nil {
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+	cooldown := time.Duration(auth.EmailLoginCooldownSeconds) * time.Second
+	if !intModels.TokenIssueAllowed(issued, time.Now(), cooldown, auth.EmailLoginDailyCap) {
+		models.AuditEventDataParameter(ar, "rate_limited", true)
+		return sucBuilder()
+	}
+
+	pay := &intModels.TaskSendEmailLoginEmailPayload{Ctx: ctx, Email: user.GetEmail()}
+	var tokenData *utils.Token
+	if intModels.EmailLoginMode(req.GetMode()) == intModels.EmailLoginModeCode {
+		tokenData, err = c.emailLoginCodeNew(ctx, time.Duration(auth.EmailLoginCodeMinutes)*time.Minute)
+		if err != nil {
+			return errBuilder(err)
+		}
+		pay.Code = tokenData.Token
+		pay.Minutes = auth.EmailLoginCodeMinutes
+	} else {
+		var errTok error
+		tokenData, errTok = (&utils.Token{}).GenerateToken(time.Duration(auth.EmailLoginLinkMinutes) * time.Minute)
+		if errTok != nil {
+			return errBuilder(internalErr(ctx, errTok, "failed to generate the email login token"))
+		}
+		q := url.Values{}
+		q.Set("token", tokenData.Token)
+		q.Set("token_id", tokenData.ID)
+		q.Set("email", user.GetEmail())
+		q.Set("login_challenge", req.GetLoginChallenge())
+		pay.Url = fmt.Sprintf("%s?%s", auth.EmailLoginURL, q.Encode())
+		pay.Minutes = auth.EmailLoginLinkMinutes
+	}
+
+	if dbErr := c.store.TokensRotate(ctx, user.GetId(), tokenData, intModels.TokenTypeEmailLogin); dbErr != nil {
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	options := []asynq.Option{asynq.MaxRetry(10), asynq.Queue(worker.QueuePriorityCritical)}
+	if err := c.tasker.SendEmailLoginEmail(context, pay, options...); err != nil {
+		return errBuilder(internalErr(ctx, err, "failed to enqueue the email login task"))
+	}
+
+	ar.Success()
+	return sucBuilder()
+}
+
+// EmailLoginFinish redeems the magic link or the code sent by EmailLoginBegin and accepts
+// the OAuth login request, users with an active MFA still have to pass the second step
+func (c *Controller) EmailLoginFinish(context context.Context, req *pbAcc.EmailLoginFinishRequest) (*pbAcc.EmailLoginFinishResponse, error) {
+	start := time.Now()
+	path := "users.controller.EmailLoginFinish"
+	errBuilder := func(e *models.AppError) (*pbAcc.EmailLoginFinishResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordEmailLoginRequest(false, duration)
+		return &pbAcc.EmailLoginFinishResponse{Response: &pbAcc.EmailLoginFinishResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+	internalErr := func(ctx *models.Context, err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	if err := intModels.EmailLoginFinishRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameEmailLogin, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+	models.AuditEventDataParameter(ar, "email", req.GetEmail())
+
+	invalid := models.NewAppError(ctx, path, "user.login.email.invalid", nil, "", int(codes.InvalidArgument), nil)
+	user, dbErr := c.store.UsersGetByEmail(ctx, req.GetEmail())
+	if dbErr != nil {
+		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			return errBuilder(invalid)
+		}
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	if user.GetPassword() == "" {
+		return errBuilder(invalid)
+	}
+
+	lockedUntil, dbErr := c.store.UsersGetLockedUntil(ctx, user.GetId())
+	if dbErr != nil {
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+	if remaining := time.Until(time.UnixMilli(lockedUntil)); remaining > 0 {
+		params := map[string]any{"Minutes": int(math.Ceil(remaining.Minutes()))}
+		return errBuilder(models.NewAppError(ctx, path, "user.login.locked.error", params, "", int(codes.PermissionDenied), nil))
+	}
+
+	var token *pb.Token
+	if req.GetCode() != "" {
+		token, err = c.emailLoginCodeCheck(ctx, user, req.GetCode())
+	} else {
+		token, err = c.emailLoginLinkCheck(ctx, user, req.GetTokenId(), req.GetToken())
+	}
+	if err != nil {
+		return errBuilder(err)
+	}
+	if token == nil {
+		// a wrong code or link is a failed login attempt, the code attempts alone reset with
+		// every new code
+		if lockErr := c.loginLockIfExceeded(ctx, user); lockErr != nil {
+			return errBuilder(lockErr)
+		}
+		return errBuilder(invalid)
+	}
+
+	// the token is kept until the whole login succeeds, so it can be sent again with the MFA code
+	if user.GetMfaActive() {
+		if req.GetMfa() == "" {
+			meta, err := c.loginMfaChallenge(ctx, user)
+			if err != nil {
+				return errBuilder(err)
+			}
+			duration := time.Since(start).Seconds()
+			c.metricsCollector.RecordEmailLoginRequest(true, duration)
+			msg := models.Tr(ctx.AcceptLanguage, "user.login.mfa_required", nil)
+			return &pbAcc.EmailLoginFinishResponse{Response: &pbAcc.EmailLoginFinishResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg, Metadata: meta}}}, nil
+		}
+
+		if err := c.loginMfaVerify(ctx, context, user, req.GetMfa()); err != nil {
+			return errBuilder(err)
+		}
+	}
+
+	if dbErr := c.store.TokensMarkUsed(ctx, token.GetId()); dbErr != nil {
+		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			return errBuilder(invalid)
+		}
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	if dbErr := c.store.UsersLoginSucceeded(ctx, user.GetId()); dbErr != nil {
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	redirectTo, acceptErr := c.oauthLoginAccept(ctx, context, user, req.GetLoginChallenge())
+	if acceptErr != nil {
+		return errBuilder(acceptErr)
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordEmailLoginRequest(true, duration)
+
+	return &pbAcc.EmailLoginFinishResponse{Response: &pbAcc.EmailLoginFinishResponse_Data{Data: &pbSh.SuccessResponseData{Metadata: map[string]string{"redirect_to": redirectTo}}}}, nil
+}
+
+// emailLoginCodeNew generates a login code, the code is returned in the Token field and
+// only its hash is meant to be stored
+func (c *Controller) emailLoginCodeNew(ctx *models.Context, ttl time.Duration) (*utils.Token, *models.AppError) {
+	path := "users.controller.emailLoginCodeNew"
+	internalErr := func(err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	tokenData, err := (&utils.Token{}).GenerateToken(ttl)
+	if err != nil {
+		return nil, internalErr(err, "failed to generate the email login token")
+	}
+
+	code, err := intModels.EmailLoginGenerateCode()
+	if err != nil {
+		return nil, internalErr(err, "failed to generate the email login code")
+	}
+
+	hash, err := utils.PasswordHash(code)
+	if err != nil {
+		return nil, internalErr(err, "failed to hash the email login code")
+	}
+
+	tokenData.Token = code
+	tokenData.Hash = []byte(hash)
+	return tokenData, nil
+}
+
+// emailLoginLinkCheck returns the email login token of the magic link, or nil if it isn't valid
+func (c *Controller) emailLoginLinkCheck(ctx *models.Context, user *pb.User, tokenID, secret string) (*pb.Token, *models.AppError) {
+	path := "users.controller.emailLoginLinkCheck"
+	token, err := c.store.TokensGet(ctx, tokenID)
+	if err != nil {
+		if err.ErrType == models.DBErrorTypeNoRows {
+			return nil, nil
+		}
+		return nil, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	if !emailLoginTokenUsable(token, user) {
+		return nil, nil
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(token.GetToken()), []byte(secret)); err != nil {
+		return nil, nil
+	}
+
+	return token, nil
+}
+
+// emailLoginCodeCheck returns the email login token matching the code, or nil if it doesn't
+// match, the code is burned after Auth.EmailLoginCodeMaxAttempts wrong attempts
+func (c *Controller) emailLoginCodeCheck(ctx *models.Context, user *pb.User, code string) (*pb.Token, *models.AppError) {
+	path := "users.controller.emailLoginCodeCheck"
+	internalErr := func(err *models.DBError) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	tokens, err := c.store.TokensGetUnusedByType(ctx, user.GetId(), intModels.TokenTypeEmailLogin)
+	if err != nil {
+		return nil, internalErr(err)
+	}
+	// EmailLoginBegin keeps only the latest token unused
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	token := tokens[0]
+	if !emailLoginTokenUsable(token, user) {
+		return nil, nil
+	}
+	if bcrypt.CompareHashAndPassword([]byte(token.GetToken()), []byte(code)) == nil {
+		return token, nil
+	}
+
+	attempts, err := c.store.TokensAttemptsIncrement(ctx, token.GetId())
+	if err != nil {
+		return nil, internalErr(err)
+	}
+	if attempts >= c.srvCfg.Auth.EmailLoginCodeMaxAttempts {
+		if err := c.store.TokensMarkUsed(ctx, token.GetId()); err != nil && err.ErrType != models.DBErrorTypeNoRows {
+			return nil, internalErr(err)
+		}
+	}
+
+	return nil, nil
+}
+
+func emailLoginTokenUsable(token *pb.Token, user *pb.User) bool {
+	return token.GetType() == string(intModels.TokenTypeEmailLogin) &&
+		token.GetUserId() == user.GetId() &&
+		!token.GetUsed() &&
+		token.GetExpiresAt() >= utils.TimeGetMillis()
+}
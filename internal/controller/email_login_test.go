@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+func TestEmailLoginBegin(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""), "user.login.email.sent")
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	ctx := th.withContext(context.Background())
+	req := &pbAcc.EmailLoginBeginRequest{Email: user.GetEmail(), LoginChallenge: "fake-challenge", Mode: string(intModels.EmailLoginModeCode)}
+
+	begin := func(t *testing.T) {
+		t.Helper()
+		res, err := th.controller.EmailLoginBegin(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, res.GetError())
+		require.Equal(t, "user.login.email.sent", res.GetData().GetMessage())
+	}
+
+	th.store.On("UsersGetByEmail", mock.Anything, user.GetEmail()).Return(user, nil)
+
+	t.Run("a code is issued and sent", func(t *testing.T) {
+		th.store.On("TokensGetCreatedAtSince", mock.Anything, user.GetId(), intModels.TokenTypeEmailLogin, mock.AnythingOfType("int64")).Return([]int64{}, nil).Once()
+		th.store.On("TokensRotate", mock.Anything, user.GetId(), mock.Anything, intModels.TokenTypeEmailLogin).Return(nil).Once()
+		th.tasker.On("SendEmailLoginEmail", mock.Anything, mock.MatchedBy(func(pay *intModels.TaskSendEmailLoginEmailPayload) bool {
+			return intModels.EmailLoginIsValidCode(pay.Code)
+		}), mock.Anything, mock.Anything).Return(nil).Once()
+
+		begin(t)
+		th.tasker.AssertNumberOfCalls(t, "SendEmailLoginEmail", 1)
+	})
+
+	tests := map[string][]int64{
+		"within the cooldown": {time.Now().UnixMilli()},
+		"daily cap reached":   make([]int64, th.srvCfg.Auth.EmailLoginDailyCap),
+	}
+	for name, issued := range tests {
+		t.Run(name+" answers the same without sending", func(t *testing.T) {
+			th.store.On("TokensGetCreatedAtSince", mock.Anything, user.GetId(), intModels.TokenTypeEmailLogin, mock.AnythingOfType("int64")).Return(issued, nil).Once()
+
+			begin(t)
+			th.tasker.AssertNumberOfCalls(t, "SendEmailLoginEmail", 1)
+		})
+	}
+}
+
+func TestEmailLoginFinish(t *testing.T) {
+	hydra := newFakeHydra(t, "http://hydra.local/done")
+	th := NewOfflineTestHelper(t, testConfig(hydra.URL), "user.login.email.invalid", "user.login.locked.error")
+	defer th.TearDown()
+	th.srvCfg.Auth.NewDeviceAlerts = false
+
+	user := th.Customer1.User
+	ctx := th.withContext(context.Background())
+	tokenData, err := th.controller.emailLoginCodeNew(th.Customer1.Ctx, time.Minute)
+	require.Nil(t, err)
+	token := &pb.Token{
+		Id:        tokenData.ID,
+		UserId:    user.GetId(),
+		Token:     string(tokenData.Hash),
+		Type:      string(intModels.TokenTypeEmailLogin),
+		ExpiresAt: tokenData.Expiry.UnixMilli(),
+	}
+	wrong := "000000"
+	if tokenData.Token == wrong {
+		wrong = "111111"
+	}
+
+	th.store.On("UsersGetByEmail", mock.Anything, user.GetEmail()).Return(user, nil)
+	th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil)
+	th.store.On("TokensGetUnusedByType", mock.Anything, user.GetId(), intModels.TokenTypeEmailLogin).Return([]*pb.Token{token}, nil)
+
+	finish := func(t *testing.T, code string) *pbAcc.EmailLoginFinishResponse {
+		t.Helper()
+		res, err := th.controller.EmailLoginFinish(ctx, &pbAcc.EmailLoginFinishRequest{Email: user.GetEmail(), LoginChallenge: "fake-challenge", Code: code})
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("a wrong code is a failed login attempt", func(t *testing.T) {
+		th.store.On("TokensAttemptsIncrement", mock.Anything, token.GetId()).Return(int32(1), nil).Once()
+		th.store.On("UsersFailedAttemptsIncrement", mock.Anything, user.GetId()).Return(int32(1), nil).Once()
+
+		res := finish(t, wrong)
+		require.Equal(t, "user.login.email.invalid", res.GetError().GetId())
+	})
+
+	t.Run("the account is locked once the attempts exceed the maximum", func(t *testing.T) {
+		th.store.On("TokensAttemptsIncrement", mock.Anything, token.GetId()).Return(int32(2), nil).Once()
+		th.store.On("UsersFailedAttemptsIncrement", mock.Anything, user.GetId()).Return(int32(6), nil).Once()
+		th.store.On("UsersLock", mock.Anything, user.GetId(), mock.AnythingOfType("int64")).Return(nil).Once()
+		th.tasker.On("SendAccountLockedEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		res := finish(t, wrong)
+		require.Equal(t, "user.login.locked.error", res.GetError().GetId())
+	})
+
+	t.Run("the right code accepts the login", func(t *testing.T) {
+		th.store.On("TokensMarkUsed", mock.Anything, token.GetId()).Return(nil).Once()
+		th.store.On("UsersLoginSucceeded", mock.Anything, user.GetId()).Return(nil).Once()
+		th.store.On("UserSessionsAdd", mock.Anything, mock.Anything).Return(nil).Once()
+		th.store.On("UserLoginDevicesAdd", mock.Anything, mock.Anything).Return(&intModels.LoginDeviceHistory{}, nil).Once()
+
+		res := finish(t, tokenData.Token)
+		require.Nil(t, res.GetError())
+		require.Equal(t, "http://hydra.local/done", res.GetData().GetMetadata()["redirect_to"])
+	})
+
+	t.Run("a code redeemed by a concurrent request is refused", func(t *testing.T) {
+		th.store.On("TokensMarkUsed", mock.Anything, token.GetId()).Return(&models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+
+		res := finish(t, tokenData.Token)
+		require.Equal(t, "user.login.email.invalid", res.GetError().GetId())
+	})
+}
@@ -0,0 +1,322 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"google.golang.org/grpc/codes"
+)
+
+// IdentitiesList returns the identities (the upstream provider accounts) of the
+// authenticated user
+func (c *Controller) IdentitiesList(context context.Context, req *pbAcc.IdentitiesListRequest) (*pbAcc.IdentitiesListResponse, error) {
+	start := time.Now()
+	path := "users.controller.IdentitiesList"
+	errBuilder := func(e *models.AppError) (*pbAcc.IdentitiesListResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordIdentityRequest(false, duration)
+		return &pbAcc.IdentitiesListResponse{Response: &pbAcc.IdentitiesListResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameIdentitiesList, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil))
+	}
+
+	identities, dbErr := c.store.IdentitiesGetByUserID(ctx, userID)
+	if dbErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, dbErr.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: dbErr}))
+	}
+
+	list := make([]*pbAcc.Identity, 0, len(identities))
+	for _, i := range identities {
+		list = append(list, i.ToProto())
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordIdentityRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.identity.list.success", nil)
+	return &pbAcc.IdentitiesListResponse{Response: &pbAcc.IdentitiesListResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}, Identities: list}, nil
+}
+
+// IdentityLinkBegin re-authenticates the user, and returns the url that starts linking an
+// account of the provider on the OAuth server, the users without a password re-authenticate
+// by a recent login instead
+func (c *Controller) IdentityLinkBegin(context context.Context, req *pbAcc.IdentityLinkBeginRequest) (*pbAcc.IdentityLinkBeginResponse, error) {
+	start := time.Now()
+	path := "users.controller.IdentityLinkBegin"
+	errBuilder := func(e *models.AppError) (*pbAcc.IdentityLinkBeginResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordIdentityRequest(false, duration)
+		return &pbAcc.IdentityLinkBeginResponse{Response: &pbAcc.IdentityLinkBeginResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+	internalErr := func(ctx *models.Context, err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameIdentityLinkBegin, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+	models.AuditEventDataParameter(ar, "provider", req.GetProvider())
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil))
+	}
+
+	if err := intModels.IdentityLinkBeginRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	if p, ok := c.srvCfg.OAuth.SocialProviders[req.GetProvider()]; !ok || p.ClientID == "" {
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"provider": {ID: "user.identity.provider.invalid"}}}
+		return errBuilder(models.NewAppError(ctx, path, "user.identity.provider.invalid", nil, "", int(codes.InvalidArgument), errors))
+	}
+
+	user, dbErr := c.store.UsersGetByID(ctx, userID)
+	if dbErr != nil {
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	identities, dbErr := c.store.IdentitiesGetByUserID(ctx, userID)
+	if dbErr != nil {
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+	for _, i := range identities {
+		if i.Provider == req.GetProvider() {
+			return errBuilder(models.NewAppError(ctx, path, "user.identity.already_linked", nil, "", int(codes.AlreadyExists), nil))
+		}
+	}
+
+	if err := c.identityReauth(ctx, path, user, req); err != nil {
+		return errBuilder(err)
+	}
+
+	ticket := &intModels.SocialLoginState{
+		ID:         intModels.SocialStateNewID(),
+		Provider:   req.GetProvider(),
+		LinkUserID: userID,
+		ExpiresAt:  time.Now().Add(time.Minute * 5).UnixMilli(),
+	}
+	if err := c.store.SocialLoginStatesAdd(ctx, ticket); err != nil {
+		return errBuilder(internalErr(ctx, err, err.Details))
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordIdentityRequest(true, duration)
+
+	backend := strings.TrimSuffix(c.config().Oauth.GetOauthBackendUrl(), "/")
+	redirectTo := fmt.Sprintf("%s/social/%s?link_ticket=%s", backend, url.PathEscape(req.GetProvider()), url.QueryEscape(ticket.ID))
+	msg := models.Tr(ctx.AcceptLanguage, "user.identity.link_begin.success", nil)
+	return &pbAcc.IdentityLinkBeginResponse{Response: &pbAcc.IdentityLinkBeginResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg, Metadata: map[string]string{"redirect_to": redirectTo}}}}, nil
+}
+
+// IdentityLinkConfirm links the provider account of a pending link (saved by the OAuth
+// server after the provider sign in) to the authenticated user, the link must have been
+// started by that same user, so a link ticket handed to someone else links nothing
+func (c *Controller) IdentityLinkConfirm(context context.Context, req *pbAcc.IdentityLinkConfirmRequest) (*pbAcc.IdentityLinkConfirmResponse, error) {
+	start := time.Now()
+	path := "users.controller.IdentityLinkConfirm"
+	errBuilder := func(e *models.AppError) (*pbAcc.IdentityLinkConfirmResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordIdentityRequest(false, duration)
+		return &pbAcc.IdentityLinkConfirmResponse{Response: &pbAcc.IdentityLinkConfirmResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+	internalErr := func(ctx *models.Context, err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameIdentityLinkConfirm, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil))
+	}
+
+	if err := intModels.IdentityLinkConfirmRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	invalid := models.NewAppError(ctx, path, "user.identity.link.invalid", nil, "", int(codes.InvalidArgument), nil)
+	link, dbErr := c.store.SocialLoginStatesTake(ctx, req.GetLinkId())
+	if dbErr != nil {
+		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			return errBuilder(invalid)
+		}
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+	info := link.LinkIdentity
+	if info == nil || info.Subject == "" || time.Now().UnixMilli() > link.ExpiresAt {
+		return errBuilder(invalid)
+	}
+	models.AuditEventDataParameter(ar, "provider", link.Provider)
+	if link.LinkUserID != userID {
+		models.AuditEventDataParameter(ar, "link_user_id", link.LinkUserID)
+		return errBuilder(invalid)
+	}
+
+	// linking the provider account again to the same user succeeds
+	owner, dbErr := c.store.UsersGetByIdentity(ctx, link.Provider, info.Subject)
+	switch {
+	case dbErr == nil && owner.GetId() != userID:
+		return errBuilder(models.NewAppError(ctx, path, "user.identity.linked_to_other", nil, "", int(codes.AlreadyExists), nil))
+	case dbErr == nil:
+	case dbErr.ErrType != models.DBErrorTypeNoRows:
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	default:
+		if dbErr := c.store.IdentitiesAdd(ctx, intModels.SocialIdentityNew(userID, link.Provider, info)); dbErr != nil {
+			if dbErr.ErrType == models.DBErrorTypeUniqueViolation {
+				return errBuilder(models.NewAppError(ctx, path, "user.identity.already_linked", nil, "", int(codes.AlreadyExists), nil))
+			}
+			return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+		}
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordIdentityRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.identity.link_confirm.success", nil)
+	return &pbAcc.IdentityLinkConfirmResponse{Response: &pbAcc.IdentityLinkConfirmResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}}, nil
+}
+
+// IdentityUnlink removes an identity of the authenticated user, unless it's the last way
+// the user can sign in with
+func (c *Controller) IdentityUnlink(context context.Context, req *pbAcc.IdentityUnlinkRequest) (*pbAcc.IdentityUnlinkResponse, error) {
+	start := time.Now()
+	path := "users.controller.IdentityUnlink"
+	errBuilder := func(e *models.AppError) (*pbAcc.IdentityUnlinkResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordIdentityRequest(false, duration)
+		return &pbAcc.IdentityUnlinkResponse{Response: &pbAcc.IdentityUnlinkResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+	internalErr := func(ctx *models.Context, err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameIdentityUnlink, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+	models.AuditEventDataParameter(ar, "identity_id", req.GetIdentityId())
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil))
+	}
+
+	if err := intModels.IdentityUnlinkRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	// the login methods are counted in the transaction of the removal, two concurrent
+	// unlinks can't leave the user without any
+	unlinked, dbErr := c.store.IdentitiesDelete(ctx, userID, req.GetIdentityId())
+	if dbErr != nil {
+		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			return errBuilder(models.NewAppError(ctx, path, "error.not_found", nil, "identity not found", int(codes.NotFound), nil))
+		}
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+	if !unlinked {
+		return errBuilder(models.NewAppError(ctx, path, "user.identity.last_login_method", nil, "", int(codes.FailedPrecondition), nil))
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordIdentityRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.identity.unlink.success", nil)
+	return &pbAcc.IdentityUnlinkResponse{Response: &pbAcc.IdentityUnlinkResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}}, nil
+}
+
+// identityProvider returns the provider of the oldest identity of the user, the users
+// without a password sign in with it, it's empty if the user has no identity
+func (c *Controller) identityProvider(ctx *models.Context, user *pb.User) (string, *models.DBError) {
+	identities, err := c.store.IdentitiesGetByUserID(ctx, user.GetId())
+	if err != nil {
+		return "", err
+	}
+	if len(identities) == 0 {
+		return "", nil
+	}
+	return identities[0].Provider, nil
+}
+
+// identityPasswordlessError is the error of the password endpoints for the users without a
+// password, it tells the provider they sign in with, nil is returned if the user has a password
+func (c *Controller) identityPasswordlessError(ctx *models.Context, path string, user *pb.User) *models.AppError {
+	if user.GetPassword() != "" {
+		return nil
+	}
+
+	provider, err := c.identityProvider(ctx, user)
+	if err != nil {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+	if provider == "" {
+		return models.NewAppError(ctx, path, "user.login.password.error", nil, "the user has no password", int(codes.InvalidArgument), nil)
+	}
+	return models.NewAppError(ctx, path, "user.login.use_auth_service.error", map[string]any{"AuthService": provider}, "", int(codes.InvalidArgument), nil)
+}
+
+// identityReauth checks the password (or, for the users without one, that the session is
+// recent), and the MFA code if the user has MFA active, the password is guessed against
+// the same lockout as the login
+func (c *Controller) identityReauth(ctx *models.Context, path string, user *pb.User, req *pbAcc.IdentityLinkBeginRequest) *models.AppError {
+	if user.GetPassword() != "" {
+		if err := c.passwordReauth(ctx, path, user, req.GetPassword()); err != nil {
+			return err
+		}
+	} else {
+		window := time.Duration(c.srvCfg.Auth.ReauthMinutes) * time.Minute
+		if !intModels.IdentityReauthIsRecent(ctx.Session.CreatedAt, time.Now(), window) {
+			return models.NewAppError(ctx, path, "user.identity.reauth_required", nil, "", int(codes.FailedPrecondition), nil)
+		}
+	}
+
+	if user.GetMfaActive() {
+		valid, err := c.mfaCodeAccept(ctx, path, user, req.GetMfa())
+		if err != nil {
+			return err
+		}
+		if !valid {
+			errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"mfa": {ID: "user.mfa.code.invalid"}}}
+			return models.NewAppError(ctx, path, "user.mfa.code.invalid", nil, "", int(codes.InvalidArgument), errors)
+		}
+	}
+
+	return nil
+}
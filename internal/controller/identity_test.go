@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+func TestIdentitiesList(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""), "user.identity.list.success")
+	defer th.TearDown()
+
+	userID := th.Customer1.User.GetId()
+	ctx := th.withUser(t, th.Customer1, "")
+	identity := &intModels.Identity{ID: utils.NewID(), UserID: userID, Provider: "google", Subject: "fake-subject", Email: "jane@example.com", CreatedAt: 1700000000000}
+	th.store.On("IdentitiesGetByUserID", mock.Anything, userID).Return([]*intModels.Identity{identity}, nil).Once()
+
+	res, err := th.controller.IdentitiesList(ctx, &pbAcc.IdentitiesListRequest{})
+	require.NoError(t, err)
+	require.Nil(t, res.GetError())
+	require.Len(t, res.GetIdentities(), 1)
+	require.Equal(t, identity.ID, res.GetIdentities()[0].GetId())
+	require.Equal(t, "google", res.GetIdentities()[0].GetProvider())
+	require.Equal(t, identity.CreatedAt, res.GetIdentities()[0].GetCreatedAt())
+}
+
+func TestIdentityLinkBegin(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""),
+		"user.identity.link_begin.success",
+		"user.login.password.error",
+		"user.login.locked.error",
+	)
+	defer th.TearDown()
+	th.srvCfg.OAuth.SocialProviders = map[string]intModels.SocialProvider{"fake": {ClientID: "fake-client"}}
+
+	user := th.Customer1.User
+	ctx := th.withUser(t, th.Customer1, "current-pass1")
+
+	linkBegin := func(t *testing.T, password string) *pbAcc.IdentityLinkBeginResponse {
+		t.Helper()
+		res, err := th.controller.IdentityLinkBegin(ctx, &pbAcc.IdentityLinkBeginRequest{Provider: "fake", Password: password})
+		require.NoError(t, err)
+		return res
+	}
+
+	th.store.On("UsersGetByID", mock.Anything, user.GetId()).Return(user, nil)
+	th.store.On("IdentitiesGetByUserID", mock.Anything, user.GetId()).Return([]*intModels.Identity{}, nil)
+
+	t.Run("a wrong password is counted as a failed attempt", func(t *testing.T) {
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil).Once()
+		th.store.On("UsersFailedAttemptsIncrement", mock.Anything, user.GetId()).Return(int32(1), nil).Once()
+
+		require.Equal(t, "user.login.password.error", linkBegin(t, "wrong-pass1").GetError().GetId())
+	})
+
+	t.Run("the account is locked once the attempts exceed the maximum", func(t *testing.T) {
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil).Once()
+		th.store.On("UsersFailedAttemptsIncrement", mock.Anything, user.GetId()).Return(int32(6), nil).Once()
+		th.store.On("UsersLock", mock.Anything, user.GetId(), mock.AnythingOfType("int64")).Return(nil).Once()
+		th.tasker.On("SendAccountLockedEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		require.Equal(t, "user.login.locked.error", linkBegin(t, "wrong-pass1").GetError().GetId())
+	})
+
+	t.Run("the right password issues a link ticket", func(t *testing.T) {
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil).Once()
+		th.store.On("SocialLoginStatesAdd", mock.Anything, mock.MatchedBy(func(s *intModels.SocialLoginState) bool {
+			return s.LinkUserID == user.GetId() && s.Provider == "fake"
+		})).Return(nil).Once()
+
+		res := linkBegin(t, "current-pass1")
+		require.Nil(t, res.GetError())
+		require.Contains(t, res.GetData().GetMetadata()["redirect_to"], "/social/fake?link_ticket=")
+	})
+}
+
+func TestIdentityLinkConfirm(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""),
+		"user.identity.link_confirm.success",
+		"user.identity.link.invalid",
+	)
+	defer th.TearDown()
+
+	userID := th.Customer1.User.GetId()
+	ctx := th.withUser(t, th.Customer1, "")
+	info := &intModels.SocialUserInfo{Subject: "fake-subject", Email: "jane@example.com"}
+
+	confirm := func(t *testing.T, link *intModels.SocialLoginState) *pbAcc.IdentityLinkConfirmResponse {
+		t.Helper()
+		th.store.On("SocialLoginStatesTake", mock.Anything, link.ID).Return(link, nil).Once()
+		res, err := th.controller.IdentityLinkConfirm(ctx, &pbAcc.IdentityLinkConfirmRequest{LinkId: link.ID})
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("the link started by another user is refused", func(t *testing.T) {
+		link := &intModels.SocialLoginState{ID: "link-other", Provider: "fake", LinkUserID: utils.NewID(), LinkIdentity: info, ExpiresAt: time.Now().Add(time.Minute).UnixMilli()}
+
+		require.Equal(t, "user.identity.link.invalid", confirm(t, link).GetError().GetId())
+		th.store.AssertNotCalled(t, "IdentitiesAdd", mock.Anything, mock.Anything)
+	})
+
+	t.Run("an expired link is refused", func(t *testing.T) {
+		link := &intModels.SocialLoginState{ID: "link-expired", Provider: "fake", LinkUserID: userID, LinkIdentity: info, ExpiresAt: time.Now().Add(-time.Minute).UnixMilli()}
+
+		require.Equal(t, "user.identity.link.invalid", confirm(t, link).GetError().GetId())
+		th.store.AssertNotCalled(t, "IdentitiesAdd", mock.Anything, mock.Anything)
+	})
+
+	t.Run("the user who started the link confirms it", func(t *testing.T) {
+		link := &intModels.SocialLoginState{ID: "link-own", Provider: "fake", LinkUserID: userID, LinkIdentity: info, ExpiresAt: time.Now().Add(time.Minute).UnixMilli()}
+		th.store.On("UsersGetByIdentity", mock.Anything, "fake", info.Subject).Return(nil, &models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+		th.store.On("IdentitiesAdd", mock.Anything, mock.MatchedBy(func(i *intModels.Identity) bool {
+			return i.UserID == userID && i.Provider == "fake" && i.Subject == info.Subject
+		})).Return(nil).Once()
+
+		res := confirm(t, link)
+		require.Nil(t, res.GetError())
+		require.Equal(t, "user.identity.link_confirm.success", res.GetData().GetMessage())
+	})
+}
+
+func TestIdentityUnlink(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""),
+		"user.identity.unlink.success",
+		"user.identity.last_login_method",
+	)
+	defer th.TearDown()
+
+	userID := th.Customer1.User.GetId()
+	ctx := th.withUser(t, th.Customer1, "")
+	identityID := utils.NewID()
+
+	unlink := func(t *testing.T) *pbAcc.IdentityUnlinkResponse {
+		t.Helper()
+		res, err := th.controller.IdentityUnlink(ctx, &pbAcc.IdentityUnlinkRequest{IdentityId: identityID})
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("the identity is unlinked", func(t *testing.T) {
+		th.store.On("IdentitiesDelete", mock.Anything, userID, identityID).Return(true, nil).Once()
+
+		res := unlink(t)
+		require.Nil(t, res.GetError())
+		require.Equal(t, "user.identity.unlink.success", res.GetData().GetMessage())
+	})
+
+	t.Run("the last login method is kept", func(t *testing.T) {
+		th.store.On("IdentitiesDelete", mock.Anything, userID, identityID).Return(false, nil).Once()
+
+		require.Equal(t, "user.identity.last_login_method", unlink(t).GetError().GetId())
+	})
+
+	t.Run("an unknown identity isn't found", func(t *testing.T) {
+		th.store.On("IdentitiesDelete", mock.Anything, userID, identityID).Return(false, &models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+
+		require.Equal(t, "error.not_found", unlink(t).GetError().GetId())
+	})
+}
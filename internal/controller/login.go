@@ -5,15 +5,21 @@ import (
 	ctxPkg "context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
 	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/worker"
 	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/hibiken/asynq"
+	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 )
 
 func (c *Controller) Login(context ctxPkg.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
@@ -49,52 +55,222 @@ func (c *Controller) Login(context ctxPkg.Context, req *pb.LoginRequest) (*pb.Lo
 		return errBuilder(err)
 	}
 
-	user, err := c.store.UsersGetByEmail(ctx, req.GetEmail())
+	// the privacy mode can't challenge depending on the account (its failed attempts), that
+	// discloses whether it exists, so every login is challenged instead
+	privacy := c.srvCfg.Auth.PrivacyMode
+	if privacy {
+		if err := c.captchaCheck(ctx, context, "Login"); err != nil {
+			duration := time.Since(startTime).Seconds()
+			c.metricsCollector.RecordLoginRequest(false, duration)
+			return errBuilder(err)
+		}
+	}
+
+	identifierType := intModels.LoginIdentifierTypeGet(req.GetEmail())
+	var user *pb.User
+	var err *models.DBError
+	if identifierType == intModels.LoginIdentifierEmail {
+		user, err = c.store.UsersGetByEmail(ctx, req.GetEmail())
+	} else {
+		user, err = c.store.UsersGetByUsername(ctx, req.GetEmail())
+	}
 	if err != nil {
 		duration := time.Since(startTime).Seconds()
 		c.metricsCollector.RecordLoginRequest(false, duration)
 		if err.ErrType == models.DBErrorTypeNoRows {
-			errors := &models.AppErrorErrorsArgs{Err: err, ErrorsInternal: map[string]*models.AppErrorError{"email": {ID: "email.not_found"}}}
-			return errBuilder(models.NewAppError(ctx, path, "email.not_found", nil, err.Details, int(codes.NotFound), errors))
+			if privacy {
+				c.loginDummyPasswordCheck(req.GetPassword())
+				return errBuilder(loginInvalidCredentialsError(ctx, path))
+			}
+			id := "email.not_found"
+			if identifierType == intModels.LoginIdentifierUsername {
+				id = "username.not_found"
+			}
+			errors := &models.AppErrorErrorsArgs{Err: err, ErrorsInternal: map[string]*models.AppErrorError{"email": {ID: id}}}
+			return errBuilder(models.NewAppError(ctx, path, id, nil, err.Details, int(codes.NotFound), errors))
 		} else {
 			return internalErr(err, err.Details)
 		}
 	}
 
-	if user.GetAuthService() != "" {
+	// the users without a password sign in with a linked identity (or a passkey)
+	if user.GetPassword() == "" {
 		duration := time.Since(startTime).Seconds()
 		c.metricsCollector.RecordLoginRequest(false, duration)
-		return errBuilder(models.NewAppError(ctx, path, "user.login.use_auth_service.error", map[string]any{"AuthService": user.GetAuthService()}, "", int(codes.InvalidArgument), nil))
+		if privacy {
+			c.loginDummyPasswordCheck(req.GetPassword())
+			return errBuilder(loginInvalidCredentialsError(ctx, path))
+		}
+		return errBuilder(c.identityPasswordlessError(ctx, path, user))
 	}
-	if err := utils.PasswordCheck(user.GetPassword(), req.GetPassword()); err != nil {
+
+	lockedUntil, err := c.store.UsersGetLockedUntil(ctx, user.GetId())
+	if err != nil {
 		duration := time.Since(startTime).Seconds()
 		c.metricsCollector.RecordLoginRequest(false, duration)
-		errors := &models.AppErrorErrorsArgs{Err: err, ErrorsInternal: map[string]*models.AppErrorError{"password": {ID: "user.login.password.error"}}}
+		return internalErr(err, err.Details)
+	}
+	if remaining := time.Until(time.UnixMilli(lockedUntil)); remaining > 0 {
+		duration := time.Since(startTime).Seconds()
+		c.metricsCollector.RecordLoginRequest(false, duration)
+		// the user was told by email, the response stays the one of the unknown accounts
+		if privacy {
+			c.loginDummyPasswordCheck(req.GetPassword())
+			return errBuilder(loginInvalidCredentialsError(ctx, path))
+		}
+		params := map[string]any{"Minutes": int(math.Ceil(remaining.Minutes()))}
+		return errBuilder(models.NewAppError(ctx, path, "user.login.locked.error", params, "", int(codes.PermissionDenied), nil))
+	}
+
+	if !privacy {
+		if err := c.loginCaptchaCheck(ctx, context, user); err != nil {
+			duration := time.Since(startTime).Seconds()
+			c.metricsCollector.RecordLoginRequest(false, duration)
+			return errBuilder(err)
+		}
+	}
+
+	rehash, passErr := intModels.PasswordCheck(c.passwordHasher, user.GetPassword(), req.GetPassword())
+	if passErr != nil {
+		duration := time.Since(startTime).Seconds()
+		c.metricsCollector.RecordLoginRequest(false, duration)
+		lockErr := c.loginLockIfExceeded(ctx, user)
+		if lockErr != nil && !(privacy && lockErr.ID == "user.login.locked.error") {
+			return errBuilder(lockErr)
+		}
+		if privacy {
+			return errBuilder(loginInvalidCredentialsError(ctx, path))
+		}
+		if lockErr != nil {
+			return errBuilder(lockErr)
+		}
+		errors := &models.AppErrorErrorsArgs{Err: passErr, ErrorsInternal: map[string]*models.AppErrorError{"password": {ID: "user.login.password.error"}}}
 		return errBuilder(models.NewAppError(ctx, path, "user.login.password.error", nil, "", int(codes.InvalidArgument), errors))
 	}
 
-	// TODO: handle if this user is using mobile or not
-	expiry := c.config().Security.GetAccessTokenExpiryWebInHours()
+	// checked after the password, so the verification state isn't disclosed to anyone knowing only the email
+	if err := c.loginEmailVerifiedCheck(ctx, path, user); err != nil {
+		duration := time.Since(startTime).Seconds()
+		c.metricsCollector.RecordLoginRequest(false, duration)
+		return errBuilder(err)
+	}
+
+	if user.GetMfaActive() {
+		if req.GetMfa() == "" {
+			meta, err := c.loginMfaChallenge(ctx, user)
+			duration := time.Since(startTime).Seconds()
+			c.metricsCollector.RecordLoginRequest(err == nil, duration)
+			if err != nil {
+				return errBuilder(err)
+			}
+			msg := models.Tr(ctx.AcceptLanguage, "user.login.mfa_required", nil)
+			return sucBuilder(&pbSh.SuccessResponseData{Message: &msg, Metadata: meta})
+		}
+
+		if err := c.loginMfaVerify(ctx, context, user, req.GetMfa()); err != nil {
+			duration := time.Since(startTime).Seconds()
+			c.metricsCollector.RecordLoginRequest(false, duration)
+			return errBuilder(err)
+		}
+	}
+
+	if rehash {
+		c.loginPasswordRehash(ctx, user.GetId(), req.GetPassword())
+	}
+
+	if err := c.store.UsersLoginSucceeded(ctx, user.GetId()); err != nil {
+		duration := time.Since(startTime).Seconds()
+		c.metricsCollector.RecordLoginRequest(false, duration)
+		return internalErr(err, err.Details)
+	}
+
+	redirectTo, acceptErr := c.oauthLoginAccept(ctx, context, user, req.GetLoginChallenge())
+	if acceptErr != nil {
+		totalDuration := time.Since(startTime).Seconds()
+		c.metricsCollector.RecordLoginRequest(false, totalDuration)
+		return errBuilder(acceptErr)
+	}
+
+	ar.Success()
+
+	totalDuration := time.Since(startTime).Seconds()
+	c.metricsCollector.RecordLoginRequest(true, totalDuration)
+
+	meta := map[string]string{"redirect_to": redirectTo}
+	return sucBuilder(&pbSh.SuccessResponseData{Metadata: meta})
+}
+
+// loginDummyPasswordCheck compares the password against a dummy hash when there is no user
+// password to check, so the unknown emails take about the same time to answer as the registered ones
+func (c *Controller) loginDummyPasswordCheck(password string) {
+	c.dummyPasswordOnce.Do(func() {
+		c.dummyPasswordHash, _ = intModels.PasswordHash(c.passwordHasher, "megacommerce-dummy-password")
+	})
+	_, _ = intModels.PasswordCheck(c.passwordHasher, c.dummyPasswordHash, password)
+}
+
+// loginPasswordRehash replaces the user's password hash by one of the current hasher, a
+// failure doesn't affect the login, the hash is upgraded on a later login
+func (c *Controller) loginPasswordRehash(ctx *models.Context, userID, password string) {
+	hash, err := intModels.PasswordHash(c.passwordHasher, password)
+	if err != nil {
+		c.log.ErrorStruct("failed to rehash the user's password", err)
+		return
+	}
+
+	if err := c.store.UsersPasswordRehash(ctx, userID, hash); err != nil {
+		c.log.ErrorStruct("failed to store the user's rehashed password", err)
+	}
+}
+
+// loginInvalidCredentialsError is the error of the privacy mode, it doesn't tell whether
+// the email or the password is wrong
+func loginInvalidCredentialsError(ctx *models.Context, path string) *models.AppError {
+	errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"email": {ID: "user.login.invalid_credentials.error"}}}
+	return models.NewAppError(ctx, path, "user.login.invalid_credentials.error", nil, "", int(codes.InvalidArgument), errors)
+}
+
+// oauthLoginAccept accepts the OAuth login request identified by the given challenge for
+// the user, and returns the url that the user should be redirected to, the session lifetime
+// follows the client platform and the remember me choice sent in the request metadata,
+// the login session is tracked so the user can list and revoke it later, and the logins
+// from a new device are reported to the user
+func (c *Controller) oauthLoginAccept(ctx *models.Context, context ctxPkg.Context, user *pb.User, challenge string) (string, *models.AppError) {
+	path := "users.controller.oauthLoginAccept"
+	internalErr := func(err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	md, _ := metadata.FromIncomingContext(context)
+	session := intModels.LoginSessionOptionsGet(md)
+
+	// a failure to track the session shouldn't prevent the user from logging in
+	sessionID, sidErr := c.oauthLoginSessionID(ctx, challenge)
+	if sidErr != nil {
+		c.log.ErrorStruct("failed to get the login session id of the login request", sidErr)
+	}
+
+	security := c.config().Security
 	body := map[string]any{
 		"subject":      user.GetId(),
-		"remember":     true,
-		"remember_for": expiry * 60 * 60,
-		"context": map[string]any{
+		"remember":     session.Remember,
+		"remember_for": session.RememberFor(security.GetAccessTokenExpiryWebInHours(), security.GetAccessTokenExpiryMobileInHours()),
+		"context": session.Context(map[string]any{
 			"lang":       ctx.AcceptLanguage,
 			"email":      user.GetEmail(),
 			"first_name": user.GetFirstName(),
-		},
+		}),
 	}
 
 	oauthPayload, marErr := json.Marshal(body)
 	if marErr != nil {
-		return internalErr(marErr, "failed to marshal json payload")
+		return "", internalErr(marErr, "failed to marshal json payload")
 	}
 
-	reqURL := fmt.Sprintf("%s/oauth2/auth/requests/login/accept?login_challenge=%s", c.config().Oauth.GetOauthAdminUrl(), req.GetLoginChallenge())
+	reqURL := fmt.Sprintf("%s/oauth2/auth/requests/login/accept?login_challenge=%s", c.config().Oauth.GetOauthAdminUrl(), challenge)
 	oauthReq, reqErr := http.NewRequestWithContext(ctx.Context, http.MethodPut, reqURL, bytes.NewReader(oauthPayload))
 	if reqErr != nil {
-		return internalErr(reqErr, "failed to build login/accept HTTP request to send to OAuth service")
+		return "", internalErr(reqErr, "failed to build login/accept HTTP request to send to OAuth service")
 	}
 	oauthReq.Header.Set("Content-Type", "application/json")
 
@@ -103,25 +279,19 @@ func (c *Controller) Login(context ctxPkg.Context, req *pb.LoginRequest) (*pb.Lo
 	resp, respErr := utils.HTTPRequestWithRetry(c.httpClient, oauthReq, 3)
 	duration := time.Since(start)
 	if respErr != nil {
-		c.log.Errorf("HTTP %s %s failed: %v (took %s)", oauthReq.Method, oauthReq.URL, err, duration)
-		totalDuration := time.Since(startTime).Seconds()
-		c.metricsCollector.RecordLoginRequest(false, totalDuration)
-		return internalErr(respErr, "failed to request OAuth server to accept login")
+		c.log.Errorf("HTTP %s %s failed: %v (took %s)", oauthReq.Method, oauthReq.URL, respErr, duration)
+		return "", internalErr(respErr, "failed to request OAuth server to accept login")
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= http.StatusInternalServerError {
-		totalDuration := time.Since(startTime).Seconds()
-		c.metricsCollector.RecordLoginRequest(false, totalDuration)
-		return internalErr(respErr, "failed to request OAuth server to accept login")
+		return "", internalErr(respErr, "failed to request OAuth server to accept login")
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		totalDuration := time.Since(startTime).Seconds()
-		c.metricsCollector.RecordLoginRequest(false, totalDuration)
 		var resErr intModels.OAuthErrorResponse
 		if err := json.NewDecoder(resp.Body).Decode(&resErr); err != nil {
-			return internalErr(err, "failed to unmarshal login/accept response from Oauth service error")
+			return "", internalErr(err, "failed to unmarshal login/accept response from Oauth service error")
 		}
 		errors := &models.AppErrorErrorsArgs{
 			Err: respErr,
@@ -130,28 +300,241 @@ func (c *Controller) Login(context ctxPkg.Context, req *pb.LoginRequest) (*pb.Lo
 				"error_description": {ID: intModels.GetOAuthRequestErrMsgID(ctx.AcceptLanguage, resErr.Error, resErr.ErrorDescription)},
 			},
 		}
-		return errBuilder(models.NewAppError(ctx, path, "login.error", nil, "", int(codes.InvalidArgument), errors))
+		return "", models.NewAppError(ctx, path, "login.error", nil, "", int(codes.InvalidArgument), errors)
 	}
 
 	var result struct {
 		RedirectTo string `json:"redirect_to"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		totalDuration := time.Since(startTime).Seconds()
-		c.metricsCollector.RecordLoginRequest(false, totalDuration)
-		return internalErr(err, "failed to unmarshal response from login/accept Oauth service")
+		return "", internalErr(err, "failed to unmarshal response from login/accept Oauth service")
 	}
 	if result.RedirectTo == "" {
-		totalDuration := time.Since(startTime).Seconds()
-		c.metricsCollector.RecordLoginRequest(false, totalDuration)
-		return internalErr(err, "received an empty redirect_url from OAuth service login/accept")
+		return "", internalErr(nil, "received an empty redirect_url from OAuth service login/accept")
+	}
+
+	userSession := intModels.UserSessionNew(ctx, md, sessionID, user.GetId(), c.srvCfg.Service.TrustedProxies)
+	if sessionID != "" {
+		if err := c.store.UserSessionsAdd(ctx, userSession); err != nil {
+			c.log.ErrorStruct("failed to store the user's login session", err)
+		}
 	}
+	c.loginNewDeviceCheck(ctx, context, user, userSession)
 
+	return result.RedirectTo, nil
+}
+
+// SocialLoginAccept accepts the OAuth login request of a user signed in with a social provider
+// by the OAuth server, the login is recorded, the session tracked and the new device reported
+// like the other logins, the session options of the social login replace the grpc metadata
+func (c *Controller) SocialLoginAccept(ctx *models.Context, user *pb.User, challenge string, session intModels.LoginSessionOptions) (string, *models.AppError) {
+	path := "users.controller.SocialLoginAccept"
+	if err := c.store.UsersLoginSucceeded(ctx, user.GetId()); err != nil {
+		return "", models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	md := metadata.Pairs(
+		intModels.LoginClientPlatformHeader, string(session.Platform),
+		intModels.LoginRememberMeHeader, strconv.FormatBool(session.Remember),
+	)
+	return c.oauthLoginAccept(ctx, metadata.NewIncomingContext(ctx.Context, md), user, challenge)
+}
+
+// loginLockIfExceeded records a failed login attempt, and locks the account once the
+// attempts exceed the configured maximum, the lock duration doubles with every extra attempt
+func (c *Controller) loginLockIfExceeded(ctx *models.Context, user *pb.User) *models.AppError {
+	path := "users.controller.loginLockIfExceeded"
+	attempts, err := c.store.UsersFailedAttemptsIncrement(ctx, user.GetId())
+	if err != nil {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	auth := c.srvCfg.Auth
+	lock := intModels.LoginLockoutDuration(
+		attempts,
+		c.config().Security.GetMaximumLoginAttempts(),
+		time.Duration(auth.LockoutBaseMinutes)*time.Minute,
+		time.Duration(auth.LockoutMaxMinutes)*time.Minute,
+	)
+	if lock <= 0 {
+		return nil
+	}
+
+	until := time.Now().Add(lock)
+	if err := c.store.UsersLock(ctx, user.GetId(), until.UnixMilli()); err != nil {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	minutes := int(lock.Minutes())
+	pay := &intModels.TaskSendAccountLockedEmailPayload{Ctx: ctx, Email: user.GetEmail(), Attempts: int(attempts), Minutes: minutes}
+	options := []asynq.Option{asynq.MaxRetry(10), asynq.Queue(worker.QueuePriorityCritical)}
+	if err := c.tasker.SendAccountLockedEmail(ctx.Context, pay, options...); err != nil {
+		c.log.ErrorStruct("failed to enqueue the account locked email", err)
+	}
+
+	return models.NewAppError(ctx, path, "user.login.locked.error", map[string]any{"Minutes": minutes}, "", int(codes.PermissionDenied), nil)
+}
+
+// passwordReauth checks the password of the authenticated user before a sensitive change,
+// the wrong passwords count towards the lockout like the failed logins do
+func (c *Controller) passwordReauth(ctx *models.Context, path string, user *pb.User, password string) *models.AppError {
+	lockedUntil, dbErr := c.store.UsersGetLockedUntil(ctx, user.GetId())
+	if dbErr != nil {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, dbErr.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: dbErr})
+	}
+	if remaining := time.Until(time.UnixMilli(lockedUntil)); remaining > 0 {
+		params := map[string]any{"Minutes": int(math.Ceil(remaining.Minutes()))}
+		return models.NewAppError(ctx, path, "user.login.locked.error", params, "", int(codes.PermissionDenied), nil)
+	}
+
+	if _, err := intModels.PasswordCheck(c.passwordHasher, user.GetPassword(), password); err != nil {
+		if lockErr := c.loginLockIfExceeded(ctx, user); lockErr != nil {
+			return lockErr
+		}
+		errors := &models.AppErrorErrorsArgs{Err: err, ErrorsInternal: map[string]*models.AppErrorError{"password": {ID: "user.login.password.error"}}}
+		return models.NewAppError(ctx, path, "user.login.password.error", nil, "", int(codes.InvalidArgument), errors)
+	}
+	return nil
+}
+
+// loginMfaChallenge issues the short lived token that must be sent back (via the
+// intModels.MfaTokenIDHeader and intModels.MfaTokenHeader metadata) along with the
+// TOTP code to complete the login of a user with an active MFA
+func (c *Controller) loginMfaChallenge(ctx *models.Context, user *pb.User) (map[string]string, *models.AppError) {
+	path := "users.controller.loginMfaChallenge"
+	ttl := time.Duration(c.srvCfg.Auth.MfaChallengeMinutes) * time.Minute
+	tokenData, err := (&utils.Token{}).GenerateToken(ttl)
+	if err != nil {
+		return nil, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, "failed to generate an mfa challenge token", int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	if err := c.store.TokensAdd(ctx, user.GetId(), tokenData, intModels.TokenTypeMfaChallenge, path); err != nil {
+		return nil, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	return map[string]string{"mfa_required": "true", "mfa_token_id": tokenData.ID, "mfa_token": tokenData.Token}, nil
+}
+
+// loginCaptchaCheck requires a bot challenge once the user reached the configured failed
+// attempts, the challenge protects the accounts under a password guessing attack, the
+// other logins don't need to solve it
+func (c *Controller) loginCaptchaCheck(ctx *models.Context, context ctxPkg.Context, user *pb.User) *models.AppError {
+	if c.captchaRequired("Login") && user.GetFailedAttempts() >= c.srvCfg.Captcha.LoginFailedAttempts {
+		return c.captchaVerify(ctx, context)
+	}
+	return nil
+}
+
+// loginEmailVerifiedCheck refuses the login of an unverified email if the user type
+// requires the verification, it's checked once the user proved the credentials
+func (c *Controller) loginEmailVerifiedCheck(ctx *models.Context, path string, user *pb.User) *models.AppError {
+	if !user.GetIsEmailVerified() && c.srvCfg.Auth.EmailVerificationRequired[user.GetUserType()] {
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"email": {ID: "user.login.email_not_verified.resend_hint"}}}
+		return models.NewAppError(ctx, path, "user.login.email_not_verified.error", nil, "", int(codes.FailedPrecondition), errors)
+	}
+	return nil
+}
+
+// loginMfaVerify checks the mfa challenge token and the TOTP code of the second login step,
+// wrong codes count as failed login attempts
+func (c *Controller) loginMfaVerify(ctx *models.Context, context ctxPkg.Context, user *pb.User, code string) *models.AppError {
+	path := "users.controller.loginMfaVerify"
+	invalidToken := models.NewAppError(ctx, path, "user.login.mfa_token.invalid", nil, "", int(codes.InvalidArgument), nil)
+
+	md, _ := metadata.FromIncomingContext(context)
+	tokenIDs, tokens := md.Get(intModels.MfaTokenIDHeader), md.Get(intModels.MfaTokenHeader)
+	if len(tokenIDs) == 0 || len(tokens) == 0 {
+		return invalidToken
+	}
+
+	token, err := c.store.TokensGet(ctx, tokenIDs[0])
+	if err != nil {
+		if err.ErrType == models.DBErrorTypeNoRows {
+			return invalidToken
+		}
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	if token.GetType() != string(intModels.TokenTypeMfaChallenge) || token.GetUserId() != user.GetId() || token.GetUsed() || token.GetExpiresAt() < utils.TimeGetMillis() {
+		return invalidToken
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(token.GetToken()), []byte(tokens[0])); err != nil {
+		return invalidToken
+	}
+
+	valid := false
+	if intModels.MfaIsValidCode(code) {
+		accepted, err := c.mfaCodeAccept(ctx, path, user, code)
+		if err != nil {
+			return err
+		}
+		valid = accepted
+	} else {
+		redeemed, err := c.loginMfaRecoveryCodeRedeem(ctx, user, code)
+		if err != nil {
+			return err
+		}
+		valid = redeemed
+	}
+
+	if !valid {
+		if lockErr := c.loginLockIfExceeded(ctx, user); lockErr != nil {
+			return lockErr
+		}
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"mfa": {ID: "user.mfa.code.invalid"}}}
+		return models.NewAppError(ctx, path, "user.mfa.code.invalid", nil, "", int(codes.InvalidArgument), errors)
+	}
+
+	if err := c.store.TokensMarkUsed(ctx, token.GetId()); err != nil {
+		if err.ErrType == models.DBErrorTypeNoRows {
+			return invalidToken
+		}
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	return nil
+}
+
+// loginMfaRecoveryCodeRedeem consumes the matching unused recovery code of the user (if any),
+// every redemption is audited and the user is notified by email
+func (c *Controller) loginMfaRecoveryCodeRedeem(ctx *models.Context, user *pb.User, code string) (bool, *models.AppError) {
+	path := "users.controller.loginMfaRecoveryCodeRedeem"
+	tokens, err := c.store.TokensGetUnusedByType(ctx, user.GetId(), intModels.TokenTypeMfaRecoveryCode)
+	if err != nil {
+		return false, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	normalized := []byte(intModels.MfaNormalizeRecoveryCode(code))
+	var token *pb.Token
+	for _, t := range tokens {
+		if bcrypt.CompareHashAndPassword([]byte(t.GetToken()), normalized) == nil {
+			token = t
+			break
+		}
+	}
+	if token == nil {
+		return false, nil
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameMfaRecoveryCodeRedeem, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+	ar.Actor.UserID = user.GetId()
+	models.AuditEventDataParameter(ar, "token_id", token.GetId())
+
+	// a concurrent request redeemed the same code first
+	if err := c.store.TokensMarkUsed(ctx, token.GetId()); err != nil {
+		if err.ErrType == models.DBErrorTypeNoRows {
+			return false, nil
+		}
+		return false, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
 	ar.Success()
 
-	totalDuration := time.Since(startTime).Seconds()
-	c.metricsCollector.RecordLoginRequest(true, totalDuration)
+	pay := &intModels.TaskSendMfaRecoveryCodeUsedEmailPayload{Ctx: ctx, Email: user.GetEmail(), Remaining: len(tokens) - 1}
+	options := []asynq.Option{asynq.MaxRetry(10), asynq.Queue(worker.QueuePriorityCritical)}
+	if err := c.tasker.SendMfaRecoveryCodeUsedEmail(ctx.Context, pay, options...); err != nil {
+		c.log.ErrorStruct("failed to enqueue the mfa recovery code used email", err)
+	}
 
-	meta := map[string]string{"redirect_to": result.RedirectTo}
-	return sucBuilder(&pbSh.SuccessResponseData{Metadata: meta})
+	return true, nil
 }
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/worker"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/hibiken/asynq"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+)
+
+// loginNewDeviceCheck records the device of the login, and emails the user if the device
+// or its network wasn't seen before, failures are only logged, they don't affect the login
+func (c *Controller) loginNewDeviceCheck(ctx *models.Context, context context.Context, user *pb.User, session *intModels.UserSession) {
+	history, err := c.store.UserLoginDevicesAdd(ctx, intModels.LoginDeviceNew(session))
+	if err != nil {
+		c.log.ErrorStruct("failed to store the user's login device", err)
+		return
+	}
+	if !c.srvCfg.Auth.NewDeviceAlerts || !history.IsNew() {
+		return
+	}
+
+	auth := c.srvCfg.Auth
+	tokenData, errTok := (&utils.Token{}).GenerateToken(time.Duration(auth.LoginNotMeLinkHours) * time.Hour)
+	if errTok != nil {
+		c.log.ErrorStruct("failed to generate the login not me token", errTok)
+		return
+	}
+	if err := c.store.TokensAdd(ctx, user.GetId(), tokenData, intModels.TokenTypeLoginNotMe, "users.controller.loginNewDeviceCheck"); err != nil {
+		c.log.ErrorStruct("failed to store the login not me token", err)
+		return
+	}
+
+	q := url.Values{}
+	q.Set("token", tokenData.Token)
+	q.Set("token_id", tokenData.ID)
+
+	device := string(session.Platform)
+	if session.UserAgent != "" {
+		device = fmt.Sprintf("%s (%s)", session.UserAgent, session.Platform)
+	}
+
+	pay := &intModels.TaskSendNewDeviceLoginEmailPayload{
+		Ctx:       ctx,
+		Email:     user.GetEmail(),
+		Device:    device,
+		IPAddress: session.IPAddress,
+		Time:      intModels.LoginDeviceTimeFormat(session.CreatedAt, ctx.Timezone),
+		Url:       fmt.Sprintf("%s?%s", auth.LoginNotMeURL, q.Encode()),
+	}
+	options := []asynq.Option{asynq.MaxRetry(10), asynq.Queue(worker.QueuePriorityCritical)}
+	if err := c.tasker.SendNewDeviceLoginEmail(context, pay, options...); err != nil {
+		c.log.ErrorStruct("failed to enqueue the new device login email", err)
+	}
+}
+
+// LoginNotMe redeems the "this wasn't me" link of a new sign-in alert, every device of the
+// user is logged out (and the issued tokens revoked), and a password reset email is sent
+// to the users that have a password
+func (c *Controller) LoginNotMe(context context.Context, req *pbAcc.LoginNotMeRequest) (*pbAcc.LoginNotMeResponse, error) {
+	start := time.Now()
+	path := "users.controller.LoginNotMe"
+	errBuilder := func(e *models.AppError) (*pbAcc.LoginNotMeResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordLoginNotMeRequest(false, duration)
+		return &pbAcc.LoginNotMeResponse{Response: &pbAcc.LoginNotMeResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+	internalErr := func(ctx *models.Context, err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	if err := intModels.LoginNotMeRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameLoginNotMe, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+	models.AuditEventDataParameter(ar, "token_id", req.GetTokenId())
+
+	invalid := models.NewAppError(ctx, path, "user.login.not_me.invalid", nil, "", int(codes.InvalidArgument), nil)
+	token, dbErr := c.store.TokensGet(ctx, req.GetTokenId())
+	if dbErr != nil {
+		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			return errBuilder(invalid)
+		}
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+	if token.GetType() != string(intModels.TokenTypeLoginNotMe) || token.GetUsed() || token.GetExpiresAt() < utils.TimeGetMillis() {
+		return errBuilder(invalid)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(token.GetToken()), []byte(req.GetToken())); err != nil {
+		return errBuilder(invalid)
+	}
+
+	user, dbErr := c.store.UsersGetByID(ctx, token.GetUserId())
+	if dbErr != nil {
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+	models.AuditEventDataParameter(ar, "user_id", user.GetId())
+
+	if dbErr := c.store.TokensMarkUsed(ctx, token.GetId()); dbErr != nil {
+		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			return errBuilder(invalid)
+		}
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	if err := c.oauthRevokeSessions(ctx, user.GetId()); err != nil {
+		return errBuilder(err)
+	}
+
+	// the users without a password sign in through their identities or passkeys only
+	resetSent := user.GetPassword() != ""
+	if resetSent {
+		if err := c.passwordResetStart(ctx, context, user); err != nil {
+			return errBuilder(err)
+		}
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordLoginNotMeRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.login.not_me.success", nil)
+	return &pbAcc.LoginNotMeResponse{Response: &pbAcc.LoginNotMeResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg, Metadata: map[string]string{"password_reset_sent": fmt.Sprint(resetSent)}}}}, nil
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+func TestLoginNotMe(t *testing.T) {
+	hydra := newFakeHydra(t, "")
+	th := NewOfflineTestHelper(t, testConfig(hydra.URL),
+		"user.login.not_me.success",
+		"user.login.not_me.invalid",
+	)
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	user.Password = utils.NewPointer("password-hash")
+	ctx := th.withContext(context.Background())
+
+	tokenData, err := (&utils.Token{}).GenerateToken(time.Hour)
+	require.NoError(t, err)
+	token := &pb.Token{
+		Id:        tokenData.ID,
+		UserId:    user.GetId(),
+		Token:     string(tokenData.Hash),
+		Type:      string(intModels.TokenTypeLoginNotMe),
+		ExpiresAt: tokenData.Expiry.UnixMilli(),
+	}
+
+	redeem := func(t *testing.T, secret string) *pbAcc.LoginNotMeResponse {
+		t.Helper()
+		res, err := th.controller.LoginNotMe(ctx, &pbAcc.LoginNotMeRequest{TokenId: tokenData.ID, Token: secret})
+		require.NoError(t, err)
+		return res
+	}
+
+	th.store.On("TokensGet", mock.Anything, tokenData.ID).Return(token, nil)
+
+	t.Run("a wrong token is refused", func(t *testing.T) {
+		require.Equal(t, "user.login.not_me.invalid", redeem(t, "wrong-token").GetError().GetId())
+		th.store.AssertNotCalled(t, "TokensMarkUsed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("the link logs every device out and starts a password reset", func(t *testing.T) {
+		th.store.On("UsersGetByID", mock.Anything, user.GetId()).Return(user, nil).Once()
+		th.store.On("TokensMarkUsed", mock.Anything, tokenData.ID).Return(nil).Once()
+		th.store.On("UserSessionsDeleteAll", mock.Anything, user.GetId(), "").Return(nil).Once()
+		th.store.On("TokensDeleteAllPasswordResetByUserID", mock.Anything, user.GetId()).Return(int64(0), nil).Once()
+		th.store.On("TokensAdd", mock.Anything, user.GetId(), mock.Anything, intModels.TokenTypePasswordReset, mock.Anything).Return(nil).Once()
+		th.tasker.On("SendPasswordResetEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		res := redeem(t, tokenData.Token)
+		require.Nil(t, res.GetError())
+		require.Equal(t, "user.login.not_me.success", res.GetData().GetMessage())
+		require.Equal(t, "true", res.GetData().GetMetadata()["password_reset_sent"])
+	})
+
+	t.Run("a link redeemed by a concurrent request is refused", func(t *testing.T) {
+		th.store.On("UsersGetByID", mock.Anything, user.GetId()).Return(user, nil).Once()
+		th.store.On("TokensMarkUsed", mock.Anything, tokenData.ID).Return(&models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+
+		require.Equal(t, "user.login.not_me.invalid", redeem(t, tokenData.Token).GetError().GetId())
+		th.store.AssertNumberOfCalls(t, "UserSessionsDeleteAll", 1)
+	})
+
+	t.Run("a used link is refused", func(t *testing.T) {
+		token.Used = true
+		defer func() { token.Used = false }()
+
+		require.Equal(t, "user.login.not_me.invalid", redeem(t, tokenData.Token).GetError().GetId())
+	})
+}
@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/captcha"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+func TestLoginEmailVerification(t *testing.T) {
+	hydra := newFakeHydra(t, "http://hydra.local/done")
+	th := NewOfflineTestHelper(t, testConfig(hydra.URL),
+		"user.login.email_not_verified.error",
+		"user.login.email_not_verified.resend_hint",
+	)
+	defer th.TearDown()
+	th.srvCfg.Auth.NewDeviceAlerts = false
+
+	user := th.Customer1.User
+	th.withPassword(t, th.Customer1, "current-pass1")
+	user.IsEmailVerified = utils.NewPointer(false)
+	ctx := context.WithValue(context.Background(), models.ContextKeyMetadata, th.Customer1.Ctx)
+	req := &pb.LoginRequest{Email: user.GetEmail(), Password: "current-pass1", LoginChallenge: "fake-challenge"}
+
+	th.store.On("UsersGetByEmail", mock.Anything, user.GetEmail()).Return(user, nil)
+	th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil)
+
+	t.Run("an unverified email is refused with a resend hint", func(t *testing.T) {
+		res, err := th.controller.Login(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "user.login.email_not_verified.error", res.GetError().GetId())
+		require.Equal(t, "user.login.email_not_verified.resend_hint", res.GetError().GetErrors().GetValues()["email"])
+		th.store.AssertNotCalled(t, "UsersLoginSucceeded", mock.Anything, mock.Anything)
+	})
+
+	t.Run("the user types not requiring a verification log in", func(t *testing.T) {
+		th.srvCfg.Auth.EmailVerificationRequired[string(intModels.UserTypeCustomer)] = false
+		defer func() { th.srvCfg.Auth.EmailVerificationRequired[string(intModels.UserTypeCustomer)] = true }()
+		th.store.On("UsersLoginSucceeded", mock.Anything, user.GetId()).Return(nil).Once()
+		th.store.On("UserSessionsAdd", mock.Anything, mock.Anything).Return(nil).Once()
+		th.store.On("UserLoginDevicesAdd", mock.Anything, mock.Anything).Return(&intModels.LoginDeviceHistory{}, nil).Once()
+
+		res, err := th.controller.Login(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, res.GetError())
+		require.Equal(t, "http://hydra.local/done", res.GetData().GetMetadata()["redirect_to"])
+	})
+}
+
+func TestLoginPrivacyMode(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""),
+		"user.login.invalid_credentials.error",
+		"user.login.locked.error",
+		"user.captcha.required",
+	)
+	defer th.TearDown()
+	require.True(t, th.srvCfg.Auth.PrivacyMode)
+
+	user := th.Customer1.User
+	th.withPassword(t, th.Customer1, "current-pass1")
+	ctx := context.WithValue(context.Background(), models.ContextKeyMetadata, th.Customer1.Ctx)
+
+	login := func(t *testing.T, email, password string) *pb.LoginResponse {
+		t.Helper()
+		res, err := th.controller.Login(ctx, &pb.LoginRequest{Email: email, Password: password, LoginChallenge: "fake-challenge"})
+		require.NoError(t, err)
+		return res
+	}
+
+	th.store.On("UsersGetByEmail", mock.Anything, user.GetEmail()).Return(user, nil)
+	th.store.On("UsersGetByEmail", mock.Anything, "unknown@example.com").Return(nil, &models.DBError{ErrType: models.DBErrorTypeNoRows})
+
+	unknown := login(t, "unknown@example.com", "current-pass1").GetError()
+	require.Equal(t, "user.login.invalid_credentials.error", unknown.GetId())
+
+	t.Run("a locked account answers like an unknown email", func(t *testing.T) {
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(time.Now().Add(time.Hour).UnixMilli(), nil).Once()
+
+		res := login(t, user.GetEmail(), "current-pass1")
+		require.Equal(t, unknown.GetId(), res.GetError().GetId())
+		require.Equal(t, unknown.GetStatusCode(), res.GetError().GetStatusCode())
+	})
+
+	t.Run("the attempt locking the account answers like an unknown email", func(t *testing.T) {
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil).Once()
+		th.store.On("UsersFailedAttemptsIncrement", mock.Anything, user.GetId()).Return(int32(6), nil).Once()
+		th.store.On("UsersLock", mock.Anything, user.GetId(), mock.AnythingOfType("int64")).Return(nil).Once()
+		th.tasker.On("SendAccountLockedEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		res := login(t, user.GetEmail(), "wrong-pass1")
+		require.Equal(t, unknown.GetId(), res.GetError().GetId())
+		th.store.AssertCalled(t, "UsersLock", mock.Anything, user.GetId(), mock.AnythingOfType("int64"))
+	})
+
+	t.Run("the challenge is asked whether the account exists or not", func(t *testing.T) {
+		th.controller.captcha = &captcha.StubVerifier{Token: "pass"}
+		th.srvCfg.Captcha.Methods = []string{"login"}
+		defer func() {
+			th.controller.captcha = nil
+			th.srvCfg.Captcha.Methods = nil
+		}()
+
+		calls := len(th.store.Calls)
+		require.Equal(t, "user.captcha.required", login(t, user.GetEmail(), "current-pass1").GetError().GetId())
+		require.Equal(t, "user.captcha.required", login(t, "unknown@example.com", "current-pass1").GetError().GetId())
+		require.Len(t, th.store.Calls, calls, "the challenge is checked before the account is looked up")
+	})
+
+	t.Run("without the privacy mode the lock is disclosed", func(t *testing.T) {
+		th.srvCfg.Auth.PrivacyMode = false
+		defer func() { th.srvCfg.Auth.PrivacyMode = true }()
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(time.Now().Add(time.Hour).UnixMilli(), nil).Once()
+
+		res := login(t, user.GetEmail(), "current-pass1")
+		require.Equal(t, "user.login.locked.error", res.GetError().GetId())
+	})
+}
+
+func TestSocialLoginAccept(t *testing.T) {
+	hydra := newFakeHydra(t, "http://hydra.local/done")
+	th := NewOfflineTestHelper(t, testConfig(hydra.URL))
+	defer th.TearDown()
+	th.srvCfg.Auth.NewDeviceAlerts = false
+
+	user := th.Customer1.User
+	th.store.On("UsersLoginSucceeded", mock.Anything, user.GetId()).Return(nil).Once()
+	th.store.On("UserSessionsAdd", mock.Anything, mock.MatchedBy(func(s *intModels.UserSession) bool {
+		return s.ID == "hydra-session" && s.UserID == user.GetId() && s.Platform == intModels.ClientPlatformMobile
+	})).Return(nil).Once()
+	th.store.On("UserLoginDevicesAdd", mock.Anything, mock.Anything).Return(&intModels.LoginDeviceHistory{}, nil).Once()
+
+	session := intModels.LoginSessionOptions{Platform: intModels.ClientPlatformMobile, Remember: true}
+	redirectTo, err := th.controller.SocialLoginAccept(th.Customer1.Ctx, user, "fake-challenge", session)
+	require.Nil(t, err)
+	require.Equal(t, "http://hydra.local/done", redirectTo)
+}
+
+func TestLoginPasswordlessUser(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""),
+		"user.login.use_auth_service.error",
+		"user.login.password.error",
+	)
+	defer th.TearDown()
+	th.srvCfg.Auth.PrivacyMode = false
+
+	// the legacy auth_service column isn't set anymore, the identities tell the provider
+	user := th.Customer1.User
+	user.Password = utils.NewPointer("")
+	ctx := context.WithValue(context.Background(), models.ContextKeyMetadata, th.Customer1.Ctx)
+	req := &pb.LoginRequest{Email: user.GetEmail(), Password: "current-pass1", LoginChallenge: "fake-challenge"}
+	th.store.On("UsersGetByEmail", mock.Anything, user.GetEmail()).Return(user, nil)
+
+	t.Run("the user signing in with an identity is told its provider", func(t *testing.T) {
+		identities := []*intModels.Identity{{ID: utils.NewID(), UserID: user.GetId(), Provider: "google"}}
+		th.store.On("IdentitiesGetByUserID", mock.Anything, user.GetId()).Return(identities, nil).Once()
+
+		res, err := th.controller.Login(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "user.login.use_auth_service.error", res.GetError().GetId())
+		th.store.AssertNotCalled(t, "UsersGetLockedUntil", mock.Anything, mock.Anything)
+	})
+
+	t.Run("the user without an identity gets the wrong password error", func(t *testing.T) {
+		th.store.On("IdentitiesGetByUserID", mock.Anything, user.GetId()).Return(nil, nil).Once()
+
+		res, err := th.controller.Login(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "user.login.password.error", res.GetError().GetId())
+	})
+}
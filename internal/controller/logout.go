@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"google.golang.org/grpc/codes"
+)
+
+// Logout revokes the OAuth login session of the current device, or all the OAuth
+// sessions (and their issued tokens) of the user if req.AllDevices is set
+func (c *Controller) Logout(context context.Context, req *pbAcc.LogoutRequest) (*pbAcc.LogoutResponse, error) {
+	start := time.Now()
+	path := "users.controller.Logout"
+	errBuilder := func(e *models.AppError) (*pbAcc.LogoutResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordLogoutRequest(false, duration)
+		return &pbAcc.LogoutResponse{Response: &pbAcc.LogoutResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameLogout, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+	models.AuditEventDataParameter(ar, "all_devices", req.GetAllDevices())
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil))
+	}
+
+	if req.GetAllDevices() {
+		if err := c.oauthRevokeSessions(ctx, userID); err != nil {
+			return errBuilder(err)
+		}
+	} else {
+		sessionID := ctx.Session.ID
+		if sessionID == "" {
+			return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "the session id is missing", int(codes.Unauthenticated), nil))
+		}
+		if err := c.oauthRevokeSession(ctx, userID, sessionID); err != nil {
+			return errBuilder(err)
+		}
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordLogoutRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.logout.success", nil)
+	return &pbAcc.LogoutResponse{Response: &pbAcc.LogoutResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}}, nil
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+)
+
+func TestLogout(t *testing.T) {
+	hydra := newFakeHydra(t, "")
+	th := NewOfflineTestHelper(t, testConfig(hydra.URL), "user.logout.success")
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	th.Customer1.Ctx.Session.ID = "session-current"
+	ctx := th.withUser(t, th.Customer1, "")
+
+	logout := func(t *testing.T, allDevices bool) {
+		t.Helper()
+		res, err := th.controller.Logout(ctx, &pbAcc.LogoutRequest{AllDevices: allDevices})
+		require.NoError(t, err)
+		require.Nil(t, res.GetError())
+		require.Equal(t, "user.logout.success", res.GetData().GetMessage())
+	}
+
+	t.Run("only the session of the request is ended", func(t *testing.T) {
+		th.store.On("UserSessionsDelete", mock.Anything, user.GetId(), "session-current").Return(nil).Once()
+
+		logout(t, false)
+		th.store.AssertNotCalled(t, "UserSessionsDeleteAll", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("every session is ended on all the devices", func(t *testing.T) {
+		th.store.On("UserSessionsDeleteAll", mock.Anything, user.GetId(), "").Return(nil).Once()
+
+		logout(t, true)
+	})
+}
@@ -48,6 +48,66 @@ type MetricsCollector struct {
 	supplierCreateErrors   metric.Int64Counter
 	supplierCreateDuration metric.Float64Histogram
 
+	// MFA metrics
+	mfaTotal    metric.Int64Counter
+	mfaErrors   metric.Int64Counter
+	mfaDuration metric.Float64Histogram
+
+	// WebAuthn metrics
+	webauthnTotal    metric.Int64Counter
+	webauthnErrors   metric.Int64Counter
+	webauthnDuration metric.Float64Histogram
+
+	// Password Reset metrics
+	passwordResetTotal    metric.Int64Counter
+	passwordResetErrors   metric.Int64Counter
+	passwordResetDuration metric.Float64Histogram
+
+	// Password Change metrics
+	passwordChangeTotal    metric.Int64Counter
+	passwordChangeErrors   metric.Int64Counter
+	passwordChangeDuration metric.Float64Histogram
+
+	// Resend verification email metrics
+	resendVerificationEmailTotal    metric.Int64Counter
+	resendVerificationEmailErrors   metric.Int64Counter
+	resendVerificationEmailDuration metric.Float64Histogram
+
+	// Email login begin metrics
+	emailLoginBeginTotal    metric.Int64Counter
+	emailLoginBeginErrors   metric.Int64Counter
+	emailLoginBeginDuration metric.Float64Histogram
+
+	// Email login metrics
+	emailLoginTotal    metric.Int64Counter
+	emailLoginErrors   metric.Int64Counter
+	emailLoginDuration metric.Float64Histogram
+
+	// Logout metrics
+	logoutTotal    metric.Int64Counter
+	logoutErrors   metric.Int64Counter
+	logoutDuration metric.Float64Histogram
+
+	// Consent metrics
+	consentTotal    metric.Int64Counter
+	consentErrors   metric.Int64Counter
+	consentDuration metric.Float64Histogram
+
+	// Identity metrics
+	identityTotal    metric.Int64Counter
+	identityErrors   metric.Int64Counter
+	identityDuration metric.Float64Histogram
+
+	// Session metrics
+	sessionTotal    metric.Int64Counter
+	sessionErrors   metric.Int64Counter
+	sessionDuration metric.Float64Histogram
+
+	// Login not me metrics
+	loginNotMeTotal    metric.Int64Counter
+	loginNotMeErrors   metric.Int64Counter
+	loginNotMeDuration metric.Float64Histogram
+
 	// Database operation metrics
 	dbOperationsTotal   metric.Int64Counter
 	dbOperationErrors   metric.Int64Counter
@@ -123,6 +183,102 @@ func NewMetricsCollector() *MetricsCollector {
 	mc.supplierCreateDuration, _ = meter.Float64Histogram("supplier_create_duration_seconds",
 		metric.WithDescription("Supplier create request duration in seconds"))
 
+	// MFA metrics
+	mc.mfaTotal, _ = meter.Int64Counter("mfa_total",
+		metric.WithDescription("Total mfa requests"))
+	mc.mfaErrors, _ = meter.Int64Counter("mfa_errors_total",
+		metric.WithDescription("Total mfa errors"))
+	mc.mfaDuration, _ = meter.Float64Histogram("mfa_duration_seconds",
+		metric.WithDescription("MFA request duration in seconds"))
+
+	// WebAuthn metrics
+	mc.webauthnTotal, _ = meter.Int64Counter("webauthn_total",
+		metric.WithDescription("Total webauthn requests"))
+	mc.webauthnErrors, _ = meter.Int64Counter("webauthn_errors_total",
+		metric.WithDescription("Total webauthn errors"))
+	mc.webauthnDuration, _ = meter.Float64Histogram("webauthn_duration_seconds",
+		metric.WithDescription("WebAuthn request duration in seconds"))
+
+	// Password Reset metrics
+	mc.passwordResetTotal, _ = meter.Int64Counter("password_reset_total",
+		metric.WithDescription("Total password reset requests"))
+	mc.passwordResetErrors, _ = meter.Int64Counter("password_reset_errors_total",
+		metric.WithDescription("Total password reset errors"))
+	mc.passwordResetDuration, _ = meter.Float64Histogram("password_reset_duration_seconds",
+		metric.WithDescription("Password Reset request duration in seconds"))
+
+	// Password Change metrics
+	mc.passwordChangeTotal, _ = meter.Int64Counter("password_change_total",
+		metric.WithDescription("Total password change requests"))
+	mc.passwordChangeErrors, _ = meter.Int64Counter("password_change_errors_total",
+		metric.WithDescription("Total password change errors"))
+	mc.passwordChangeDuration, _ = meter.Float64Histogram("password_change_duration_seconds",
+		metric.WithDescription("Password Change request duration in seconds"))
+
+	// Resend verification email metrics
+	mc.resendVerificationEmailTotal, _ = meter.Int64Counter("resend_verification_email_total",
+		metric.WithDescription("Total resend verification email requests"))
+	mc.resendVerificationEmailErrors, _ = meter.Int64Counter("resend_verification_email_errors_total",
+		metric.WithDescription("Total resend verification email errors"))
+	mc.resendVerificationEmailDuration, _ = meter.Float64Histogram("resend_verification_email_duration_seconds",
+		metric.WithDescription("Resend verification email request duration in seconds"))
+
+	// Email login begin metrics
+	mc.emailLoginBeginTotal, _ = meter.Int64Counter("email_login_begin_total",
+		metric.WithDescription("Total email login begin requests"))
+	mc.emailLoginBeginErrors, _ = meter.Int64Counter("email_login_begin_errors_total",
+		metric.WithDescription("Total email login begin errors"))
+	mc.emailLoginBeginDuration, _ = meter.Float64Histogram("email_login_begin_duration_seconds",
+		metric.WithDescription("Email login begin request duration in seconds"))
+
+	// Email login metrics
+	mc.emailLoginTotal, _ = meter.Int64Counter("email_login_total",
+		metric.WithDescription("Total email login requests"))
+	mc.emailLoginErrors, _ = meter.Int64Counter("email_login_errors_total",
+		metric.WithDescription("Total email login errors"))
+	mc.emailLoginDuration, _ = meter.Float64Histogram("email_login_duration_seconds",
+		metric.WithDescription("Email login request duration in seconds"))
+
+	// Logout metrics
+	mc.logoutTotal, _ = meter.Int64Counter("logout_total",
+		metric.WithDescription("Total logout requests"))
+	mc.logoutErrors, _ = meter.Int64Counter("logout_errors_total",
+		metric.WithDescription("Total logout errors"))
+	mc.logoutDuration, _ = meter.Float64Histogram("logout_duration_seconds",
+		metric.WithDescription("Logout request duration in seconds"))
+
+	// Consent metrics
+	mc.consentTotal, _ = meter.Int64Counter("consent_total",
+		metric.WithDescription("Total consent requests"))
+	mc.consentErrors, _ = meter.Int64Counter("consent_errors_total",
+		metric.WithDescription("Total consent errors"))
+	mc.consentDuration, _ = meter.Float64Histogram("consent_duration_seconds",
+		metric.WithDescription("Consent request duration in seconds"))
+
+	// Identity metrics
+	mc.identityTotal, _ = meter.Int64Counter("identity_total",
+		metric.WithDescription("Total identity requests"))
+	mc.identityErrors, _ = meter.Int64Counter("identity_errors_total",
+		metric.WithDescription("Total identity errors"))
+	mc.identityDuration, _ = meter.Float64Histogram("identity_duration_seconds",
+		metric.WithDescription("Identity request duration in seconds"))
+
+	// Session metrics
+	mc.sessionTotal, _ = meter.Int64Counter("session_total",
+		metric.WithDescription("Total session requests"))
+	mc.sessionErrors, _ = meter.Int64Counter("session_errors_total",
+		metric.WithDescription("Total session errors"))
+	mc.sessionDuration, _ = meter.Float64Histogram("session_duration_seconds",
+		metric.WithDescription("Session request duration in seconds"))
+
+	// Login not me metrics
+	mc.loginNotMeTotal, _ = meter.Int64Counter("login_not_me_total",
+		metric.WithDescription("Total login not me requests"))
+	mc.loginNotMeErrors, _ = meter.Int64Counter("login_not_me_errors_total",
+		metric.WithDescription("Total login not me errors"))
+	mc.loginNotMeDuration, _ = meter.Float64Histogram("login_not_me_duration_seconds",
+		metric.WithDescription("Login not me request duration in seconds"))
+
 	// Database operation metrics
 	mc.dbOperationsTotal, _ = meter.Int64Counter("db_operations_total",
 		metric.WithDescription("Total database operations"))
@@ -206,6 +362,114 @@ func (m *MetricsCollector) RecordSupplierCreateRequest(success bool, duration fl
 	}
 }
 
+func (m *MetricsCollector) RecordMfaRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.mfaTotal.Add(ctx, 1)
+	m.mfaDuration.Record(ctx, duration)
+	if !success {
+		m.mfaErrors.Add(ctx, 1)
+	}
+}
+
+func (m *MetricsCollector) RecordWebauthnRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.webauthnTotal.Add(ctx, 1)
+	m.webauthnDuration.Record(ctx, duration)
+	if !success {
+		m.webauthnErrors.Add(ctx, 1)
+	}
+}
+
+func (m *MetricsCollector) RecordPasswordResetRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.passwordResetTotal.Add(ctx, 1)
+	m.passwordResetDuration.Record(ctx, duration)
+	if !success {
+		m.passwordResetErrors.Add(ctx, 1)
+	}
+}
+
+func (m *MetricsCollector) RecordPasswordChangeRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.passwordChangeTotal.Add(ctx, 1)
+	m.passwordChangeDuration.Record(ctx, duration)
+	if !success {
+		m.passwordChangeErrors.Add(ctx, 1)
+	}
+}
+
+func (m *MetricsCollector) RecordResendVerificationEmailRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.resendVerificationEmailTotal.Add(ctx, 1)
+	m.resendVerificationEmailDuration.Record(ctx, duration)
+	if !success {
+		m.resendVerificationEmailErrors.Add(ctx, 1)
+	}
+}
+
+func (m *MetricsCollector) RecordEmailLoginBeginRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.emailLoginBeginTotal.Add(ctx, 1)
+	m.emailLoginBeginDuration.Record(ctx, duration)
+	if !success {
+		m.emailLoginBeginErrors.Add(ctx, 1)
+	}
+}
+
+func (m *MetricsCollector) RecordEmailLoginRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.emailLoginTotal.Add(ctx, 1)
+	m.emailLoginDuration.Record(ctx, duration)
+	if !success {
+		m.emailLoginErrors.Add(ctx, 1)
+	}
+}
+
+func (m *MetricsCollector) RecordLogoutRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.logoutTotal.Add(ctx, 1)
+	m.logoutDuration.Record(ctx, duration)
+	if !success {
+		m.logoutErrors.Add(ctx, 1)
+	}
+}
+
+func (m *MetricsCollector) RecordConsentRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.consentTotal.Add(ctx, 1)
+	m.consentDuration.Record(ctx, duration)
+	if !success {
+		m.consentErrors.Add(ctx, 1)
+	}
+}
+
+func (m *MetricsCollector) RecordIdentityRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.identityTotal.Add(ctx, 1)
+	m.identityDuration.Record(ctx, duration)
+	if !success {
+		m.identityErrors.Add(ctx, 1)
+	}
+}
+
+func (m *MetricsCollector) RecordSessionRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.sessionTotal.Add(ctx, 1)
+	m.sessionDuration.Record(ctx, duration)
+	if !success {
+		m.sessionErrors.Add(ctx, 1)
+	}
+}
+
+func (m *MetricsCollector) RecordLoginNotMeRequest(success bool, duration float64) {
+	ctx := context.Background()
+	m.loginNotMeTotal.Add(ctx, 1)
+	m.loginNotMeDuration.Record(ctx, duration)
+	if !success {
+		m.loginNotMeErrors.Add(ctx, 1)
+	}
+}
+
 func (m *MetricsCollector) RecordDBOperation(success bool, duration float64) {
 	ctx := context.Background()
 	m.dbOperationsTotal.Add(ctx, 1)
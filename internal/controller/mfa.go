@@ -0,0 +1,281 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+)
+
+// MfaEnroll generates a new TOTP secret for the authenticated user, the secret stays
+// inactive until it's confirmed with a first code by MfaConfirm
+func (c *Controller) MfaEnroll(context context.Context, req *pbAcc.MfaEnrollRequest) (*pbAcc.MfaEnrollResponse, error) {
+	start := time.Now()
+	path := "users.controller.MfaEnroll"
+	errBuilder := func(e *models.AppError) (*pbAcc.MfaEnrollResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordMfaRequest(false, duration)
+		return &pbAcc.MfaEnrollResponse{Response: &pbAcc.MfaEnrollResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameMfaEnroll, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	user, err := c.mfaGetUser(ctx, path)
+	if err != nil {
+		return errBuilder(err)
+	}
+	if user.GetMfaActive() {
+		return errBuilder(models.NewAppError(ctx, path, "user.mfa.already_active.error", nil, "", int(codes.FailedPrecondition), nil))
+	}
+
+	secret, genErr := intModels.MfaGenerateSecret()
+	if genErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, "failed to generate an mfa secret", int(codes.Internal), &models.AppErrorErrorsArgs{Err: genErr}))
+	}
+
+	if err := c.store.UsersMfaSecretSet(ctx, user.GetId(), secret); err != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err}))
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordMfaRequest(true, duration)
+
+	issuer := c.config().Main.GetSiteName()
+	meta := map[string]string{"secret": secret, "otpauth_url": intModels.MfaOtpAuthURL(issuer, user.GetEmail(), secret)}
+	msg := models.Tr(ctx.AcceptLanguage, "user.mfa.enroll.success", nil)
+	return &pbAcc.MfaEnrollResponse{Response: &pbAcc.MfaEnrollResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg, Metadata: meta}}}, nil
+}
+
+// MfaConfirm activates the pending MFA secret of the authenticated user
+func (c *Controller) MfaConfirm(context context.Context, req *pbAcc.MfaConfirmRequest) (*pbAcc.MfaConfirmResponse, error) {
+	start := time.Now()
+	path := "users.controller.MfaConfirm"
+	errBuilder := func(e *models.AppError) (*pbAcc.MfaConfirmResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordMfaRequest(false, duration)
+		return &pbAcc.MfaConfirmResponse{Response: &pbAcc.MfaConfirmResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameMfaConfirm, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	if err := intModels.MfaConfirmRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	user, err := c.mfaGetUser(ctx, path)
+	if err != nil {
+		return errBuilder(err)
+	}
+	if user.GetMfaActive() {
+		return errBuilder(models.NewAppError(ctx, path, "user.mfa.already_active.error", nil, "", int(codes.FailedPrecondition), nil))
+	}
+	if user.GetMfaSecret() == "" {
+		return errBuilder(models.NewAppError(ctx, path, "user.mfa.not_enrolled.error", nil, "", int(codes.FailedPrecondition), nil))
+	}
+
+	valid, err := c.mfaCodeAccept(ctx, path, user, req.GetCode())
+	if err != nil {
+		return errBuilder(err)
+	}
+	if !valid {
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"code": {ID: "user.mfa.code.invalid"}}}
+		return errBuilder(models.NewAppError(ctx, path, "user.mfa.code.invalid", nil, "", int(codes.InvalidArgument), errors))
+	}
+
+	if err := c.store.UsersMfaActivate(ctx, user.GetId()); err != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err}))
+	}
+
+	recoveryCodes, err := c.mfaRecoveryCodesNew(ctx, path, user.GetId())
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordMfaRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.mfa.confirm.success", nil)
+	return &pbAcc.MfaConfirmResponse{Response: &pbAcc.MfaConfirmResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}, RecoveryCodes: recoveryCodes}, nil
+}
+
+// MfaDisable deactivates MFA for the authenticated user after checking the password
+func (c *Controller) MfaDisable(context context.Context, req *pbAcc.MfaDisableRequest) (*pbAcc.MfaDisableResponse, error) {
+	start := time.Now()
+	path := "users.controller.MfaDisable"
+	errBuilder := func(e *models.AppError) (*pbAcc.MfaDisableResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordMfaRequest(false, duration)
+		return &pbAcc.MfaDisableResponse{Response: &pbAcc.MfaDisableResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameMfaDisable, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	if err := intModels.MfaDisableRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	user, err := c.mfaGetUser(ctx, path)
+	if err != nil {
+		return errBuilder(err)
+	}
+	if !user.GetMfaActive() {
+		return errBuilder(models.NewAppError(ctx, path, "user.mfa.not_active.error", nil, "", int(codes.FailedPrecondition), nil))
+	}
+
+	if err := c.passwordReauth(ctx, path, user, req.GetPassword()); err != nil {
+		return errBuilder(err)
+	}
+
+	if err := c.store.UsersMfaDeactivate(ctx, user.GetId()); err != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err}))
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordMfaRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.mfa.disable.success", nil)
+	return &pbAcc.MfaDisableResponse{Response: &pbAcc.MfaDisableResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}}, nil
+}
+
+// MfaRecoveryCodesRegenerate replaces all the recovery codes of the authenticated user
+func (c *Controller) MfaRecoveryCodesRegenerate(context context.Context, req *pbAcc.MfaRecoveryCodesRegenerateRequest) (*pbAcc.MfaRecoveryCodesRegenerateResponse, error) {
+	start := time.Now()
+	path := "users.controller.MfaRecoveryCodesRegenerate"
+	errBuilder := func(e *models.AppError) (*pbAcc.MfaRecoveryCodesRegenerateResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordMfaRequest(false, duration)
+		return &pbAcc.MfaRecoveryCodesRegenerateResponse{Response: &pbAcc.MfaRecoveryCodesRegenerateResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameMfaRecoveryCodesRegenerate, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	if err := intModels.MfaRecoveryCodesRegenerateRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	user, err := c.mfaGetUser(ctx, path)
+	if err != nil {
+		return errBuilder(err)
+	}
+	if !user.GetMfaActive() {
+		return errBuilder(models.NewAppError(ctx, path, "user.mfa.not_active.error", nil, "", int(codes.FailedPrecondition), nil))
+	}
+
+	if err := c.passwordReauth(ctx, path, user, req.GetPassword()); err != nil {
+		return errBuilder(err)
+	}
+
+	recoveryCodes, err := c.mfaRecoveryCodesNew(ctx, path, user.GetId())
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordMfaRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.mfa.recovery_codes.success", nil)
+	return &pbAcc.MfaRecoveryCodesRegenerateResponse{Response: &pbAcc.MfaRecoveryCodesRegenerateResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}, RecoveryCodes: recoveryCodes}, nil
+}
+
+// mfaRecoveryCodesNew generates and stores (hashed) a new set of recovery codes,
+// replacing the old ones, the returned plain codes are shown to the user once
+func (c *Controller) mfaRecoveryCodesNew(ctx *models.Context, path, userID string) ([]string, *models.AppError) {
+	internalErr := func(err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	recoveryCodes, err := intModels.MfaGenerateRecoveryCodes(intModels.MfaRecoveryCodesCount)
+	if err != nil {
+		return nil, internalErr(err, "failed to generate mfa recovery codes")
+	}
+
+	expiry := time.Now().Add(intModels.MfaRecoveryCodeValidity)
+	tokens := make([]*utils.Token, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hash, err := bcrypt.GenerateFromPassword([]byte(intModels.MfaNormalizeRecoveryCode(code)), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, internalErr(err, "failed to hash an mfa recovery code")
+		}
+		tokens = append(tokens, &utils.Token{ID: utils.NewID(), Hash: hash, Expiry: expiry})
+	}
+
+	if err := c.store.TokensMfaRecoveryCodesReplace(ctx, userID, tokens); err != nil {
+		return nil, internalErr(err, err.Details)
+	}
+
+	return recoveryCodes, nil
+}
+
+// mfaCodeAccept checks the TOTP code of the user and consumes its time step, a code of the
+// same or an earlier step than the last accepted one is refused as a replay
+func (c *Controller) mfaCodeAccept(ctx *models.Context, path string, user *pb.User, code string) (bool, *models.AppError) {
+	counter, ok := intModels.MfaValidateCode(user.GetMfaSecret(), code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	if err := c.store.UsersMfaCounterAdvance(ctx, user.GetId(), counter); err != nil {
+		if err.ErrType == models.DBErrorTypeNoRows {
+			return false, nil
+		}
+		return false, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+	return true, nil
+}
+
+// mfaGetUser returns the authenticated user if MFA is enabled for this deployment
+func (c *Controller) mfaGetUser(ctx *models.Context, path string) (*pb.User, *models.AppError) {
+	if !c.config().Security.GetEnableMultifactorAuthentication() {
+		return nil, models.NewAppError(ctx, path, "user.mfa.disabled.error", nil, "", int(codes.FailedPrecondition), nil)
+	}
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return nil, models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil)
+	}
+
+	user, err := c.store.UsersGetByID(ctx, userID)
+	if err != nil {
+		if err.ErrType == models.DBErrorTypeNoRows {
+			return nil, models.NewAppError(ctx, path, "error.not_found", nil, "user not found", int(codes.NotFound), nil)
+		}
+		return nil, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	return user, nil
+}
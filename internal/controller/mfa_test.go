@@ -0,0 +1,243 @@
+package controller
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+// totpCode computes the TOTP code of the secret at the given time
+func totpCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	require.NoError(t, err)
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(at.Unix()/intModels.MfaTOTPPeriod))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+func TestMfaCodeAccept(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""))
+	defer th.TearDown()
+
+	secret, genErr := intModels.MfaGenerateSecret()
+	require.NoError(t, genErr)
+	user := th.Customer1.User
+	user.MfaSecret = utils.NewPointer(secret)
+	ctx := th.Customer1.Ctx
+	code := totpCode(t, secret, time.Now())
+
+	t.Run("the time step of an accepted code is stored", func(t *testing.T) {
+		counter := time.Now().Unix() / intModels.MfaTOTPPeriod
+		th.store.On("UsersMfaCounterAdvance", ctx, user.GetId(), mock.MatchedBy(func(c int64) bool {
+			return c >= counter-intModels.MfaTOTPSkew && c <= counter+intModels.MfaTOTPSkew
+		})).Return(nil).Once()
+
+		valid, err := th.controller.mfaCodeAccept(ctx, "test", user, code)
+		require.Nil(t, err)
+		require.True(t, valid)
+	})
+
+	t.Run("a replayed code is refused", func(t *testing.T) {
+		th.store.On("UsersMfaCounterAdvance", ctx, user.GetId(), mock.AnythingOfType("int64")).
+			Return(&models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+
+		valid, err := th.controller.mfaCodeAccept(ctx, "test", user, code)
+		require.Nil(t, err)
+		require.False(t, valid)
+	})
+
+	t.Run("a wrong code doesn't reach the store", func(t *testing.T) {
+		calls := len(th.store.Calls)
+		valid, err := th.controller.mfaCodeAccept(ctx, "test", user, "000000")
+		require.Nil(t, err)
+		require.False(t, valid)
+		require.Len(t, th.store.Calls, calls)
+	})
+
+	t.Run("a store failure is an internal error", func(t *testing.T) {
+		th.store.On("UsersMfaCounterAdvance", ctx, user.GetId(), mock.AnythingOfType("int64")).
+			Return(&models.DBError{ErrType: models.DBErrorTypeInternal, Details: "connection reset"}).Once()
+
+		_, err := th.controller.mfaCodeAccept(ctx, "test", user, code)
+		require.NotNil(t, err)
+		require.Equal(t, models.ErrMsgInternal, err.ID)
+	})
+}
+
+func TestMfaConfirm(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""), "user.mfa.confirm.success")
+	defer th.TearDown()
+
+	secret, genErr := intModels.MfaGenerateSecret()
+	require.NoError(t, genErr)
+	user := th.Customer1.User
+	user.MfaSecret = utils.NewPointer(secret)
+
+	th.store.On("UsersGetByID", th.Customer1.Ctx, user.GetId()).Return(user, nil)
+	th.store.On("UsersMfaCounterAdvance", th.Customer1.Ctx, user.GetId(), mock.AnythingOfType("int64")).Return(nil)
+	th.store.On("UsersMfaActivate", th.Customer1.Ctx, user.GetId()).Return(nil).Once()
+	th.store.On("TokensMfaRecoveryCodesReplace", th.Customer1.Ctx, user.GetId(), mock.Anything).Return(nil).Once()
+
+	ctx := th.withUser(t, th.Customer1, "")
+	res, err := th.controller.MfaConfirm(ctx, &pbAcc.MfaConfirmRequest{Code: totpCode(t, secret, time.Now())})
+	require.NoError(t, err)
+	require.Nil(t, res.GetError())
+	require.Len(t, res.GetRecoveryCodes(), intModels.MfaRecoveryCodesCount)
+	require.Empty(t, res.GetData().GetMetadata())
+}
+
+func TestMfaConfirmReplay(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""), "user.mfa.code.invalid")
+	defer th.TearDown()
+
+	secret, genErr := intModels.MfaGenerateSecret()
+	require.NoError(t, genErr)
+	user := th.Customer1.User
+	user.MfaSecret = utils.NewPointer(secret)
+
+	th.store.On("UsersGetByID", th.Customer1.Ctx, user.GetId()).Return(user, nil)
+	th.store.On("UsersMfaCounterAdvance", th.Customer1.Ctx, user.GetId(), mock.AnythingOfType("int64")).
+		Return(&models.DBError{ErrType: models.DBErrorTypeNoRows})
+
+	ctx := th.withUser(t, th.Customer1, "")
+	res, err := th.controller.MfaConfirm(ctx, &pbAcc.MfaConfirmRequest{Code: totpCode(t, secret, time.Now())})
+	require.NoError(t, err)
+	require.Equal(t, "user.mfa.code.invalid", res.GetError().GetId())
+	th.store.AssertNotCalled(t, "UsersMfaActivate", mock.Anything, mock.Anything)
+}
+
+func TestLoginMfaRecoveryCodeRedeem(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""))
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	ctx := th.Customer1.Ctx
+	codes, genErr := intModels.MfaGenerateRecoveryCodes(1)
+	require.NoError(t, genErr)
+	hash, hashErr := bcrypt.GenerateFromPassword([]byte(intModels.MfaNormalizeRecoveryCode(codes[0])), bcrypt.MinCost)
+	require.NoError(t, hashErr)
+	token := &pb.Token{Id: utils.NewID(), UserId: user.GetId(), Token: string(hash), Type: string(intModels.TokenTypeMfaRecoveryCode)}
+
+	th.store.On("TokensGetUnusedByType", ctx, user.GetId(), intModels.TokenTypeMfaRecoveryCode).Return([]*pb.Token{token}, nil)
+
+	t.Run("a code consumed by a concurrent request is refused", func(t *testing.T) {
+		th.store.On("TokensMarkUsed", ctx, token.GetId()).Return(&models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+
+		redeemed, err := th.controller.loginMfaRecoveryCodeRedeem(ctx, user, codes[0])
+		require.Nil(t, err)
+		require.False(t, redeemed)
+		th.tasker.AssertNotCalled(t, "SendMfaRecoveryCodeUsedEmail", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("a code is redeemed once", func(t *testing.T) {
+		th.store.On("TokensMarkUsed", ctx, token.GetId()).Return(nil).Once()
+		th.tasker.On("SendMfaRecoveryCodeUsedEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		redeemed, err := th.controller.loginMfaRecoveryCodeRedeem(ctx, user, codes[0])
+		require.Nil(t, err)
+		require.True(t, redeemed)
+	})
+}
+
+func TestMfaDisableReauth(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""),
+		"user.mfa.disable.success",
+		"user.login.password.error",
+		"user.login.locked.error",
+	)
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	user.MfaActive = utils.NewPointer(true)
+	ctx := th.withUser(t, th.Customer1, "current-pass1")
+	th.store.On("UsersGetByID", mock.Anything, user.GetId()).Return(user, nil)
+
+	disable := func(t *testing.T, password string) *pbAcc.MfaDisableResponse {
+		t.Helper()
+		res, err := th.controller.MfaDisable(ctx, &pbAcc.MfaDisableRequest{Password: password})
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("a wrong password is counted as a failed attempt", func(t *testing.T) {
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil).Once()
+		th.store.On("UsersFailedAttemptsIncrement", mock.Anything, user.GetId()).Return(int32(1), nil).Once()
+
+		res := disable(t, "wrong-pass1")
+		require.Equal(t, "user.login.password.error", res.GetError().GetId())
+	})
+
+	t.Run("a locked account is refused before the password is checked", func(t *testing.T) {
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(time.Now().Add(time.Hour).UnixMilli(), nil).Once()
+
+		require.Equal(t, "user.login.locked.error", disable(t, "current-pass1").GetError().GetId())
+		th.store.AssertNotCalled(t, "UsersMfaDeactivate", mock.Anything, mock.Anything)
+	})
+
+	t.Run("the right password disables the MFA", func(t *testing.T) {
+		th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil).Once()
+		th.store.On("UsersMfaDeactivate", mock.Anything, user.GetId()).Return(nil).Once()
+
+		require.Nil(t, disable(t, "current-pass1").GetError())
+	})
+}
+
+func TestMfaRecoveryCodesRegenerate(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""), "user.mfa.recovery_codes.success")
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	user.MfaActive = utils.NewPointer(true)
+	ctx := th.withUser(t, th.Customer1, "current-pass1")
+	th.store.On("UsersGetByID", mock.Anything, user.GetId()).Return(user, nil)
+	th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil)
+	th.store.On("TokensMfaRecoveryCodesReplace", mock.Anything, user.GetId(), mock.Anything).Return(nil).Once()
+
+	res, resErr := th.controller.MfaRecoveryCodesRegenerate(ctx, &pbAcc.MfaRecoveryCodesRegenerateRequest{Password: "current-pass1"})
+	require.NoError(t, resErr)
+	require.Nil(t, res.GetError())
+	require.Len(t, res.GetRecoveryCodes(), intModels.MfaRecoveryCodesCount)
+	require.Empty(t, res.GetData().GetMetadata())
+}
+
+func TestMfaRecoveryCodesRegenerateReauth(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""), "user.login.password.error", "user.login.locked.error")
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	user.MfaActive = utils.NewPointer(true)
+	ctx := th.withUser(t, th.Customer1, "current-pass1")
+	th.store.On("UsersGetByID", mock.Anything, user.GetId()).Return(user, nil)
+	th.store.On("UsersGetLockedUntil", mock.Anything, user.GetId()).Return(int64(0), nil)
+	th.store.On("UsersFailedAttemptsIncrement", mock.Anything, user.GetId()).Return(int32(6), nil).Once()
+	th.store.On("UsersLock", mock.Anything, user.GetId(), mock.AnythingOfType("int64")).Return(nil).Once()
+	th.tasker.On("SendAccountLockedEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+	res, resErr := th.controller.MfaRecoveryCodesRegenerate(ctx, &pbAcc.MfaRecoveryCodesRegenerateRequest{Password: "wrong-pass1"})
+	require.NoError(t, resErr)
+	require.Equal(t, "user.login.locked.error", res.GetError().GetId())
+	th.store.AssertNotCalled(t, "TokensMfaRecoveryCodesReplace", mock.Anything, mock.Anything, mock.Anything)
+}
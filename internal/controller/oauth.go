@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"google.golang.org/grpc/codes"
+)
+
+// oauthRevokeSessions revokes all the OAuth login sessions of the user (so the user must
+// login again on every device), and all the consent sessions with their issued tokens,
+// the tracked sessions of the user are removed as well
+func (c *Controller) oauthRevokeSessions(ctx *models.Context, userID string) *models.AppError {
+	subject := url.QueryEscape(userID)
+	endpoints := []string{
+		fmt.Sprintf("%s/oauth2/auth/sessions/login?subject=%s", c.config().Oauth.GetOauthAdminUrl(), subject),
+		fmt.Sprintf("%s/oauth2/auth/sessions/consent?subject=%s&all=true", c.config().Oauth.GetOauthAdminUrl(), subject),
+	}
+
+	for _, endpoint := range endpoints {
+		if err := c.oauthAdminDelete(ctx, endpoint); err != nil {
+			return err
+		}
+	}
+
+	if err := c.store.UserSessionsDeleteAll(ctx, userID, ""); err != nil {
+		return models.NewAppError(ctx, "users.controller.oauthRevokeSessions", models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+	return nil
+}
+
+// oauthRevokeSession revokes a single OAuth login session (E,g the one of the current device),
+// and the tokens issued through it, so the device can't keep refreshing its access token
+func (c *Controller) oauthRevokeSession(ctx *models.Context, userID, sessionID string) *models.AppError {
+	sid := url.QueryEscape(sessionID)
+	endpoints := []string{
+		fmt.Sprintf("%s/oauth2/auth/sessions/login?sid=%s", c.config().Oauth.GetOauthAdminUrl(), sid),
+		fmt.Sprintf("%s/oauth2/auth/sessions/consent?subject=%s&login_session_id=%s&all=true", c.config().Oauth.GetOauthAdminUrl(), url.QueryEscape(userID), sid),
+	}
+
+	for _, endpoint := range endpoints {
+		if err := c.oauthAdminDelete(ctx, endpoint); err != nil {
+			return err
+		}
+	}
+
+	if err := c.store.UserSessionsDelete(ctx, userID, sessionID); err != nil && err.ErrType != models.DBErrorTypeNoRows {
+		return models.NewAppError(ctx, "users.controller.oauthRevokeSession", models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+	return nil
+}
+
+// oauthLoginSessionID returns the id of the login session that the OAuth server will create
+// (or reuse) once the login request of the challenge is accepted
+func (c *Controller) oauthLoginSessionID(ctx *models.Context, challenge string) (string, *models.AppError) {
+	endpoint := fmt.Sprintf("%s/oauth2/auth/requests/login?login_challenge=%s", c.config().Oauth.GetOauthAdminUrl(), url.QueryEscape(challenge))
+
+	var lr struct {
+		SessionID string `json:"session_id"`
+	}
+	if err := c.oauthAdminRequest(ctx, http.MethodGet, endpoint, nil, &lr); err != nil {
+		return "", err
+	}
+	return lr.SessionID, nil
+}
+
+// oauthAdminDelete sends a DELETE request to the OAuth admin API, a not found
+// response is considered successful since there is nothing left to delete
+func (c *Controller) oauthAdminDelete(ctx *models.Context, endpoint string) *models.AppError {
+	path := "users.controller.oauthAdminDelete"
+	internalErr := func(err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	req, err := http.NewRequestWithContext(ctx.Context, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return internalErr(err, "failed to build a DELETE HTTP request to send to OAuth service")
+	}
+
+	start := time.Now()
+	resp, err := utils.HTTPRequestWithRetry(c.httpClient, req, 3)
+	if err != nil {
+		c.log.Errorf("HTTP %s %s failed: %v (took %s)", req.Method, req.URL, err, time.Since(start))
+		return internalErr(err, "failed to request OAuth server to delete a resource")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return internalErr(nil, fmt.Sprintf("unexpected status code %d from OAuth service %s", resp.StatusCode, req.URL.Path))
+	}
+	return nil
+}
+
+// oauthAdminRequest sends a JSON request to the OAuth admin API and decodes the JSON response into out (if not nil)
+func (c *Controller) oauthAdminRequest(ctx *models.Context, method, endpoint string, body, out any) *models.AppError {
+	path := "users.controller.oauthAdminRequest"
+	internalErr := func(err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return internalErr(err, "failed to marshal json payload")
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx.Context, method, endpoint, reader)
+	if err != nil {
+		return internalErr(err, fmt.Sprintf("failed to build a %s HTTP request to send to OAuth service", method))
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := utils.HTTPRequestWithRetry(c.httpClient, req, 3)
+	if err != nil {
+		c.log.Errorf("HTTP %s %s failed: %v (took %s)", req.Method, req.URL, err, time.Since(start))
+		return internalErr(err, "failed to send a request to OAuth service")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return internalErr(nil, fmt.Sprintf("unexpected status code %d from OAuth service %s", resp.StatusCode, req.URL.Path))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var resErr intModels.OAuthErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&resErr); err != nil {
+			return internalErr(err, "failed to unmarshal the error response from Oauth service")
+		}
+		id := intModels.GetOAuthRequestErrMsgID(ctx.AcceptLanguage, resErr.Error, resErr.ErrorDescription)
+		return models.NewAppError(ctx, path, id, nil, resErr.ErrorDescription, int(codes.InvalidArgument), nil)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return internalErr(err, "failed to unmarshal the response from Oauth service")
+		}
+	}
+	return nil
+}
@@ -47,64 +47,109 @@ func (c *Controller) PasswordForgot(context context.Context, req *usersPb.Passwo
 	defer c.ProcessAudit(ar)
 	models.AuditEventDataParameter(ar, "email", email)
 
+	if err := c.captchaCheck(ctx, context, "PasswordForgot"); err != nil {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordPasswordForgotRequest(false, duration)
+		return errBuilder(err)
+	}
+
 	user, dbErr := c.store.UsersGetByEmail(ctx, email)
 	if dbErr != nil {
 		duration := time.Since(start).Seconds()
-		c.metricsCollector.RecordPasswordForgotRequest(false, duration)
 		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			if c.srvCfg.Auth.PrivacyMode {
+				// equalizes the timing with the token generation of the registered emails
+				c.loginDummyPasswordCheck(email)
+				c.passwordForgotNotice(ctx, context, email, "")
+				c.metricsCollector.RecordPasswordForgotRequest(true, duration)
+				return sucBuilder(passwordForgotSuccessData(ctx, email))
+			}
+			c.metricsCollector.RecordPasswordForgotRequest(false, duration)
 			return errBuilder(models.NewAppError(ctx, path, "email.not_found", nil, dbErr.Details, int(codes.NotFound), &models.AppErrorErrorsArgs{Err: dbErr}))
 		} else {
+			c.metricsCollector.RecordPasswordForgotRequest(false, duration)
 			return errBuilder(internalErr(ctx, err))
 		}
 	}
 
-	// SSO account has no password
-	if user.GetAuthData() != "" {
+	// the users without a password sign in with a linked identity (or a passkey)
+	if user.GetPassword() == "" {
 		duration := time.Since(start).Seconds()
+		if c.srvCfg.Auth.PrivacyMode {
+			provider, dbErr := c.identityProvider(ctx, user)
+			if dbErr != nil {
+				c.metricsCollector.RecordPasswordForgotRequest(false, duration)
+				return errBuilder(internalErr(ctx, dbErr))
+			}
+			c.metricsCollector.RecordPasswordForgotRequest(true, duration)
+			c.passwordForgotNotice(ctx, context, email, provider)
+			return sucBuilder(passwordForgotSuccessData(ctx, email))
+		}
 		c.metricsCollector.RecordPasswordForgotRequest(false, duration)
 		return errBuilder(models.NewAppError(ctx, path, "forgot.password.sso.error", nil, "", int(codes.InvalidArgument), nil))
 	}
 
+	if err := c.passwordResetStart(ctx, context, user); err != nil {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordPasswordForgotRequest(false, duration)
+		return errBuilder(err)
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordPasswordForgotRequest(true, duration)
+	return sucBuilder(passwordForgotSuccessData(ctx, email))
+}
+
+// passwordResetStart issues a password reset token for the user (the outstanding ones are
+// dropped), and enqueues the email carrying it
+func (c *Controller) passwordResetStart(ctx *models.Context, context context.Context, user *usersPb.User) *models.AppError {
+	path := "users.controller.passwordResetStart"
+	internalErr := func(err error) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, "", int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
 	token := &utils.Token{}
 	tokenData, errTok := token.GenerateToken(time.Duration(time.Hour * time.Duration(c.config().Security.GetTokenPasswordResetExpiryInHours())))
 	if errTok != nil {
-		duration := time.Since(start).Seconds()
-		c.metricsCollector.RecordPasswordForgotRequest(false, duration)
-		return errBuilder(internalErr(ctx, errTok))
+		return internalErr(errTok)
 	}
 
-	_, dbErr = c.store.TokensDeleteAllPasswordResetByUserID(ctx, user.GetId())
-	if dbErr != nil {
-		duration := time.Since(start).Seconds()
-		c.metricsCollector.RecordPasswordForgotRequest(false, duration)
-		return errBuilder(internalErr(ctx, dbErr))
+	if _, dbErr := c.store.TokensDeleteAllPasswordResetByUserID(ctx, user.GetId()); dbErr != nil {
+		return internalErr(dbErr)
 	}
 
-	dbErr = c.store.TokensAdd(ctx, user.GetId(), tokenData, intModels.TokenTypePasswordReset, path)
-	if dbErr != nil {
-		duration := time.Since(start).Seconds()
-		c.metricsCollector.RecordPasswordForgotRequest(false, duration)
-		return errBuilder(internalErr(ctx, dbErr))
+	if dbErr := c.store.TokensAdd(ctx, user.GetId(), tokenData, intModels.TokenTypePasswordReset, path); dbErr != nil {
+		return internalErr(dbErr)
 	}
 
 	optoins := []asynq.Option{asynq.MaxRetry(10), asynq.ProcessIn(time.Second * 10), asynq.Queue(worker.QueuePriorityCritical)}
 	taskPayload := &intModels.TaskSendPasswordResetEmailPayload{
 		Ctx:     ctx,
-		Email:   email,
+		Email:   user.GetEmail(),
 		Token:   tokenData.Token,
 		TokenID: tokenData.ID,
 		Hours:   int(c.config().Security.GetTokenPasswordResetExpiryInHours()),
 	}
 	if err := c.tasker.SendPasswordResetEmail(context, taskPayload, optoins...); err != nil {
-		duration := time.Since(start).Seconds()
-		c.metricsCollector.RecordPasswordForgotRequest(false, duration)
-		return errBuilder(internalErr(ctx, err))
+		return internalErr(err)
 	}
 
-	ar.Success()
-	duration := time.Since(start).Seconds()
-	c.metricsCollector.RecordPasswordForgotRequest(true, duration)
+	return nil
+}
+
+func passwordForgotSuccessData(ctx *models.Context, email string) *sharedPb.SuccessResponseData {
 	msg := models.Tr(ctx.AcceptLanguage, "forgot.password.success_message", nil)
-	metadata := map[string]string{"description": models.Tr(ctx.AcceptLanguage, "forgot.password.success_message.description", map[string]any{"Email": req.GetEmail()})}
-	return sucBuilder(&sharedPb.SuccessResponseData{Message: &msg, Metadata: metadata})
+	metadata := map[string]string{"description": models.Tr(ctx.AcceptLanguage, "forgot.password.success_message.description", map[string]any{"Email": email})}
+	return &sharedPb.SuccessResponseData{Message: &msg, Metadata: metadata}
+}
+
+// passwordForgotNotice informs the owner of the email that a reset was requested, but there is
+// no password to reset, it is used by the privacy mode instead of returning an error
+func (c *Controller) passwordForgotNotice(ctx *models.Context, context context.Context, email, authService string) {
+	options := []asynq.Option{asynq.MaxRetry(10), asynq.Queue(worker.QueuePriorityCritical)}
+	pay := &intModels.TaskSendPasswordForgotNoticeEmailPayload{Ctx: ctx, Email: email, AuthService: authService}
+	if err := c.tasker.SendPasswordForgotNoticeEmail(context, pay, options...); err != nil {
+		c.log.ErrorStruct("failed to enqueue the password forgot notice email", err)
+	}
 }
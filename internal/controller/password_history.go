@@ -0,0 +1,50 @@
+package controller
+
+import (
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"google.golang.org/grpc/codes"
+)
+
+// passwordHistorySize returns how many of the latest passwords (the current one included)
+// the user can't reuse
+func (c *Controller) passwordHistorySize(user *pb.User) int {
+	return max(c.srvCfg.Auth.PasswordHistorySize[user.GetUserType()], 1)
+}
+
+// passwordUpdateAllowed refuses to set a new password for a user whose roles aren't granted
+// intModels.PermissionPasswordUpdate, it's checked by every path changing the password
+func passwordUpdateAllowed(ctx *models.Context, path string, user *pb.User) *models.AppError {
+	if !intModels.PermissionHas(user.GetRoles(), intModels.PermissionPasswordUpdate) {
+		return models.NewAppError(ctx, path, "error.permission_denied", nil, "the password_update permission is missing", int(codes.PermissionDenied), nil)
+	}
+	return nil
+}
+
+// passwordHistoryCheck rejects a new password that matches the user's current password, or
+// one of the previous passwords kept in the history
+func (c *Controller) passwordHistoryCheck(ctx *models.Context, path string, user *pb.User, password string) *models.AppError {
+	size := c.passwordHistorySize(user)
+	hashes := []string{user.GetPassword()}
+	if size > 1 {
+		history, err := c.store.UsersPasswordHistoryGet(ctx, user.GetId(), size-1)
+		if err != nil {
+			return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+		}
+		hashes = append(hashes, history...)
+	}
+
+	for _, hash := range hashes {
+		if hash == "" {
+			continue
+		}
+		if _, err := intModels.PasswordCheck(c.passwordHasher, hash, password); err == nil {
+			params := map[string]any{"Count": size}
+			errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"password": {ID: "password.reused", Params: params}}}
+			return models.NewAppError(ctx, path, "password.reused", params, "", int(codes.InvalidArgument), errors)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+func TestPasswordHistoryCheck(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""), "password.reused")
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	ctx := th.Customer1.Ctx
+	size := th.controller.passwordHistorySize(user)
+	require.Equal(t, 3, size)
+
+	hash := func(password string) string {
+		h, err := intModels.PasswordHash(th.controller.passwordHasher, password)
+		require.NoError(t, err)
+		return h
+	}
+	current := hash("current-pass1")
+	user.Password = &current
+	// the store returns the latest size - 1 previous passwords, newest first
+	history := []string{hash("previous-pass1"), hash("previous-pass2")}
+	th.store.On("UsersPasswordHistoryGet", mock.Anything, user.GetId(), size-1).Return(history, nil)
+
+	tests := map[string]struct {
+		password string
+		expects  string
+	}{
+		"the current password":         {password: "current-pass1", expects: "password.reused"},
+		"the latest previous password": {password: "previous-pass1", expects: "password.reused"},
+		"the oldest kept password":     {password: "previous-pass2", expects: "password.reused"},
+		"a new password":               {password: "brand-new-pass1"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := th.controller.passwordHistoryCheck(ctx, "test", user, tc.password)
+			if tc.expects == "" {
+				require.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			require.Equal(t, tc.expects, err.ID)
+			require.Equal(t, size, err.IDParams["Count"])
+		})
+	}
+
+	t.Run("a history of one is the current password only", func(t *testing.T) {
+		sizes := th.srvCfg.Auth.PasswordHistorySize
+		th.srvCfg.Auth.PasswordHistorySize = map[string]int{}
+		defer func() { th.srvCfg.Auth.PasswordHistorySize = sizes }()
+
+		calls := len(th.store.Calls)
+		require.Nil(t, th.controller.passwordHistoryCheck(ctx, "test", user, "previous-pass1"))
+		require.NotNil(t, th.controller.passwordHistoryCheck(ctx, "test", user, "current-pass1"))
+		require.Len(t, th.store.Calls, calls, "the history isn't read")
+	})
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+)
+
+// PasswordReset redeems the token issued by PasswordForgot and sets the new password
+func (c *Controller) PasswordReset(context context.Context, req *pbAcc.PasswordResetRequest) (*pbAcc.PasswordResetResponse, error) {
+	start := time.Now()
+	path := "users.controller.PasswordReset"
+	errBuilder := func(e *models.AppError) (*pbAcc.PasswordResetResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordPasswordResetRequest(false, duration)
+		return &pbAcc.PasswordResetResponse{Response: &pbAcc.PasswordResetResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+	internalErr := func(ctx *models.Context, err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	if err := intModels.PasswordResetRequestIsValid(ctx, req, c.config().Password, c.passwordHasher, c.breachedPasswords); err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNamePasswordReset, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+	models.AuditEventDataParameter(ar, "token_id", req.GetTokenId())
+	models.AuditEventDataParameter(ar, "permission", intModels.PermissionPasswordUpdate.ID)
+
+	token, dbErr := c.store.TokensGet(ctx, req.GetTokenId())
+	if dbErr != nil {
+		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			return errBuilder(models.NewAppError(ctx, path, "password_reset.token.not_found", nil, "", int(codes.NotFound), &models.AppErrorErrorsArgs{Err: dbErr}))
+		}
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	if token.GetType() != string(intModels.TokenTypePasswordReset) {
+		return errBuilder(models.NewAppError(ctx, path, "password_reset.token.not_found", nil, "", int(codes.NotFound), nil))
+	}
+
+	if token.GetUsed() {
+		return errBuilder(models.NewAppError(ctx, path, "password_reset.token.used", nil, "", int(codes.InvalidArgument), nil))
+	}
+
+	if token.GetExpiresAt() < utils.TimeGetMillis() {
+		return errBuilder(models.NewAppError(ctx, path, "password_reset.token.expired", nil, "", int(codes.InvalidArgument), nil))
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(token.GetToken()), []byte(req.GetToken())); err != nil {
+		return errBuilder(models.NewAppError(ctx, path, "password_reset.token.error", nil, "", int(codes.InvalidArgument), &models.AppErrorErrorsArgs{Err: err}))
+	}
+
+	user, dbErr := c.store.UsersGetByID(ctx, token.GetUserId())
+	if dbErr != nil {
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	if err := passwordUpdateAllowed(ctx, path, user); err != nil {
+		return errBuilder(err)
+	}
+
+	if err := c.passwordHistoryCheck(ctx, path, user, req.GetPassword()); err != nil {
+		return errBuilder(err)
+	}
+
+	hash, hashErr := intModels.PasswordHash(c.passwordHasher, req.GetPassword())
+	if hashErr != nil {
+		return errBuilder(internalErr(ctx, hashErr, "failed to hash the new password"))
+	}
+
+	if err := c.store.UsersPasswordReset(ctx, token.GetUserId(), token.GetId(), hash, c.passwordHistorySize(user)); err != nil {
+		if err.ErrType == models.DBErrorTypeNoRows {
+			return errBuilder(models.NewAppError(ctx, path, "password_reset.token.used", nil, "", int(codes.InvalidArgument), nil))
+		}
+		return errBuilder(internalErr(ctx, err, err.Details))
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordPasswordResetRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "password_reset.reset_successfully", nil)
+	return &pbAcc.PasswordResetResponse{Response: &pbAcc.PasswordResetResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}}, nil
+}
@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+// getPasswordResetToken builds a password reset token of the user, and the request redeeming it
+func getPasswordResetToken(t *testing.T, userID, password string, opts ...func(token *pb.Token)) (*pb.Token, *pbAcc.PasswordResetRequest) {
+	t.Helper()
+
+	tokenData, err := (&utils.Token{}).GenerateToken(time.Hour)
+	require.NoError(t, err)
+	token := &pb.Token{
+		Id:        tokenData.ID,
+		UserId:    userID,
+		Token:     string(tokenData.Hash),
+		Type:      string(intModels.TokenTypePasswordReset),
+		CreatedAt: utils.TimeGetMillis(),
+		ExpiresAt: tokenData.Expiry.UnixMilli(),
+	}
+	for _, opt := range opts {
+		opt(token)
+	}
+
+	return token, &pbAcc.PasswordResetRequest{TokenId: tokenData.ID, Token: tokenData.Token, Password: password}
+}
+
+func TestPasswordReset(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""),
+		"password_reset.reset_successfully",
+		"password_reset.token.not_found",
+		"password_reset.token.used",
+		"password_reset.token.expired",
+		"password_reset.token.error",
+		"password.min_length",
+		"password.reused",
+		"error.permission_denied",
+	)
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	th.withPassword(t, th.Customer1, "current-pass1")
+	previous, err := intModels.PasswordHash(th.controller.passwordHasher, "previous-pass1")
+	require.NoError(t, err)
+
+	ctx := th.withContext(context.Background())
+	th.store.On("UsersGetByID", mock.Anything, user.GetId()).Return(user, nil)
+	th.store.On("UsersPasswordHistoryGet", mock.Anything, user.GetId(), th.controller.passwordHistorySize(user)-1).Return([]string{previous}, nil)
+
+	reset := func(t *testing.T, token *pb.Token, req *pbAcc.PasswordResetRequest) *pbAcc.PasswordResetResponse {
+		th.store.On("TokensGet", mock.Anything, token.GetId()).Return(token, nil).Once()
+		res, err := th.controller.PasswordReset(ctx, req)
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("the new password is set and the token consumed", func(t *testing.T) {
+		token, req := getPasswordResetToken(t, user.GetId(), "brand-new-pass1")
+		th.store.On("UsersPasswordReset", mock.Anything, user.GetId(), token.GetId(), mock.MatchedBy(func(hash string) bool {
+			_, err := intModels.PasswordCheck(th.controller.passwordHasher, hash, "brand-new-pass1")
+			return err == nil
+		}), th.controller.passwordHistorySize(user)).Return(nil).Once()
+
+		res := reset(t, token, req)
+		require.Nil(t, res.GetError())
+		require.Equal(t, "password_reset.reset_successfully", res.GetData().GetMessage())
+	})
+
+	tests := map[string]struct {
+		password string
+		opts     []func(token *pb.Token)
+		expects  string
+	}{
+		"token of another type": {password: "brand-new-pass1", opts: []func(*pb.Token){func(t *pb.Token) { t.Type = string(intModels.TokenTypeEmailConfirmation) }}, expects: "password_reset.token.not_found"},
+		"used token":            {password: "brand-new-pass1", opts: []func(*pb.Token){func(t *pb.Token) { t.Used = true }}, expects: "password_reset.token.used"},
+		"expired token":         {password: "brand-new-pass1", opts: []func(*pb.Token){func(t *pb.Token) { t.ExpiresAt = time.Now().Add(-time.Minute).UnixMilli() }}, expects: "password_reset.token.expired"},
+		"wrong token":           {password: "brand-new-pass1", opts: []func(*pb.Token){func(t *pb.Token) { t.Token = "$2a$10$invalidinvalidinvalidinvalidinvalidinvalidinvalidinvali" }}, expects: "password_reset.token.error"},
+		"current password":      {password: "current-pass1", expects: "password.reused"},
+		"previous password":     {password: "previous-pass1", expects: "password.reused"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			token, req := getPasswordResetToken(t, user.GetId(), tc.password, tc.opts...)
+			res := reset(t, token, req)
+			require.Equal(t, tc.expects, res.GetError().GetId())
+		})
+	}
+
+	t.Run("the invalid password isn't disclosed in the error", func(t *testing.T) {
+		_, req := getPasswordResetToken(t, user.GetId(), "short1")
+
+		res, err := th.controller.PasswordReset(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "password.min_length", res.GetError().GetId())
+		require.NotContains(t, res.GetError().GetDetailedError(), "short1")
+	})
+
+	t.Run("the password_update permission is required", func(t *testing.T) {
+		roles := user.Roles
+		user.Roles = []string{"unknown_role"}
+		defer func() { user.Roles = roles }()
+
+		token, req := getPasswordResetToken(t, user.GetId(), "brand-new-pass1")
+		res := reset(t, token, req)
+		require.Equal(t, "error.permission_denied", res.GetError().GetId())
+	})
+
+	t.Run("a token consumed by a concurrent reset", func(t *testing.T) {
+		token, req := getPasswordResetToken(t, user.GetId(), "brand-new-pass1")
+		th.store.On("UsersPasswordReset", mock.Anything, user.GetId(), token.GetId(), mock.Anything, mock.Anything).
+			Return(&models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+
+		res := reset(t, token, req)
+		require.Equal(t, "password_reset.token.used", res.GetError().GetId())
+	})
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/worker"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/hibiken/asynq"
+	"google.golang.org/grpc/codes"
+)
+
+// ResendVerificationEmail issues a new email confirmation token and sends it, the response is
+// the same whether the email is registered, already verified, or currently rate limited, so
+// it can't be used to find out the registered emails
+func (c *Controller) ResendVerificationEmail(context context.Context, req *pbAcc.ResendVerificationEmailRequest) (*pbAcc.ResendVerificationEmailResponse, error) {
+	start := time.Now()
+	path := "users.controller.ResendVerificationEmail"
+	errBuilder := func(e *models.AppError) (*pbAcc.ResendVerificationEmailResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordResendVerificationEmailRequest(false, duration)
+		return &pbAcc.ResendVerificationEmailResponse{Response: &pbAcc.ResendVerificationEmailResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+	internalErr := func(ctx *models.Context, err error, details string) *models.AppError {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+	sucBuilder := func() (*pbAcc.ResendVerificationEmailResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordResendVerificationEmailRequest(true, duration)
+		msg := models.Tr(ctx.AcceptLanguage, "email_confirm.resend.success_message", map[string]any{"Email": req.GetEmail()})
+		return &pbAcc.ResendVerificationEmailResponse{Response: &pbAcc.ResendVerificationEmailResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}}, nil
+	}
+
+	if err := intModels.ResendVerificationEmailRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameResendVerificationEmail, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+	models.AuditEventDataParameter(ar, "email", req.GetEmail())
+
+	user, dbErr := c.store.UsersGetByEmail(ctx, req.GetEmail())
+	if dbErr != nil {
+		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			return sucBuilder()
+		}
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	if user.GetIsEmailVerified() || user.GetPassword() == "" {
+		return sucBuilder()
+	}
+
+	since := time.Now().Add(-time.Hour * 24).UnixMilli()
+	issued, dbErr := c.store.TokensGetCreatedAtSince(ctx, user.GetId(), intModels.TokenTypeEmailConfirmation, since)
+	if dbErr != nil {
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	auth := c.srvCfg.Auth
+	cooldown := time.Duration(auth.VerificationResendCooldownSeconds) * time.Second
+	if !intModels.TokenIssueAllowed(issued, time.Now(), cooldown, auth.VerificationResendDailyCap) {
+		models.AuditEventDataParameter(ar, "rate_limited", true)
+		return sucBuilder()
+	}
+
+	hours := c.config().Security.GetTokenConfirmationExpiryInHours()
+	token := &utils.Token{}
+	tokenData, errTok := token.GenerateToken(time.Hour * time.Duration(hours))
+	if errTok != nil {
+		return errBuilder(internalErr(ctx, errTok, "failed to generate the email confirmation token"))
+	}
+
+	if dbErr := c.store.TokensRotate(ctx, user.GetId(), tokenData, intModels.TokenTypeEmailConfirmation); dbErr != nil {
+		return errBuilder(internalErr(ctx, dbErr, dbErr.Details))
+	}
+
+	options := []asynq.Option{asynq.MaxRetry(10), asynq.Queue(worker.QueuePriorityCritical)}
+	pay := &intModels.TaskSendVerifyEmailPayload{
+		Ctx:     ctx,
+		Email:   user.GetEmail(),
+		Token:   tokenData.Token,
+		TokenID: tokenData.ID,
+		Hours:   int(hours),
+	}
+	if err := c.tasker.SendVerifyEmail(context, pay, options...); err != nil {
+		return errBuilder(internalErr(ctx, err, "failed to enqueue the verify email task"))
+	}
+
+	ar.Success()
+	return sucBuilder()
+}
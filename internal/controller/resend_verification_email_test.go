@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+func TestResendVerificationEmail(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""), "email_confirm.resend.success_message")
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	user.IsEmailVerified = utils.NewPointer(false)
+	ctx := th.withContext(context.Background())
+	req := &pbAcc.ResendVerificationEmailRequest{Email: user.GetEmail()}
+
+	resend := func(t *testing.T, req *pbAcc.ResendVerificationEmailRequest) {
+		t.Helper()
+		res, err := th.controller.ResendVerificationEmail(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, res.GetError())
+		require.Equal(t, "email_confirm.resend.success_message", res.GetData().GetMessage())
+	}
+
+	t.Run("a new token is issued and sent", func(t *testing.T) {
+		th.store.On("UsersGetByEmail", mock.Anything, user.GetEmail()).Return(user, nil).Once()
+		th.store.On("TokensGetCreatedAtSince", mock.Anything, user.GetId(), intModels.TokenTypeEmailConfirmation, mock.AnythingOfType("int64")).Return([]int64{}, nil).Once()
+		th.store.On("TokensRotate", mock.Anything, user.GetId(), mock.Anything, intModels.TokenTypeEmailConfirmation).Return(nil).Once()
+		th.tasker.On("SendVerifyEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		resend(t, req)
+		th.tasker.AssertNumberOfCalls(t, "SendVerifyEmail", 1)
+	})
+
+	t.Run("a resend within the cooldown answers the same without sending", func(t *testing.T) {
+		th.store.On("UsersGetByEmail", mock.Anything, user.GetEmail()).Return(user, nil).Once()
+		th.store.On("TokensGetCreatedAtSince", mock.Anything, user.GetId(), intModels.TokenTypeEmailConfirmation, mock.AnythingOfType("int64")).
+			Return([]int64{time.Now().UnixMilli()}, nil).Once()
+
+		resend(t, req)
+		th.tasker.AssertNumberOfCalls(t, "SendVerifyEmail", 1)
+	})
+
+	t.Run("an unknown email answers the same", func(t *testing.T) {
+		th.store.On("UsersGetByEmail", mock.Anything, "unknown@example.com").Return(nil, &models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+
+		resend(t, &pbAcc.ResendVerificationEmailRequest{Email: "unknown@example.com"})
+		th.tasker.AssertNumberOfCalls(t, "SendVerifyEmail", 1)
+	})
+}
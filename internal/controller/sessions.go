@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// SessionsList returns the login sessions (the devices) of the authenticated user, the
+// session of the request is marked as the current one
+func (c *Controller) SessionsList(context context.Context, req *pbAcc.SessionsListRequest) (*pbAcc.SessionsListResponse, error) {
+	start := time.Now()
+	path := "users.controller.SessionsList"
+	errBuilder := func(e *models.AppError) (*pbAcc.SessionsListResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordSessionRequest(false, duration)
+		return &pbAcc.SessionsListResponse{Response: &pbAcc.SessionsListResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameSessionsList, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil))
+	}
+
+	sessions, dbErr := c.store.UserSessionsGetByUserID(ctx, userID)
+	if dbErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, dbErr.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: dbErr}))
+	}
+	list := make([]*pbAcc.Session, 0, len(sessions))
+	for _, s := range sessions {
+		s.Current = s.ID == ctx.Session.ID
+		list = append(list, s.ToProto())
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordSessionRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.session.list.success", nil)
+	return &pbAcc.SessionsListResponse{Response: &pbAcc.SessionsListResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}, Sessions: list}, nil
+}
+
+// SessionRevoke logs one of the devices of the authenticated user out, the OAuth login
+// session and the tokens issued through it are revoked
+func (c *Controller) SessionRevoke(context context.Context, req *pbAcc.SessionRevokeRequest) (*pbAcc.SessionRevokeResponse, error) {
+	start := time.Now()
+	path := "users.controller.SessionRevoke"
+	errBuilder := func(e *models.AppError) (*pbAcc.SessionRevokeResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordSessionRequest(false, duration)
+		return &pbAcc.SessionRevokeResponse{Response: &pbAcc.SessionRevokeResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameSessionRevoke, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+	models.AuditEventDataParameter(ar, "session_id", req.GetSessionId())
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil))
+	}
+
+	if err := intModels.SessionRevokeRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	// the session must belong to the user, otherwise any login session id could be revoked
+	sessions, dbErr := c.store.UserSessionsGetByUserID(ctx, userID)
+	if dbErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, dbErr.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: dbErr}))
+	}
+	found := false
+	for _, s := range sessions {
+		if s.ID == req.GetSessionId() {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errBuilder(models.NewAppError(ctx, path, "error.not_found", nil, "session not found", int(codes.NotFound), nil))
+	}
+
+	if err := c.oauthRevokeSession(ctx, userID, req.GetSessionId()); err != nil {
+		return errBuilder(err)
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordSessionRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.session.revoke.success", nil)
+	return &pbAcc.SessionRevokeResponse{Response: &pbAcc.SessionRevokeResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}}, nil
+}
+
+// SessionsRevokeOthers logs the authenticated user out of every device except the one the
+// request was sent from, the devices failing to be logged out don't keep the others logged
+// in, they're reported by a partial failure error
+func (c *Controller) SessionsRevokeOthers(context context.Context, req *pbAcc.SessionsRevokeOthersRequest) (*pbAcc.SessionsRevokeOthersResponse, error) {
+	start := time.Now()
+	path := "users.controller.SessionsRevokeOthers"
+	errBuilder := func(e *models.AppError) (*pbAcc.SessionsRevokeOthersResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordSessionRequest(false, duration)
+		return &pbAcc.SessionsRevokeOthersResponse{Response: &pbAcc.SessionsRevokeOthersResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameSessionsRevokeOthers, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil))
+	}
+	current := ctx.Session.ID
+	if current == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "the session id is missing", int(codes.Unauthenticated), nil))
+	}
+
+	revoked, revokeErr := c.sessionsRevokeOthers(ctx, userID, current)
+	models.AuditEventDataParameter(ar, "revoked", revoked)
+	if revokeErr != nil {
+		return errBuilder(revokeErr)
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordSessionRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.session.revoke_others.success", nil)
+	return &pbAcc.SessionsRevokeOthersResponse{Response: &pbAcc.SessionsRevokeOthersResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg}}}, nil
+}
+
+// sessionsRevokeOthers revokes every login session of the user except the kept one (an empty
+// id keeps none), and returns the number of the revoked sessions, a session failing to be
+// revoked doesn't stop the others, the returned error reports how many of them failed
+func (c *Controller) sessionsRevokeOthers(ctx *models.Context, userID, keepID string) (int, *models.AppError) {
+	path := "users.controller.sessionsRevokeOthers"
+	sessions, dbErr := c.store.UserSessionsGetByUserID(ctx, userID)
+	if dbErr != nil {
+		return 0, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, dbErr.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: dbErr})
+	}
+
+	revoked := 0
+	var errs []error
+	for _, s := range sessions {
+		if s.ID == keepID {
+			continue
+		}
+		if err := c.oauthRevokeSession(ctx, userID, s.ID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		revoked++
+	}
+
+	if len(errs) > 0 {
+		params := map[string]any{"Failed": len(errs)}
+		details := fmt.Sprintf("failed to revoke %d of %d sessions", len(errs), len(errs)+revoked)
+		return revoked, models.NewAppError(ctx, path, "user.session.revoke_others.partial_failure", params, details, int(codes.Unavailable), &models.AppErrorErrorsArgs{Err: errors.Join(errs...)})
+	}
+	return revoked, nil
+}
+
+// sessionTouchInterceptor updates the last seen time of the session that the authenticated
+// requests are sent with, it must run after models.UnaryMetadataInterceptor, which builds
+// the request context, a failure is logged without failing the request
+func (c *Controller) sessionTouchInterceptor() grpc.UnaryServerInterceptor {
+	return func(gctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := models.ContextGet(gctx)
+		if err == nil && ctx.Session != nil && ctx.Session.UserID != "" && ctx.Session.ID != "" {
+			c.sessionTouch(ctx)
+		}
+		return handler(gctx, req)
+	}
+}
+
+// sessionTouch writes the last seen time of the request's session at most once per
+// interval, the sessions touched recently are remembered so their requests skip the database
+func (c *Controller) sessionTouch(ctx *models.Context) {
+	interval := time.Duration(c.srvCfg.Auth.SessionTouchSeconds) * time.Second
+	if interval <= 0 {
+		interval = intModels.UserSessionTouchInterval
+	}
+
+	now := utils.TimeGetMillis()
+	if last, ok := c.sessionTouches.Load(ctx.Session.ID); ok && now-last.(int64) < interval.Milliseconds() {
+		return
+	}
+	c.sessionTouches.Store(ctx.Session.ID, now)
+
+	if err := c.store.UserSessionsTouch(ctx, ctx.Session.UserID, ctx.Session.ID, now, interval); err != nil {
+		c.log.ErrorStruct("failed to update the last seen time of the session", err)
+	}
+
+	// the expired entries are dropped once per interval, so the ended sessions don't pile up
+	if last := c.sessionTouchesPrunedAt.Load(); now-last >= interval.Milliseconds() && c.sessionTouchesPrunedAt.CompareAndSwap(last, now) {
+		c.sessionTouches.Range(func(id, at any) bool {
+			if now-at.(int64) >= interval.Milliseconds() {
+				c.sessionTouches.Delete(id)
+			}
+			return true
+		})
+	}
+}
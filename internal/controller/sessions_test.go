@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+func TestSessionsList(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""), "user.session.list.success")
+	defer th.TearDown()
+
+	userID := th.Customer1.User.GetId()
+	th.Customer1.Ctx.Session.ID = "session-current"
+	ctx := th.withUser(t, th.Customer1, "")
+
+	sessions := []*intModels.UserSession{
+		{ID: "session-other", UserAgent: "fake-agent", LastSeenAt: 1700000002000},
+		{ID: "session-current", UserAgent: "fake-agent", LastSeenAt: 1700000001000},
+	}
+	th.store.On("UserSessionsGetByUserID", mock.Anything, userID).Return(sessions, nil).Once()
+
+	res, err := th.controller.SessionsList(ctx, &pbAcc.SessionsListRequest{})
+	require.NoError(t, err)
+	require.Nil(t, res.GetError())
+	require.Len(t, res.GetSessions(), 2)
+	require.Equal(t, "session-other", res.GetSessions()[0].GetId())
+	require.False(t, res.GetSessions()[0].GetCurrent())
+	require.Equal(t, "session-current", res.GetSessions()[1].GetId())
+	require.True(t, res.GetSessions()[1].GetCurrent())
+	require.Equal(t, int64(1700000001000), res.GetSessions()[1].GetLastSeenAt())
+}
+
+func TestSessionsRevokeOthers(t *testing.T) {
+	// the OAuth server fails to revoke the "session-broken" login session only
+	hydra := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sid") == "session-broken" || r.URL.Query().Get("login_session_id") == "session-broken" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer hydra.Close()
+
+	th := NewOfflineTestHelper(t, testConfig(hydra.URL),
+		"user.session.revoke_others.success",
+		"user.session.revoke_others.partial_failure",
+	)
+	defer th.TearDown()
+
+	userID := th.Customer1.User.GetId()
+	th.Customer1.Ctx.Session.ID = "session-current"
+	ctx := th.withUser(t, th.Customer1, "")
+
+	sessions := []*intModels.UserSession{{ID: "session-current"}, {ID: "session-broken"}, {ID: "session-other"}}
+	th.store.On("UserSessionsGetByUserID", mock.Anything, userID).Return(sessions, nil).Once()
+	th.store.On("UserSessionsDelete", mock.Anything, userID, "session-other").Return(nil).Once()
+
+	res, err := th.controller.SessionsRevokeOthers(ctx, &pbAcc.SessionsRevokeOthersRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "user.session.revoke_others.partial_failure", res.GetError().GetId())
+	th.store.AssertCalled(t, "UserSessionsDelete", mock.Anything, userID, "session-other")
+	th.store.AssertNotCalled(t, "UserSessionsDelete", mock.Anything, userID, "session-current")
+}
+
+func TestSessionTouchInterceptor(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""))
+	defer th.TearDown()
+
+	intercept := th.controller.sessionTouchInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) { return "handled", nil }
+
+	t.Run("the session of an authenticated request is touched", func(t *testing.T) {
+		th.Customer1.Ctx.Session.ID = "session-current"
+		ctx := th.withUser(t, th.Customer1, "")
+		th.store.On("UserSessionsTouch", mock.Anything, th.Customer1.User.GetId(), "session-current", mock.AnythingOfType("int64"), time.Minute).Return(nil).Once()
+
+		res, err := intercept(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+		require.NoError(t, err)
+		require.Equal(t, "handled", res)
+	})
+
+	t.Run("the session touched within the interval skips the store", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), models.ContextKeyMetadata, th.Customer1.Ctx)
+
+		res, err := intercept(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+		require.NoError(t, err)
+		require.Equal(t, "handled", res)
+		th.store.AssertNumberOfCalls(t, "UserSessionsTouch", 1)
+	})
+
+	t.Run("the anonymous requests are let through", func(t *testing.T) {
+		th.Customer1.Ctx.Session.UserID = ""
+		ctx := context.WithValue(context.Background(), models.ContextKeyMetadata, th.Customer1.Ctx)
+
+		res, err := intercept(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+		require.NoError(t, err)
+		require.Equal(t, "handled", res)
+		th.store.AssertNumberOfCalls(t, "UserSessionsTouch", 1)
+	})
+}
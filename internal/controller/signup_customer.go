@@ -42,7 +42,13 @@ func (c *Controller) CreateCustomer(context context.Context, req *pb.CustomerCre
 	defer c.ProcessAudit(ar)
 
 	sanitized := intModels.SignupCustomerRequestSanitize(req)
-	if err = intModels.SignupCustomerRequestIsValid(ctx, sanitized, c.config().Password); err != nil {
+	if err = intModels.SignupCustomerRequestIsValid(ctx, sanitized, c.config().Password, c.passwordHasher, c.breachedPasswords, c.emailDomainPolicy()); err != nil {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordCustomerCreateRequest(false, duration)
+		return errBuilder(err)
+	}
+
+	if err = c.captchaCheck(ctx, context, "CreateCustomer"); err != nil {
 		duration := time.Since(start).Seconds()
 		c.metricsCollector.RecordCustomerCreateRequest(false, duration)
 		return errBuilder(err)
@@ -72,6 +78,7 @@ func (c *Controller) CreateCustomer(context context.Context, req *pb.CustomerCre
 			Email:     utils.NewPointer(sanitized.GetEmail()),
 			Password:  utils.NewPointer(req.GetPassword()),
 		},
+		c.passwordHasher,
 	)
 	if err != nil {
 		duration := time.Since(start).Seconds()
@@ -42,7 +42,13 @@ func (c *Controller) CreateSupplier(context context.Context, req *pb.SupplierCre
 	defer c.ProcessAudit(ar)
 
 	sanitized := intModels.SignupSupplierRequestSanitize(req)
-	if err = intModels.SignupSupplierRequestIsValid(ctx, sanitized, c.config().Password); err != nil {
+	if err = intModels.SignupSupplierRequestIsValid(ctx, sanitized, c.config().Password, c.passwordHasher, c.breachedPasswords, c.emailDomainPolicy()); err != nil {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordSupplierCreateRequest(false, duration)
+		return errBuilder(err)
+	}
+
+	if err = c.captchaCheck(ctx, context, "CreateSupplier"); err != nil {
 		duration := time.Since(start).Seconds()
 		c.metricsCollector.RecordSupplierCreateRequest(false, duration)
 		return errBuilder(err)
@@ -74,6 +80,7 @@ func (c *Controller) CreateSupplier(context context.Context, req *pb.SupplierCre
 			Password:   utils.NewPointer(req.GetPassword()),
 			Roles:      []string{string(models.RoleIDSupplierAdmin)},
 		},
+		c.passwordHasher,
 	)
 	if err != nil {
 		duration := time.Since(start).Seconds()
@@ -32,7 +32,7 @@ func (th *TestHelper) getValidSignupSupplierRequest(t *testing.T) (*userPb.User,
 		Membership: utils.NewPointer(validReq.GetMembership()),
 	}
 
-	validUser, err := models.SignupSupplierRequestPreSave(th.Supplier1.Ctx, validSupplier)
+	validUser, err := models.SignupSupplierRequestPreSave(th.Supplier1.Ctx, validSupplier, th.controller.passwordHasher)
 	require.Nil(t, err)
 	return validUser, validReq
 }
@@ -2,6 +2,9 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"runtime"
 	"testing"
@@ -18,6 +21,7 @@ import (
 	workerMocks "github.com/ahmad-khatib0-org/megacommerce-user/internal/worker/mocks"
 	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
 	"github.com/brianvoe/gofakeit/v7"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/spf13/viper"
 )
 
@@ -70,17 +74,127 @@ func NewTestHelper(tb testing.TB) (*TestHelper, *models.InternalError) {
 	th.store = store
 	th.tasker = tasker
 	th.controller = &Controller{
-		config: th.config,
-		log:    th.log,
-		store:  store,
-		tasker: tasker,
+		config:            th.config,
+		log:               th.log,
+		store:             store,
+		tasker:            tasker,
+		srvCfg:            th.srvCfg,
+		passwordHasher:    testPasswordHasher(),
+		emailDomainPolicy: func() *intModels.EmailDomainPolicy { return nil },
 	}
 
 	th.initUsers()
 	return th, nil
 }
 
+// testPasswordHasher is an argon2id hasher with cheap parameters, so the tests don't spend seconds on hashing
+func testPasswordHasher() intModels.PasswordHasher {
+	return &intModels.Argon2idHasher{MemoryKiB: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+}
+
+// testConfig builds the shared config of the offline tests, the OAuth admin endpoints are
+// the ones served at hydraURL (empty if the test doesn't reach them), opts adjust the rest
+func testConfig(hydraURL string, opts ...func(cfg *com.Config)) *com.Config {
+	cfg := &com.Config{
+		Main:         &com.ConfigMain{SiteName: utils.NewPointer("Megacommerce")},
+		Localization: &com.ConfigLocalization{DefaultClientLocale: utils.NewPointer("en")},
+		Security: &com.ConfigSecurity{
+			EnableMultifactorAuthentication: utils.NewPointer(true),
+			MaximumLoginAttempts:            utils.NewPointer(int32(5)),
+			AccessTokenExpiryWebInHours:     utils.NewPointer(int32(24)),
+			AccessTokenExpiryMobileInHours:  utils.NewPointer(int32(24)),
+		},
+		Password: &com.ConfigPassword{
+			MinimumLength: utils.NewPointer(int32(8)),
+			MaximumLength: utils.NewPointer(int32(72)),
+			Lowercase:     utils.NewPointer(true),
+			Number:        utils.NewPointer(true),
+		},
+		Oauth: &com.ConfigOAuth{OauthAdminUrl: utils.NewPointer(hydraURL)},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// NewOfflineTestHelper builds a TestHelper that doesn't need the common service, the shared
+// config is the given one, and the translations are only the trIDs (plus the generic errors),
+// each one translated to itself
+func NewOfflineTestHelper(tb testing.TB, config *com.Config, trIDs ...string) *TestHelper {
+	tb.Helper()
+	th := &TestHelper{config: func() *com.Config { return config }}
+	log, err := logger.InitLogger("dev")
+	if err != nil {
+		tb.Fatalf("failed to initialize logger: %v", err)
+	}
+	th.log = log
+
+	if err := th.initServiceConfig(); err != nil {
+		tb.Fatalf("%v", err)
+	}
+
+	ids := append([]string{models.ErrMsgInternal, "error.unauthenticated", "error.not_found"}, trIDs...)
+	trans := &com.TranslationElements{}
+	for _, id := range ids {
+		trans.Trans = append(trans.Trans, &com.TranslationElement{Id: id, Tr: id})
+	}
+	locale := config.GetLocalization().GetDefaultClientLocale()
+	if err := models.TranslationsInit(map[string]*com.TranslationElements{locale: trans}, locale); err != nil {
+		tb.Fatalf("failed to init translations: %v", err)
+	}
+
+	th.store = storeMocks.NewMockUsersStore(tb)
+	th.mailer = mailerMocks.NewMockMailerService(tb)
+	th.tasker = workerMocks.NewMockTaskDistributor(tb)
+	th.controller = &Controller{
+		config:            th.config,
+		log:               th.log,
+		store:             th.store,
+		tasker:            th.tasker,
+		srvCfg:            th.srvCfg,
+		httpClient:        http.DefaultClient,
+		metricsCollector:  NewMetricsCollector(),
+		passwordHasher:    testPasswordHasher(),
+		emailDomainPolicy: func() *intModels.EmailDomainPolicy { return nil },
+	}
+
+	wa, err := webauthn.New(&webauthn.Config{
+		RPID:          th.srvCfg.WebAuthn.RPID,
+		RPDisplayName: th.srvCfg.WebAuthn.RPDisplayName,
+		RPOrigins:     th.srvCfg.WebAuthn.RPOrigins,
+	})
+	if err != nil {
+		tb.Fatalf("failed to initialize webauthn: %v", err)
+	}
+	th.controller.webauthn = wa
+
+	th.initUsers()
+	return th
+}
+
+// newFakeHydra serves the OAuth admin endpoints used to accept a login, the login
+// requests belong to the hydra-session login session
+func newFakeHydra(tb testing.TB, redirectTo string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/oauth2/auth/requests/login":
+			json.NewEncoder(w).Encode(map[string]string{"session_id": "hydra-session"})
+		case r.Method == http.MethodPut && r.URL.Path == "/oauth2/auth/requests/login/accept":
+			json.NewEncoder(w).Encode(map[string]string{"redirect_to": redirectTo})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": "not_found"})
+		}
+	}))
+	tb.Cleanup(server.Close)
+	return server
+}
+
 func (th *TestHelper) TearDown() {
+	if th.common == nil {
+		return
+	}
 	if err := th.common.Close(); err != nil {
 		th.log.Warnf("failed to close the common client listener ", err)
 	}
@@ -135,6 +249,7 @@ func (th *TestHelper) createUser(userType intModels.UserType, roleID models.Role
 
 func (th *TestHelper) getContext() *models.Context {
 	ctx := &models.Context{
+		Context:        context.Background(),
 		RequestID:      utils.NewID(),
 		IPAddress:      gofakeit.IPv4Address(),
 		XForwardedFor:  gofakeit.IPv4Address(),
@@ -154,6 +269,27 @@ func (th *TestHelper) getContext() *models.Context {
 	return ctx
 }
 
+// withPassword sets the password of tu, hashed by the controller hasher
+func (th *TestHelper) withPassword(tb testing.TB, tu *TestingUser, password string) {
+	tb.Helper()
+	hash, err := intModels.PasswordHash(th.controller.passwordHasher, password)
+	if err != nil {
+		tb.Fatalf("failed to hash the password: %v", err)
+	}
+	tu.User.Password = utils.NewPointer(hash)
+}
+
+// withUser makes tu the authenticated user of the session, with the password set unless
+// it's empty, and returns the context of its requests
+func (th *TestHelper) withUser(tb testing.TB, tu *TestingUser, password string) context.Context {
+	tb.Helper()
+	if password != "" {
+		th.withPassword(tb, tu, password)
+	}
+	tu.Ctx.Session.UserID = tu.User.GetId()
+	return context.WithValue(context.Background(), models.ContextKeyMetadata, tu.Ctx)
+}
+
 func (th *TestHelper) withContext(ctx context.Context) context.Context {
 	return context.WithValue(ctx, models.ContextKeyMetadata, th.getContext())
 }
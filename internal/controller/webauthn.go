@@ -0,0 +1,380 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+
+	pbSh "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/shared/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"google.golang.org/grpc/codes"
+)
+
+// WebauthnRegisterBegin starts the registration of a new passkey for the authenticated user,
+// the returned options are passed as is to navigator.credentials.create()
+func (c *Controller) WebauthnRegisterBegin(context context.Context, req *pbAcc.WebauthnRegisterBeginRequest) (*pbAcc.WebauthnRegisterBeginResponse, error) {
+	start := time.Now()
+	path := "users.controller.WebauthnRegisterBegin"
+	errBuilder := func(e *models.AppError) (*pbAcc.WebauthnRegisterBeginResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordWebauthnRequest(false, duration)
+		return &pbAcc.WebauthnRegisterBeginResponse{Response: &pbAcc.WebauthnRegisterBeginResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil))
+	}
+
+	waUser, err := c.webauthnGetUser(ctx, path, userID)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(waUser.Credentials))
+	for _, cred := range waUser.Credentials {
+		exclusions = append(exclusions, protocol.CredentialDescriptor{Type: protocol.PublicKeyCredentialType, CredentialID: cred.Credential.ID})
+	}
+
+	creation, session, waErr := c.webauthn.BeginRegistration(
+		waUser,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred),
+	)
+	if waErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, "failed to begin the webauthn registration", int(codes.Internal), &models.AppErrorErrorsArgs{Err: waErr}))
+	}
+
+	meta, err := c.webauthnSessionStart(ctx, path, userID, intModels.WebauthnSessionTypeRegistration, session, creation)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordWebauthnRequest(true, duration)
+	return &pbAcc.WebauthnRegisterBeginResponse{Response: &pbAcc.WebauthnRegisterBeginResponse_Data{Data: &pbSh.SuccessResponseData{Metadata: meta}}}, nil
+}
+
+// WebauthnRegisterFinish verifies the attestation created by the authenticator and
+// stores the new passkey
+func (c *Controller) WebauthnRegisterFinish(context context.Context, req *pbAcc.WebauthnRegisterFinishRequest) (*pbAcc.WebauthnRegisterFinishResponse, error) {
+	start := time.Now()
+	path := "users.controller.WebauthnRegisterFinish"
+	errBuilder := func(e *models.AppError) (*pbAcc.WebauthnRegisterFinishResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordWebauthnRequest(false, duration)
+		return &pbAcc.WebauthnRegisterFinishResponse{Response: &pbAcc.WebauthnRegisterFinishResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameWebauthnRegister, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	if err := intModels.WebauthnRegisterFinishRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	userID := ctx.Session.UserID
+	if userID == "" {
+		return errBuilder(models.NewAppError(ctx, path, "error.unauthenticated", nil, "user not authenticated", int(codes.Unauthenticated), nil))
+	}
+
+	session, err := c.webauthnSessionTake(ctx, path, req.GetSessionId(), intModels.WebauthnSessionTypeRegistration)
+	if err != nil {
+		return errBuilder(err)
+	}
+	if session.UserID != userID {
+		return errBuilder(models.NewAppError(ctx, path, "user.webauthn.session.invalid", nil, "", int(codes.InvalidArgument), nil))
+	}
+
+	waUser, err := c.webauthnGetUser(ctx, path, userID)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	parsed, parseErr := protocol.ParseCredentialCreationResponseBody(strings.NewReader(req.GetCredential()))
+	if parseErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, "user.webauthn.credential.invalid", nil, parseErr.Error(), int(codes.InvalidArgument), &models.AppErrorErrorsArgs{Err: parseErr}))
+	}
+
+	cred, waErr := c.webauthn.CreateCredential(waUser, session.Data, parsed)
+	if waErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, "user.webauthn.credential.invalid", nil, waErr.Error(), int(codes.InvalidArgument), &models.AppErrorErrorsArgs{Err: waErr}))
+	}
+
+	stored := &intModels.WebauthnCredential{
+		ID:         intModels.WebauthnCredentialID(cred.ID),
+		UserID:     userID,
+		Name:       strings.TrimSpace(req.GetName()),
+		Credential: *cred,
+		CreatedAt:  utils.TimeGetMillis(),
+	}
+	if err := c.store.WebauthnCredentialsAdd(ctx, stored); err != nil {
+		if err.ErrType == models.DBErrorTypeUniqueViolation {
+			return errBuilder(models.NewAppError(ctx, path, "user.webauthn.credential.exists", nil, "", int(codes.AlreadyExists), nil))
+		}
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err}))
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordWebauthnRequest(true, duration)
+
+	msg := models.Tr(ctx.AcceptLanguage, "user.webauthn.register.success", nil)
+	return &pbAcc.WebauthnRegisterFinishResponse{Response: &pbAcc.WebauthnRegisterFinishResponse_Data{Data: &pbSh.SuccessResponseData{Message: &msg, Metadata: map[string]string{"credential_id": stored.ID}}}}, nil
+}
+
+// WebauthnLoginBegin starts a passkey login for the user owning the email, the returned
+// options are passed as is to navigator.credentials.get()
+func (c *Controller) WebauthnLoginBegin(context context.Context, req *pbAcc.WebauthnLoginBeginRequest) (*pbAcc.WebauthnLoginBeginResponse, error) {
+	start := time.Now()
+	path := "users.controller.WebauthnLoginBegin"
+	errBuilder := func(e *models.AppError) (*pbAcc.WebauthnLoginBeginResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordWebauthnRequest(false, duration)
+		return &pbAcc.WebauthnLoginBeginResponse{Response: &pbAcc.WebauthnLoginBeginResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	if err := intModels.WebauthnLoginBeginRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	// the privacy mode answers the unknown emails and the users without passkeys with the
+	// options of a fake user, the login then fails on the finish step like a wrong passkey
+	privacy := c.srvCfg.Auth.PrivacyMode
+	user, dbErr := c.store.UsersGetByEmail(ctx, req.GetEmail())
+	if dbErr != nil {
+		if dbErr.ErrType != models.DBErrorTypeNoRows {
+			return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, dbErr.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: dbErr}))
+		}
+		if !privacy {
+			return errBuilder(models.NewAppError(ctx, path, "user.webauthn.no_credentials.error", nil, "", int(codes.NotFound), nil))
+		}
+		user = nil
+	}
+
+	var waUser *intModels.WebauthnUser
+	if user != nil {
+		waUser, err = c.webauthnGetUser(ctx, path, user.GetId())
+		if err != nil {
+			return errBuilder(err)
+		}
+		if len(waUser.Credentials) == 0 {
+			if !privacy {
+				return errBuilder(models.NewAppError(ctx, path, "user.webauthn.no_credentials.error", nil, "", int(codes.NotFound), nil))
+			}
+			waUser = nil
+		}
+	}
+
+	// a passkey login stands for both factors, so the authenticator must verify the user
+	// (E,g by a PIN or a biometric), the possession of the passkey alone isn't enough
+	verification := webauthn.WithUserVerification(protocol.VerificationRequired)
+	if waUser == nil {
+		assertion, _, waErr := c.webauthn.BeginLogin(intModels.WebauthnFakeUser(c.srvCfg.WebAuthn.PrivacySecret, req.GetEmail()), verification)
+		if waErr != nil {
+			return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, "failed to begin the webauthn login", int(codes.Internal), &models.AppErrorErrorsArgs{Err: waErr}))
+		}
+		opts, jsonErr := json.Marshal(assertion)
+		if jsonErr != nil {
+			return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, "failed to marshal webauthn options", int(codes.Internal), &models.AppErrorErrorsArgs{Err: jsonErr}))
+		}
+
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordWebauthnRequest(true, duration)
+		meta := map[string]string{"session_id": utils.NewID(), "options": string(opts)}
+		return &pbAcc.WebauthnLoginBeginResponse{Response: &pbAcc.WebauthnLoginBeginResponse_Data{Data: &pbSh.SuccessResponseData{Metadata: meta}}}, nil
+	}
+
+	assertion, session, waErr := c.webauthn.BeginLogin(waUser, verification)
+	if waErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, "failed to begin the webauthn login", int(codes.Internal), &models.AppErrorErrorsArgs{Err: waErr}))
+	}
+
+	meta, err := c.webauthnSessionStart(ctx, path, user.GetId(), intModels.WebauthnSessionTypeLogin, session, assertion)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordWebauthnRequest(true, duration)
+	return &pbAcc.WebauthnLoginBeginResponse{Response: &pbAcc.WebauthnLoginBeginResponse_Data{Data: &pbSh.SuccessResponseData{Metadata: meta}}}, nil
+}
+
+// WebauthnLoginFinish verifies the assertion signed by the authenticator and accepts
+// the OAuth login request the same way Login does
+func (c *Controller) WebauthnLoginFinish(context context.Context, req *pbAcc.WebauthnLoginFinishRequest) (*pbAcc.WebauthnLoginFinishResponse, error) {
+	start := time.Now()
+	path := "users.controller.WebauthnLoginFinish"
+	errBuilder := func(e *models.AppError) (*pbAcc.WebauthnLoginFinishResponse, error) {
+		duration := time.Since(start).Seconds()
+		c.metricsCollector.RecordWebauthnRequest(false, duration)
+		return &pbAcc.WebauthnLoginFinishResponse{Response: &pbAcc.WebauthnLoginFinishResponse_Error{Error: models.AppErrorToProto(e)}}, nil
+	}
+
+	ctx, err := models.ContextGet(context)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar := models.AuditRecordNew(ctx, intModels.EventNameWebauthnLogin, models.EventStatusFail)
+	defer c.ProcessAudit(ar)
+
+	if err := intModels.WebauthnLoginFinishRequestIsValid(ctx, req); err != nil {
+		return errBuilder(err)
+	}
+
+	// the sessions of the fake users (see WebauthnLoginBegin) don't exist, and a locked
+	// account isn't disclosed either, they fail in the privacy mode like a wrong passkey
+	privacy := c.srvCfg.Auth.PrivacyMode
+	invalidCredential := models.NewAppError(ctx, path, "user.webauthn.credential.invalid", nil, "", int(codes.Unauthenticated), nil)
+
+	session, err := c.webauthnSessionTake(ctx, path, req.GetSessionId(), intModels.WebauthnSessionTypeLogin)
+	if err != nil {
+		if privacy && err.ID == "user.webauthn.session.invalid" {
+			return errBuilder(invalidCredential)
+		}
+		return errBuilder(err)
+	}
+
+	waUser, err := c.webauthnGetUser(ctx, path, session.UserID)
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	lockedUntil, dbErr := c.store.UsersGetLockedUntil(ctx, session.UserID)
+	if dbErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, dbErr.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: dbErr}))
+	}
+	if remaining := time.Until(time.UnixMilli(lockedUntil)); remaining > 0 {
+		if privacy {
+			return errBuilder(invalidCredential)
+		}
+		params := map[string]any{"Minutes": int(math.Ceil(remaining.Minutes()))}
+		return errBuilder(models.NewAppError(ctx, path, "user.login.locked.error", params, "", int(codes.PermissionDenied), nil))
+	}
+
+	if err := c.loginCaptchaCheck(ctx, context, waUser.User); err != nil {
+		return errBuilder(err)
+	}
+
+	parsed, parseErr := protocol.ParseCredentialRequestResponseBody(strings.NewReader(req.GetCredential()))
+	if parseErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, "user.webauthn.credential.invalid", nil, parseErr.Error(), int(codes.InvalidArgument), &models.AppErrorErrorsArgs{Err: parseErr}))
+	}
+
+	cred, waErr := c.webauthn.ValidateLogin(waUser, session.Data, parsed)
+	if waErr != nil {
+		return errBuilder(models.NewAppError(ctx, path, "user.webauthn.credential.invalid", nil, waErr.Error(), int(codes.Unauthenticated), &models.AppErrorErrorsArgs{Err: waErr}))
+	}
+	if cred.Authenticator.CloneWarning {
+		return errBuilder(models.NewAppError(ctx, path, "user.webauthn.clone_warning.error", nil, "the sign count of the authenticator went backwards", int(codes.PermissionDenied), nil))
+	}
+
+	stored := waUser.CredentialByID(cred.ID)
+	if stored == nil {
+		return errBuilder(models.NewAppError(ctx, path, "user.webauthn.credential.invalid", nil, "", int(codes.Unauthenticated), nil))
+	}
+	stored.Credential = *cred
+	if err := c.store.WebauthnCredentialsUpdateUsage(ctx, stored); err != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err}))
+	}
+
+	user := waUser.User
+	if err := c.loginEmailVerifiedCheck(ctx, path, user); err != nil {
+		return errBuilder(err)
+	}
+
+	if err := c.store.UsersLoginSucceeded(ctx, user.GetId()); err != nil {
+		return errBuilder(models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err}))
+	}
+
+	redirectTo, err := c.oauthLoginAccept(ctx, context, user, req.GetLoginChallenge())
+	if err != nil {
+		return errBuilder(err)
+	}
+
+	ar.Success()
+	duration := time.Since(start).Seconds()
+	c.metricsCollector.RecordWebauthnRequest(true, duration)
+
+	return &pbAcc.WebauthnLoginFinishResponse{Response: &pbAcc.WebauthnLoginFinishResponse_Data{Data: &pbSh.SuccessResponseData{Metadata: map[string]string{"redirect_to": redirectTo}}}}, nil
+}
+
+func (c *Controller) webauthnGetUser(ctx *models.Context, path, userID string) (*intModels.WebauthnUser, *models.AppError) {
+	user, err := c.store.UsersGetByID(ctx, userID)
+	if err != nil {
+		if err.ErrType == models.DBErrorTypeNoRows {
+			return nil, models.NewAppError(ctx, path, "error.not_found", nil, "user not found", int(codes.NotFound), nil)
+		}
+		return nil, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	creds, err := c.store.WebauthnCredentialsGetByUserID(ctx, userID)
+	if err != nil {
+		return nil, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	return &intModels.WebauthnUser{User: user, Credentials: creds}, nil
+}
+
+// webauthnSessionStart persists the ceremony session and returns the metadata sent to the client
+func (c *Controller) webauthnSessionStart(ctx *models.Context, path, userID string, typ intModels.WebauthnSessionType, data *webauthn.SessionData, options any) (map[string]string, *models.AppError) {
+	opts, err := json.Marshal(options)
+	if err != nil {
+		return nil, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, "failed to marshal webauthn options", int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	ttl := time.Duration(c.srvCfg.WebAuthn.SessionMinutes) * time.Minute
+	session := &intModels.WebauthnSession{
+		ID:        utils.NewID(),
+		UserID:    userID,
+		Type:      typ,
+		Data:      *data,
+		ExpiresAt: time.Now().Add(ttl).UnixMilli(),
+	}
+	if err := c.store.WebauthnSessionsAdd(ctx, session); err != nil {
+		return nil, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	return map[string]string{"session_id": session.ID, "options": string(opts)}, nil
+}
+
+// webauthnSessionTake consumes the ceremony session, it fails if it's missing, expired or of another type
+func (c *Controller) webauthnSessionTake(ctx *models.Context, path, id string, typ intModels.WebauthnSessionType) (*intModels.WebauthnSession, *models.AppError) {
+	invalid := models.NewAppError(ctx, path, "user.webauthn.session.invalid", nil, "", int(codes.InvalidArgument), nil)
+
+	session, err := c.store.WebauthnSessionsTake(ctx, id)
+	if err != nil {
+		if err.ErrType == models.DBErrorTypeNoRows {
+			return nil, invalid
+		}
+		return nil, models.NewAppError(ctx, path, models.ErrMsgInternal, nil, err.Details, int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	if session.Type != typ || session.ExpiresAt < utils.TimeGetMillis() {
+		return nil, invalid
+	}
+	return session, nil
+}
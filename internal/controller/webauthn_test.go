@@ -0,0 +1,365 @@
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/captcha"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+// fakeAuthenticator is a software passkey, it creates "none" attestations and signs
+// assertions with an ES256 key
+type fakeAuthenticator struct {
+	t         *testing.T
+	key       *ecdsa.PrivateKey
+	id        []byte
+	rpID      string
+	origin    string
+	signCount uint32
+	// unverified signs the assertions with the user present flag only, without the user verified one
+	unverified bool
+}
+
+func newFakeAuthenticator(t *testing.T, rpID, origin string) *fakeAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	id := make([]byte, 16)
+	_, err = rand.Read(id)
+	require.NoError(t, err)
+
+	return &fakeAuthenticator{t: t, key: key, id: id, rpID: rpID, origin: origin}
+}
+
+func (a *fakeAuthenticator) authData(flags byte, attested []byte) []byte {
+	rpHash := sha256.Sum256([]byte(a.rpID))
+	data := append(rpHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	return append(data, attested...)
+}
+
+func (a *fakeAuthenticator) clientData(typ, challenge string) []byte {
+	data, err := json.Marshal(map[string]string{"type": typ, "challenge": challenge, "origin": a.origin})
+	require.NoError(a.t, err)
+	return data
+}
+
+// create returns the JSON encoded PublicKeyCredential of navigator.credentials.create()
+func (a *fakeAuthenticator) create(challenge string) string {
+	x, y := make([]byte, 32), make([]byte, 32)
+	a.key.X.FillBytes(x)
+	a.key.Y.FillBytes(y)
+	publicKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{KeyType: int64(webauthncose.EllipticKey), Algorithm: int64(webauthncose.AlgES256)},
+		Curve:         1,
+		XCoord:        x,
+		YCoord:        y,
+	})
+	require.NoError(a.t, err)
+
+	// a zero AAGUID, then the credential id and its public key
+	attested := make([]byte, 16)
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.id)))
+	attested = append(attested, a.id...)
+	attested = append(attested, publicKey...)
+
+	// user present, attested credential data included
+	attestation, err := webauthncbor.Marshal(map[string]any{"fmt": "none", "attStmt": map[string]any{}, "authData": a.authData(0x41, attested)})
+	require.NoError(a.t, err)
+
+	return a.credentialJSON(map[string]string{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(a.clientData("webauthn.create", challenge)),
+		"attestationObject": base64.RawURLEncoding.EncodeToString(attestation),
+	})
+}
+
+// get returns the JSON encoded PublicKeyCredential of navigator.credentials.get()
+func (a *fakeAuthenticator) get(challenge string) string {
+	flags := byte(0x05)
+	if a.unverified {
+		flags = 0x01
+	}
+	authData := a.authData(flags, nil)
+	clientData := a.clientData("webauthn.get", challenge)
+	clientDataHash := sha256.Sum256(clientData)
+
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(a.t, err)
+
+	return a.credentialJSON(map[string]string{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientData),
+		"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+		"signature":         base64.RawURLEncoding.EncodeToString(signature),
+	})
+}
+
+func (a *fakeAuthenticator) credentialJSON(response map[string]string) string {
+	id := base64.RawURLEncoding.EncodeToString(a.id)
+	data, err := json.Marshal(map[string]any{"id": id, "rawId": id, "type": "public-key", "response": response})
+	require.NoError(a.t, err)
+	return string(data)
+}
+
+func TestWebauthnRegister(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""), "user.webauthn.session.invalid", "user.webauthn.register.success")
+	defer th.TearDown()
+
+	user := th.Customer1.User
+	ctx := th.withUser(t, th.Customer1, "")
+	authenticator := newFakeAuthenticator(t, th.srvCfg.WebAuthn.RPID, th.srvCfg.WebAuthn.RPOrigins[0])
+
+	th.store.On("UsersGetByID", mock.Anything, user.GetId()).Return(user, nil)
+	th.store.On("WebauthnCredentialsGetByUserID", mock.Anything, user.GetId()).Return([]*intModels.WebauthnCredential{}, nil)
+
+	var session *intModels.WebauthnSession
+	th.store.On("WebauthnSessionsAdd", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		session = args.Get(1).(*intModels.WebauthnSession)
+	}).Return(nil).Once()
+
+	res, err := th.controller.WebauthnRegisterBegin(ctx, &pbAcc.WebauthnRegisterBeginRequest{})
+	require.NoError(t, err)
+	require.Nil(t, res.GetError())
+	require.Equal(t, intModels.WebauthnSessionTypeRegistration, session.Type)
+	require.Equal(t, session.ID, res.GetData().GetMetadata()["session_id"])
+
+	credential := authenticator.create(session.Data.Challenge)
+	req := &pbAcc.WebauthnRegisterFinishRequest{SessionId: session.ID, Name: "laptop", Credential: credential}
+
+	t.Run("the attestation is verified and the passkey stored", func(t *testing.T) {
+		th.store.On("WebauthnSessionsTake", mock.Anything, session.ID).Return(session, nil).Once()
+		var stored *intModels.WebauthnCredential
+		th.store.On("WebauthnCredentialsAdd", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*intModels.WebauthnCredential)
+		}).Return(nil).Once()
+
+		res, err := th.controller.WebauthnRegisterFinish(ctx, req)
+		require.NoError(t, err)
+		require.Nil(t, res.GetError())
+		require.Equal(t, intModels.WebauthnCredentialID(authenticator.id), stored.ID)
+		require.Equal(t, user.GetId(), stored.UserID)
+		require.Equal(t, "laptop", stored.Name)
+	})
+
+	t.Run("a consumed session can't be reused", func(t *testing.T) {
+		th.store.On("WebauthnSessionsTake", mock.Anything, session.ID).Return(nil, &models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+
+		res, err := th.controller.WebauthnRegisterFinish(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "user.webauthn.session.invalid", res.GetError().GetId())
+	})
+
+	t.Run("a login session can't finish a registration", func(t *testing.T) {
+		login := *session
+		login.Type = intModels.WebauthnSessionTypeLogin
+		th.store.On("WebauthnSessionsTake", mock.Anything, session.ID).Return(&login, nil).Once()
+
+		res, err := th.controller.WebauthnRegisterFinish(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "user.webauthn.session.invalid", res.GetError().GetId())
+	})
+}
+
+func TestWebauthnLogin(t *testing.T) {
+	hydra := newFakeHydra(t, "http://hydra.local/done")
+	th := NewOfflineTestHelper(t, testConfig(hydra.URL),
+		"user.webauthn.session.invalid",
+		"user.webauthn.clone_warning.error",
+		"user.login.email_not_verified.error",
+		"user.login.email_not_verified.resend_hint",
+		"user.captcha.required",
+		"user.login.locked.error",
+		"user.webauthn.credential.invalid",
+	)
+	defer th.TearDown()
+	th.srvCfg.Auth.NewDeviceAlerts = false
+	th.srvCfg.Auth.PrivacyMode = false
+
+	user := th.Customer1.User
+	ctx := context.WithValue(context.Background(), models.ContextKeyMetadata, th.Customer1.Ctx)
+	authenticator := newFakeAuthenticator(t, th.srvCfg.WebAuthn.RPID, th.srvCfg.WebAuthn.RPOrigins[0])
+
+	// the passkey registered by the attestation of the authenticator
+	waUser := &intModels.WebauthnUser{User: user}
+	_, registration, waErr := th.controller.webauthn.BeginRegistration(waUser)
+	require.NoError(t, waErr)
+	parsed, waErr := protocol.ParseCredentialCreationResponseBody(strings.NewReader(authenticator.create(registration.Challenge)))
+	require.NoError(t, waErr)
+	cred, waErr := th.controller.webauthn.CreateCredential(waUser, *registration, parsed)
+	require.NoError(t, waErr)
+	stored := &intModels.WebauthnCredential{ID: intModels.WebauthnCredentialID(cred.ID), UserID: user.GetId(), Name: "laptop", Credential: *cred}
+
+	th.store.On("UsersGetByEmail", mock.Anything, user.GetEmail()).Return(user, nil)
+	th.store.On("UsersGetByID", mock.Anything, user.GetId()).Return(user, nil)
+	th.store.On("WebauthnCredentialsGetByUserID", mock.Anything, user.GetId()).Return([]*intModels.WebauthnCredential{stored}, nil)
+	var lockedUntil int64
+	th.store.EXPECT().UsersGetLockedUntil(mock.Anything, user.GetId()).RunAndReturn(func(*models.Context, string) (int64, *models.DBError) {
+		return lockedUntil, nil
+	}).Maybe()
+
+	begin := func(t *testing.T) *intModels.WebauthnSession {
+		var session *intModels.WebauthnSession
+		th.store.On("WebauthnSessionsAdd", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			session = args.Get(1).(*intModels.WebauthnSession)
+		}).Return(nil).Once()
+
+		res, err := th.controller.WebauthnLoginBegin(ctx, &pbAcc.WebauthnLoginBeginRequest{Email: user.GetEmail()})
+		require.NoError(t, err)
+		require.Nil(t, res.GetError())
+		require.Equal(t, intModels.WebauthnSessionTypeLogin, session.Type)
+		return session
+	}
+	finish := func(t *testing.T, session *intModels.WebauthnSession) *pbAcc.WebauthnLoginFinishResponse {
+		th.store.On("WebauthnSessionsTake", mock.Anything, session.ID).Return(session, nil).Once()
+		req := &pbAcc.WebauthnLoginFinishRequest{SessionId: session.ID, Credential: authenticator.get(session.Data.Challenge), LoginChallenge: "fake-challenge"}
+
+		res, err := th.controller.WebauthnLoginFinish(ctx, req)
+		require.NoError(t, err)
+		return res
+	}
+
+	t.Run("the assertion is verified and the login accepted", func(t *testing.T) {
+		session := begin(t)
+		authenticator.signCount = 5
+		th.store.On("WebauthnCredentialsUpdateUsage", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			require.Equal(t, uint32(5), args.Get(1).(*intModels.WebauthnCredential).Credential.Authenticator.SignCount)
+		}).Return(nil).Once()
+		th.store.On("UsersLoginSucceeded", mock.Anything, user.GetId()).Return(nil).Once()
+		th.store.On("UserSessionsAdd", mock.Anything, mock.Anything).Return(nil).Once()
+		th.store.On("UserLoginDevicesAdd", mock.Anything, mock.Anything).Return(&intModels.LoginDeviceHistory{}, nil).Once()
+
+		res := finish(t, session)
+		require.Nil(t, res.GetError())
+		require.Equal(t, "http://hydra.local/done", res.GetData().GetMetadata()["redirect_to"])
+		stored.Credential.Authenticator.SignCount = 5
+	})
+
+	t.Run("a consumed session can't be reused", func(t *testing.T) {
+		session := begin(t)
+		th.store.On("WebauthnSessionsTake", mock.Anything, session.ID).Return(nil, &models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+
+		req := &pbAcc.WebauthnLoginFinishRequest{SessionId: session.ID, Credential: authenticator.get(session.Data.Challenge), LoginChallenge: "fake-challenge"}
+		res, err := th.controller.WebauthnLoginFinish(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, "user.webauthn.session.invalid", res.GetError().GetId())
+	})
+
+	t.Run("a sign count that didn't increase is refused", func(t *testing.T) {
+		session := begin(t)
+		authenticator.signCount = 3
+
+		res := finish(t, session)
+		require.Equal(t, "user.webauthn.clone_warning.error", res.GetError().GetId())
+	})
+
+	t.Run("an unverified email is refused", func(t *testing.T) {
+		session := begin(t)
+		authenticator.signCount = 10
+		user.IsEmailVerified = utils.NewPointer(false)
+		defer func() { user.IsEmailVerified = utils.NewPointer(true) }()
+		th.store.On("WebauthnCredentialsUpdateUsage", mock.Anything, mock.Anything).Return(nil).Once()
+
+		res := finish(t, session)
+		require.Equal(t, "user.login.email_not_verified.error", res.GetError().GetId())
+	})
+
+	t.Run("a locked account is refused", func(t *testing.T) {
+		session := begin(t)
+		lockedUntil = time.Now().Add(time.Hour).UnixMilli()
+		defer func() { lockedUntil = 0 }()
+
+		res := finish(t, session)
+		require.Equal(t, "user.login.locked.error", res.GetError().GetId())
+	})
+
+	t.Run("an assertion without the user verification is refused", func(t *testing.T) {
+		session := begin(t)
+		authenticator.signCount = 8
+		authenticator.unverified = true
+		defer func() { authenticator.unverified = false }()
+
+		res := finish(t, session)
+		require.Equal(t, "user.webauthn.credential.invalid", res.GetError().GetId())
+	})
+
+	t.Run("a challenge is required after repeated failed logins", func(t *testing.T) {
+		session := begin(t)
+		th.controller.captcha = &captcha.StubVerifier{Token: "pass"}
+		th.srvCfg.Captcha.Methods = []string{"login"}
+		user.FailedAttempts = utils.NewPointer(th.srvCfg.Captcha.LoginFailedAttempts)
+		defer func() {
+			th.controller.captcha = nil
+			user.FailedAttempts = utils.NewPointer(int32(0))
+		}()
+
+		res := finish(t, session)
+		require.Equal(t, "user.captcha.required", res.GetError().GetId())
+
+		// the same ceremony goes on with a solved challenge
+		md := metadata.Pairs(intModels.CaptchaTokenHeader, "pass")
+		authenticator.signCount = 11
+		th.store.On("WebauthnSessionsTake", mock.Anything, session.ID).Return(session, nil).Once()
+		th.store.On("WebauthnCredentialsUpdateUsage", mock.Anything, mock.Anything).Return(nil).Once()
+		th.store.On("UsersLoginSucceeded", mock.Anything, user.GetId()).Return(nil).Once()
+		th.store.On("UserSessionsAdd", mock.Anything, mock.Anything).Return(nil).Once()
+		th.store.On("UserLoginDevicesAdd", mock.Anything, mock.Anything).Return(&intModels.LoginDeviceHistory{}, nil).Once()
+
+		req := &pbAcc.WebauthnLoginFinishRequest{SessionId: session.ID, Credential: authenticator.get(session.Data.Challenge), LoginChallenge: "fake-challenge"}
+		res, err := th.controller.WebauthnLoginFinish(metadata.NewIncomingContext(ctx, md), req)
+		require.NoError(t, err)
+		require.Nil(t, res.GetError())
+	})
+}
+
+func TestWebauthnLoginBeginPrivacyMode(t *testing.T) {
+	th := NewOfflineTestHelper(t, testConfig(""), "user.webauthn.credential.invalid", "user.webauthn.session.invalid")
+	defer th.TearDown()
+	th.srvCfg.Auth.PrivacyMode = true
+	ctx := context.WithValue(context.Background(), models.ContextKeyMetadata, th.Customer1.Ctx)
+
+	th.store.On("UsersGetByEmail", mock.Anything, "unknown@example.com").Return(nil, &models.DBError{ErrType: models.DBErrorTypeNoRows})
+	begin := func(t *testing.T) map[string]any {
+		t.Helper()
+		res, err := th.controller.WebauthnLoginBegin(ctx, &pbAcc.WebauthnLoginBeginRequest{Email: "unknown@example.com"})
+		require.NoError(t, err)
+		require.Nil(t, res.GetError())
+		require.NotEmpty(t, res.GetData().GetMetadata()["session_id"])
+
+		var options map[string]any
+		require.NoError(t, json.Unmarshal([]byte(res.GetData().GetMetadata()["options"]), &options))
+		return options["publicKey"].(map[string]any)
+	}
+
+	first, second := begin(t), begin(t)
+	require.Len(t, first["allowCredentials"], 1)
+	require.Equal(t, first["allowCredentials"], second["allowCredentials"], "an unknown email is offered the same credential every time")
+	require.Equal(t, "required", first["userVerification"])
+	th.store.AssertNotCalled(t, "WebauthnSessionsAdd", mock.Anything, mock.Anything)
+
+	// the finish step of the fake session fails like a wrong passkey
+	sessionID := utils.NewID()
+	th.store.On("WebauthnSessionsTake", mock.Anything, sessionID).Return(nil, &models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+	res, err := th.controller.WebauthnLoginFinish(ctx, &pbAcc.WebauthnLoginFinishRequest{SessionId: sessionID, Credential: "{}", LoginChallenge: "fake-challenge"})
+	require.NoError(t, err)
+	require.Equal(t, "user.webauthn.credential.invalid", res.GetError().GetId())
+}
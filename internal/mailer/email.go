@@ -65,3 +65,185 @@ func (m *Mailer) SendPasswordResetEmail(lang, email, token, tokenID string, hour
 
 	return m.send(&mailData{to: email, subject: title, body: body})
 }
+
+func (m *Mailer) SendAccountLockedEmail(lang, email string, attempts, minutes int) error {
+	td, err := m.NewTemplateData(lang)
+	if err != nil {
+		return err
+	}
+
+	title := models.Tr(lang, "templates.account_locked.title", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	welcome := models.Tr(lang, "templates.welcome", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	locked := models.Tr(lang, "templates.account_locked.part1", map[string]any{"Attempts": attempts, "Minutes": minutes})
+	notYou := models.Tr(lang, "templates.account_locked.part2", nil)
+	click := models.Tr(lang, "templates.click_on_link", nil)
+
+	td.Props["Title"] = title
+	td.Props["Welcome"] = welcome
+	td.Props["Locked"] = locked
+	td.Props["NotYou"] = notYou
+	td.Props["Click"] = click
+	td.Props["Url"] = m.config().GetSupport().GetForgotPasswordLink()
+
+	body, err := m.templateContainer.RenderToString("account_locked_email", td)
+	if err != nil {
+		return err
+	}
+
+	return m.send(&mailData{to: email, subject: title, body: body})
+}
+
+func (m *Mailer) SendMfaRecoveryCodeUsedEmail(lang, email string, remaining int) error {
+	td, err := m.NewTemplateData(lang)
+	if err != nil {
+		return err
+	}
+
+	title := models.Tr(lang, "templates.mfa_recovery_code_used.title", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	welcome := models.Tr(lang, "templates.welcome", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	used := models.Tr(lang, "templates.mfa_recovery_code_used.part1", map[string]any{"Remaining": remaining})
+	notYou := models.Tr(lang, "templates.mfa_recovery_code_used.part2", nil)
+	click := models.Tr(lang, "templates.click_on_link", nil)
+
+	td.Props["Title"] = title
+	td.Props["Welcome"] = welcome
+	td.Props["Used"] = used
+	td.Props["NotYou"] = notYou
+	td.Props["Click"] = click
+	td.Props["Url"] = m.config().GetSupport().GetForgotPasswordLink()
+
+	body, err := m.templateContainer.RenderToString("mfa_recovery_code_used_email", td)
+	if err != nil {
+		return err
+	}
+
+	return m.send(&mailData{to: email, subject: title, body: body})
+}
+
+func (m *Mailer) SendPasswordChangedEmail(lang, email string) error {
+	td, err := m.NewTemplateData(lang)
+	if err != nil {
+		return err
+	}
+
+	title := models.Tr(lang, "templates.password_changed.title", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	welcome := models.Tr(lang, "templates.welcome", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	changed := models.Tr(lang, "templates.password_changed.part1", nil)
+	notYou := models.Tr(lang, "templates.password_changed.part2", nil)
+	click := models.Tr(lang, "templates.click_on_link", nil)
+
+	td.Props["Title"] = title
+	td.Props["Welcome"] = welcome
+	td.Props["Changed"] = changed
+	td.Props["NotYou"] = notYou
+	td.Props["Click"] = click
+	td.Props["Url"] = m.config().GetSupport().GetForgotPasswordLink()
+
+	body, err := m.templateContainer.RenderToString("password_changed_email", td)
+	if err != nil {
+		return err
+	}
+
+	return m.send(&mailData{to: email, subject: title, body: body})
+}
+
+// SendEmailLoginEmail sends the passwordless login secret, either the code or the magic link url
+func (m *Mailer) SendEmailLoginEmail(lang, email, code, url string, minutes int) error {
+	td, err := m.NewTemplateData(lang)
+	if err != nil {
+		return err
+	}
+
+	title := models.Tr(lang, "templates.email_login.title", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	welcome := models.Tr(lang, "templates.welcome", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	note := models.Tr(lang, "templates.email_login.part3", map[string]any{"Minutes": minutes})
+	notYou := models.Tr(lang, "templates.email_login.part4", nil)
+
+	td.Props["Title"] = title
+	td.Props["Welcome"] = welcome
+	td.Props["Note"] = note
+	td.Props["NotYou"] = notYou
+	if code != "" {
+		td.Props["Received"] = models.Tr(lang, "templates.email_login.part1", nil)
+		td.Props["Code"] = code
+	} else {
+		td.Props["Received"] = models.Tr(lang, "templates.email_login.part2", nil)
+		td.Props["Click"] = models.Tr(lang, "templates.click_on_link", nil)
+		td.Props["Url"] = url
+	}
+
+	body, err := m.templateContainer.RenderToString("email_login_email", td)
+	if err != nil {
+		return err
+	}
+
+	return m.send(&mailData{to: email, subject: title, body: body})
+}
+
+// SendPasswordForgotNoticeEmail tells the receiver that a password reset was requested for an
+// address that has no password, either it isn't registered, or it signs in via authService
+func (m *Mailer) SendPasswordForgotNoticeEmail(lang, email, authService string) error {
+	td, err := m.NewTemplateData(lang)
+	if err != nil {
+		return err
+	}
+
+	title := models.Tr(lang, "templates.reset_password.title", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	welcome := models.Tr(lang, "templates.welcome", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	received := models.Tr(lang, "templates.password_forgot_notice.part1", nil)
+	notYou := models.Tr(lang, "templates.password_forgot_notice.part4", nil)
+
+	var notice string
+	if authService != "" {
+		notice = models.Tr(lang, "templates.password_forgot_notice.part2", map[string]any{"AuthService": authService})
+	} else {
+		notice = models.Tr(lang, "templates.password_forgot_notice.part3", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	}
+
+	td.Props["Title"] = title
+	td.Props["Welcome"] = welcome
+	td.Props["Received"] = received
+	td.Props["Notice"] = notice
+	td.Props["NotYou"] = notYou
+
+	body, err := m.templateContainer.RenderToString("password_forgot_notice_email", td)
+	if err != nil {
+		return err
+	}
+
+	return m.send(&mailData{to: email, subject: title, body: body})
+}
+
+// SendNewDeviceLoginEmail tells the user about a sign-in from a device or a network that
+// wasn't seen before, the url ("this wasn't me") logs every device out and starts a password reset
+func (m *Mailer) SendNewDeviceLoginEmail(lang, email, device, ipAddress, at, url string) error {
+	td, err := m.NewTemplateData(lang)
+	if err != nil {
+		return err
+	}
+
+	title := models.Tr(lang, "templates.new_device_login.title", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	welcome := models.Tr(lang, "templates.welcome", map[string]any{"SiteName": m.config().GetMain().GetSiteName()})
+	signedIn := models.Tr(lang, "templates.new_device_login.part1", nil)
+	notYou := models.Tr(lang, "templates.new_device_login.part2", nil)
+
+	td.Props["Title"] = title
+	td.Props["Welcome"] = welcome
+	td.Props["SignedIn"] = signedIn
+	td.Props["DeviceLabel"] = models.Tr(lang, "templates.new_device_login.device", nil)
+	td.Props["Device"] = device
+	td.Props["IPAddressLabel"] = models.Tr(lang, "templates.new_device_login.ip_address", nil)
+	td.Props["IPAddress"] = ipAddress
+	td.Props["TimeLabel"] = models.Tr(lang, "templates.new_device_login.time", nil)
+	td.Props["Time"] = at
+	td.Props["NotYou"] = notYou
+	td.Props["Click"] = models.Tr(lang, "templates.new_device_login.not_me", nil)
+	td.Props["Url"] = url
+
+	body, err := m.templateContainer.RenderToString("new_device_login_email", td)
+	if err != nil {
+		return err
+	}
+
+	return m.send(&mailData{to: email, subject: title, body: body})
+}
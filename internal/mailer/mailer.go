@@ -145,5 +145,11 @@ type MailerService interface {
 	GetPerHourEmailRateLimiter() *throttled.GCRARateLimiterCtx
 	SendVerifyEmail(lang, email, token, tokenID string, hours int) error
 	SendPasswordResetEmail(lang, email, token, tokenID string, hours int) error
+	SendAccountLockedEmail(lang, email string, attempts, minutes int) error
+	SendMfaRecoveryCodeUsedEmail(lang, email string, remaining int) error
+	SendPasswordChangedEmail(lang, email string) error
+	SendEmailLoginEmail(lang, email, code, url string, minutes int) error
+	SendPasswordForgotNoticeEmail(lang, email, authService string) error
+	SendNewDeviceLoginEmail(lang, email, device, ipAddress, at, url string) error
 	InitEmailBatching()
 }
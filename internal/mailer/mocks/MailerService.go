@@ -161,6 +161,414 @@ func (_c *MockMailerService_InitEmailBatching_Call) RunAndReturn(run func()) *Mo
 	return _c
 }
 
+// SendAccountLockedEmail provides a mock function for the type MockMailerService
+func (_mock *MockMailerService) SendAccountLockedEmail(lang string, email string, attempts int, minutes int) error {
+	ret := _mock.Called(lang, email, attempts, minutes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendAccountLockedEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, int, int) error); ok {
+		r0 = returnFunc(lang, email, attempts, minutes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMailerService_SendAccountLockedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendAccountLockedEmail'
+type MockMailerService_SendAccountLockedEmail_Call struct {
+	*mock.Call
+}
+
+// SendAccountLockedEmail is a helper method to define mock.On call
+//   - lang string
+//   - email string
+//   - attempts int
+//   - minutes int
+func (_e *MockMailerService_Expecter) SendAccountLockedEmail(lang interface{}, email interface{}, attempts interface{}, minutes interface{}) *MockMailerService_SendAccountLockedEmail_Call {
+	return &MockMailerService_SendAccountLockedEmail_Call{Call: _e.mock.On("SendAccountLockedEmail", lang, email, attempts, minutes)}
+}
+
+func (_c *MockMailerService_SendAccountLockedEmail_Call) Run(run func(lang string, email string, attempts int, minutes int)) *MockMailerService_SendAccountLockedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMailerService_SendAccountLockedEmail_Call) Return(err error) *MockMailerService_SendAccountLockedEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMailerService_SendAccountLockedEmail_Call) RunAndReturn(run func(lang string, email string, attempts int, minutes int) error) *MockMailerService_SendAccountLockedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendEmailLoginEmail provides a mock function for the type MockMailerService
+func (_mock *MockMailerService) SendEmailLoginEmail(lang string, email string, code string, url string, minutes int) error {
+	ret := _mock.Called(lang, email, code, url, minutes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendEmailLoginEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string, int) error); ok {
+		r0 = returnFunc(lang, email, code, url, minutes)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMailerService_SendEmailLoginEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendEmailLoginEmail'
+type MockMailerService_SendEmailLoginEmail_Call struct {
+	*mock.Call
+}
+
+// SendEmailLoginEmail is a helper method to define mock.On call
+//   - lang string
+//   - email string
+//   - code string
+//   - url string
+//   - minutes int
+func (_e *MockMailerService_Expecter) SendEmailLoginEmail(lang interface{}, email interface{}, code interface{}, url interface{}, minutes interface{}) *MockMailerService_SendEmailLoginEmail_Call {
+	return &MockMailerService_SendEmailLoginEmail_Call{Call: _e.mock.On("SendEmailLoginEmail", lang, email, code, url, minutes)}
+}
+
+func (_c *MockMailerService_SendEmailLoginEmail_Call) Run(run func(lang string, email string, code string, url string, minutes int)) *MockMailerService_SendEmailLoginEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMailerService_SendEmailLoginEmail_Call) Return(err error) *MockMailerService_SendEmailLoginEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMailerService_SendEmailLoginEmail_Call) RunAndReturn(run func(lang string, email string, code string, url string, minutes int) error) *MockMailerService_SendEmailLoginEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMfaRecoveryCodeUsedEmail provides a mock function for the type MockMailerService
+func (_mock *MockMailerService) SendMfaRecoveryCodeUsedEmail(lang string, email string, remaining int) error {
+	ret := _mock.Called(lang, email, remaining)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMfaRecoveryCodeUsedEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, int) error); ok {
+		r0 = returnFunc(lang, email, remaining)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMailerService_SendMfaRecoveryCodeUsedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMfaRecoveryCodeUsedEmail'
+type MockMailerService_SendMfaRecoveryCodeUsedEmail_Call struct {
+	*mock.Call
+}
+
+// SendMfaRecoveryCodeUsedEmail is a helper method to define mock.On call
+//   - lang string
+//   - email string
+//   - remaining int
+func (_e *MockMailerService_Expecter) SendMfaRecoveryCodeUsedEmail(lang interface{}, email interface{}, remaining interface{}) *MockMailerService_SendMfaRecoveryCodeUsedEmail_Call {
+	return &MockMailerService_SendMfaRecoveryCodeUsedEmail_Call{Call: _e.mock.On("SendMfaRecoveryCodeUsedEmail", lang, email, remaining)}
+}
+
+func (_c *MockMailerService_SendMfaRecoveryCodeUsedEmail_Call) Run(run func(lang string, email string, remaining int)) *MockMailerService_SendMfaRecoveryCodeUsedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMailerService_SendMfaRecoveryCodeUsedEmail_Call) Return(err error) *MockMailerService_SendMfaRecoveryCodeUsedEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMailerService_SendMfaRecoveryCodeUsedEmail_Call) RunAndReturn(run func(lang string, email string, remaining int) error) *MockMailerService_SendMfaRecoveryCodeUsedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendNewDeviceLoginEmail provides a mock function for the type MockMailerService
+func (_mock *MockMailerService) SendNewDeviceLoginEmail(lang string, email string, device string, ipAddress string, at string, url string) error {
+	ret := _mock.Called(lang, email, device, ipAddress, at, url)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendNewDeviceLoginEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string, string, string, string) error); ok {
+		r0 = returnFunc(lang, email, device, ipAddress, at, url)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMailerService_SendNewDeviceLoginEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendNewDeviceLoginEmail'
+type MockMailerService_SendNewDeviceLoginEmail_Call struct {
+	*mock.Call
+}
+
+// SendNewDeviceLoginEmail is a helper method to define mock.On call
+//   - lang string
+//   - email string
+//   - device string
+//   - ipAddress string
+//   - at string
+//   - url string
+func (_e *MockMailerService_Expecter) SendNewDeviceLoginEmail(lang interface{}, email interface{}, device interface{}, ipAddress interface{}, at interface{}, url interface{}) *MockMailerService_SendNewDeviceLoginEmail_Call {
+	return &MockMailerService_SendNewDeviceLoginEmail_Call{Call: _e.mock.On("SendNewDeviceLoginEmail", lang, email, device, ipAddress, at, url)}
+}
+
+func (_c *MockMailerService_SendNewDeviceLoginEmail_Call) Run(run func(lang string, email string, device string, ipAddress string, at string, url string)) *MockMailerService_SendNewDeviceLoginEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMailerService_SendNewDeviceLoginEmail_Call) Return(err error) *MockMailerService_SendNewDeviceLoginEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMailerService_SendNewDeviceLoginEmail_Call) RunAndReturn(run func(lang string, email string, device string, ipAddress string, at string, url string) error) *MockMailerService_SendNewDeviceLoginEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendPasswordChangedEmail provides a mock function for the type MockMailerService
+func (_mock *MockMailerService) SendPasswordChangedEmail(lang string, email string) error {
+	ret := _mock.Called(lang, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendPasswordChangedEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = returnFunc(lang, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMailerService_SendPasswordChangedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendPasswordChangedEmail'
+type MockMailerService_SendPasswordChangedEmail_Call struct {
+	*mock.Call
+}
+
+// SendPasswordChangedEmail is a helper method to define mock.On call
+//   - lang string
+//   - email string
+func (_e *MockMailerService_Expecter) SendPasswordChangedEmail(lang interface{}, email interface{}) *MockMailerService_SendPasswordChangedEmail_Call {
+	return &MockMailerService_SendPasswordChangedEmail_Call{Call: _e.mock.On("SendPasswordChangedEmail", lang, email)}
+}
+
+func (_c *MockMailerService_SendPasswordChangedEmail_Call) Run(run func(lang string, email string)) *MockMailerService_SendPasswordChangedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMailerService_SendPasswordChangedEmail_Call) Return(err error) *MockMailerService_SendPasswordChangedEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMailerService_SendPasswordChangedEmail_Call) RunAndReturn(run func(lang string, email string) error) *MockMailerService_SendPasswordChangedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendPasswordForgotNoticeEmail provides a mock function for the type MockMailerService
+func (_mock *MockMailerService) SendPasswordForgotNoticeEmail(lang string, email string, authService string) error {
+	ret := _mock.Called(lang, email, authService)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendPasswordForgotNoticeEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = returnFunc(lang, email, authService)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMailerService_SendPasswordForgotNoticeEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendPasswordForgotNoticeEmail'
+type MockMailerService_SendPasswordForgotNoticeEmail_Call struct {
+	*mock.Call
+}
+
+// SendPasswordForgotNoticeEmail is a helper method to define mock.On call
+//   - lang string
+//   - email string
+//   - authService string
+func (_e *MockMailerService_Expecter) SendPasswordForgotNoticeEmail(lang interface{}, email interface{}, authService interface{}) *MockMailerService_SendPasswordForgotNoticeEmail_Call {
+	return &MockMailerService_SendPasswordForgotNoticeEmail_Call{Call: _e.mock.On("SendPasswordForgotNoticeEmail", lang, email, authService)}
+}
+
+func (_c *MockMailerService_SendPasswordForgotNoticeEmail_Call) Run(run func(lang string, email string, authService string)) *MockMailerService_SendPasswordForgotNoticeEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMailerService_SendPasswordForgotNoticeEmail_Call) Return(err error) *MockMailerService_SendPasswordForgotNoticeEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMailerService_SendPasswordForgotNoticeEmail_Call) RunAndReturn(run func(lang string, email string, authService string) error) *MockMailerService_SendPasswordForgotNoticeEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendPasswordResetEmail provides a mock function for the type MockMailerService
 func (_mock *MockMailerService) SendPasswordResetEmail(lang string, email string, token string, tokenID string, hours int) error {
 	ret := _mock.Called(lang, email, token, tokenID, hours)
@@ -7,20 +7,19 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
 )
 
-type ConsentRequest struct {
-	Subject                      string         `json:"subject"`
-	RequestedScope               []string       `json:"requested_scope"`
-	RequestedAccessTokenAudience []string       `json:"requested_access_token_audience"`
-	Context                      map[string]any `json:"context"`
-}
-
-// Consent TODO: track the error, and track metrics
+// Consent grants the requested scopes right away for the trusted (first party) clients, and
+// the grants that the user already remembered (skip is set), the other clients are sent to
+// the frontend consent page, which accepts or rejects via the ConsentAccept/ConsentReject RPCs
+//
+// TODO: track the error, and track metrics
 func (oa *OAuth) Consent(w http.ResponseWriter, r *http.Request) {
 	lang := oa.config().GetLocalization().GetDefaultClientLocale()
 	config := oa.config().Oauth
@@ -61,36 +60,40 @@ func (oa *OAuth) Consent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var consentRequest ConsentRequest
+	var consentRequest intModels.OAuthConsentRequest
 	if err = json.NewDecoder(resp.Body).Decode(&consentRequest); err != nil {
 		returnErr(err, "failed to unmarshall oauth/consent response", "oauth.server_error.internal")
 		return
 	}
 
-	email := ""
-	firstName := ""
-	if consentRequest.Context != nil {
-		if v, ok := consentRequest.Context["lang"].(string); ok && v != "" {
-			lang = v
-		}
-		if v, ok := consentRequest.Context["email"].(string); ok && v != "" {
-			email = v
-		}
-		if v, ok := consentRequest.Context["first_name"].(string); ok && v != "" {
-			firstName = v
-		}
+	trusted := slices.Contains(oa.srvCfg.OAuth.TrustedClients, consentRequest.Client.ClientID)
+	if !consentRequest.Skip && !trusted {
+		u := fmt.Sprintf("%s?consent_challenge=%s", oa.srvCfg.OAuth.FrontendConsentURL, url.QueryEscape(challenge))
+		http.Redirect(w, r, u, http.StatusFound)
+		return
+	}
+
+	if v, ok := consentRequest.Context["lang"].(string); ok && v != "" {
+		lang = v
+	}
+
+	// the claims are loaded fresh, the skipped consents reuse a login that could be old
+	user, dbErr := oa.store.UsersGetByID(&models.Context{Context: ctx, AcceptLanguage: lang}, consentRequest.Subject)
+	if dbErr != nil {
+		returnErr(dbErr, "failed to get the user of the consent request", "oauth.server_error.internal")
+		return
 	}
 
-	expiry := oa.config().Security.GetAccessTokenExpiryWebInHours()
+	// the consent is remembered only when the login was, for the lifetime of its platform
+	security := oa.config().Security
+	session := intModels.LoginSessionOptionsFromContext(consentRequest.Context)
 	audience := config.GetOauthGrantAccessTokenAudience()
 	acceptBody := map[string]any{
 		"grant_scope":                 consentRequest.RequestedScope,
 		"grant_access_token_audience": audience,
-		"remember":                    true,
-		"remember_for":                expiry * 60 * 60,
-		"session": map[string]any{
-			"id_token": map[string]any{"email": email, "first_name": firstName},
-		},
+		"remember":                    session.Remember,
+		"remember_for":                session.RememberFor(security.GetAccessTokenExpiryWebInHours(), security.GetAccessTokenExpiryMobileInHours()),
+		"session":                     intModels.OAuthConsentSession(user, consentRequest.RequestedScope),
 	}
 
 	oauthPayload, err := json.Marshal(acceptBody)
@@ -1,18 +1,119 @@
 package oauth
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
 )
 
-// Login redirect to frontend login page with login_challenge
+// Login redirect to frontend login page with login_challenge, unless Hydra already
+// authenticated the subject (skip is set), then the login is accepted right away
 func (oa *OAuth) Login(w http.ResponseWriter, r *http.Request) {
+	lang := oa.config().GetLocalization().GetDefaultClientLocale()
+	config := oa.config().Oauth
 	challenge := r.URL.Query().Get("login_challenge")
 	if challenge == "" {
 		http.Error(w, "The login challenge token is missing from request", http.StatusBadRequest)
 		return
 	}
 
-	url := oa.config().GetOauth().GetFrontendLoginUrl()
-	http.Redirect(w, r, fmt.Sprintf("%s?login_challenge=%s", url, challenge), http.StatusFound)
+	returnErr := func(err error, errDetails, msgID string) {
+		oa.log.ErrorStruct(errDetails, err)
+		msg := models.Tr(lang, "An error occurred during authentication.", nil)
+		desc := models.Tr(lang, msgID, nil)
+		u := fmt.Sprintf("%s?error=%s&error_description=%s&translated=true", config.GetFrontendLoginErrorUrl(), url.QueryEscape(msg), url.QueryEscape(desc))
+		http.Redirect(w, r, u, http.StatusFound)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	escaped := url.QueryEscape(challenge)
+	loginURL := fmt.Sprintf("%s/oauth2/auth/requests/login?login_challenge=%s", config.GetOauthAdminUrl(), escaped)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, loginURL, nil)
+	if err != nil {
+		returnErr(err, "failed to create request oauth/login", "oauth.server_error.internal")
+		return
+	}
+
+	resp, err := utils.HTTPRequestWithRetry(oa.httpClient, req, 3)
+	if err != nil {
+		returnErr(err, "failed to request oauth/login", "oauth.server_error.internal")
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		returnErr(fmt.Errorf("unexpected status code %d", resp.StatusCode), "failed to request oauth/login", "oauth.server_error.internal")
+		return
+	}
+
+	var loginRequest intModels.OAuthLoginRequest
+	if err := json.NewDecoder(resp.Body).Decode(&loginRequest); err != nil {
+		returnErr(err, "failed to unmarshall oauth/login response", "oauth.server_error.internal")
+		return
+	}
+
+	if !loginRequest.Skip {
+		url := config.GetFrontendLoginUrl()
+		http.Redirect(w, r, fmt.Sprintf("%s?login_challenge=%s", url, escaped), http.StatusFound)
+		return
+	}
+
+	// Hydra ignores remember on skipped logins, the existing session is kept as is
+	redirectTo, err := oa.loginAccept(ctx, challenge, map[string]any{"subject": loginRequest.Subject})
+	if err != nil {
+		returnErr(err, "failed to accept the skipped login", "oauth.unknown_error")
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusFound)
+}
+
+// loginAccept accepts the login request of the challenge with the given body, and returns
+// the url that Hydra wants the user agent to be redirected to
+func (oa *OAuth) loginAccept(ctx context.Context, challenge string, body map[string]any) (string, error) {
+	oauthPayload, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshall login/accept request: %w", err)
+	}
+
+	loginURL := fmt.Sprintf("%s/oauth2/auth/requests/login/accept?login_challenge=%s", oa.config().Oauth.GetOauthAdminUrl(), url.QueryEscape(challenge))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, loginURL, bytes.NewReader(oauthPayload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create login/accept request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := utils.HTTPRequestWithRetry(oa.httpClient, req, 3)
+	if err != nil {
+		oa.log.Errorf("HTTP %s %s failed: %v (took %s)", req.Method, req.URL, err, time.Since(start))
+		return "", fmt.Errorf("failed to request login/accept endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected login/accept status code %d", resp.StatusCode)
+	}
+
+	var result struct {
+		RedirectTo string `json:"redirect_to"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to unmarshall login/accept response: %w", err)
+	}
+	if result.RedirectTo == "" {
+		return "", fmt.Errorf("received an empty redirect URL from login/accept response")
+	}
+
+	return result.RedirectTo, nil
 }
@@ -0,0 +1,108 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+)
+
+type LogoutRequest struct {
+	Subject     string `json:"subject"`
+	SessionID   string `json:"sid"`
+	RpInitiated bool   `json:"rp_initiated"`
+}
+
+// Logout accepts the logout request identified by the logout_challenge, and redirects to
+// the url returned by Hydra (which clears the session, then redirects to the post logout
+// url), the configured frontend logout url is used if Hydra doesn't return one
+func (oa *OAuth) Logout(w http.ResponseWriter, r *http.Request) {
+	lang := oa.config().GetLocalization().GetDefaultClientLocale()
+	config := oa.config().Oauth
+	challenge := r.URL.Query().Get("logout_challenge")
+	frontendURL := oa.srvCfg.OAuth.FrontendLogoutURL
+
+	returnErr := func(err error, errDetails, msgID string) {
+		oa.log.ErrorStruct(errDetails, err)
+		msg := models.Tr(lang, "An error occurred during authentication.", nil)
+		desc := models.Tr(lang, msgID, nil)
+		u := fmt.Sprintf("%s?error=%s&error_description=%s&translated=true", frontendURL, url.QueryEscape(msg), url.QueryEscape(desc))
+		http.Redirect(w, r, u, http.StatusFound)
+	}
+
+	if challenge == "" {
+		returnErr(nil, "", "oauth.logout_challenge.missing")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	escaped := url.QueryEscape(challenge)
+	logoutURL := fmt.Sprintf("%s/oauth2/auth/requests/logout?logout_challenge=%s", config.GetOauthAdminUrl(), escaped)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, logoutURL, nil)
+	if err != nil {
+		returnErr(err, "failed to create request oauth/logout", "oauth.server_error.internal")
+		return
+	}
+
+	resp, err := utils.HTTPRequestWithRetry(oa.httpClient, req, 3)
+	if err != nil {
+		returnErr(err, "failed to request oauth/logout", "oauth.server_error.internal")
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		returnErr(fmt.Errorf("unexpected status code %d", resp.StatusCode), "failed to request oauth/logout", "oauth.logout_challenge.invalid")
+		return
+	}
+
+	var logoutRequest LogoutRequest
+	if err = json.NewDecoder(resp.Body).Decode(&logoutRequest); err != nil {
+		returnErr(err, "failed to unmarshall oauth/logout response", "oauth.server_error.internal")
+		return
+	}
+
+	logoutURL = fmt.Sprintf("%s/oauth2/auth/requests/logout/accept?logout_challenge=%s", config.GetOauthAdminUrl(), escaped)
+	req, err = http.NewRequestWithContext(ctx, http.MethodPut, logoutURL, nil)
+	if err != nil {
+		returnErr(err, "failed to create logout/accept request", "oauth.unknown_error")
+		return
+	}
+
+	start := time.Now()
+	resp, err = utils.HTTPRequestWithRetry(oa.httpClient, req, 3)
+	if err != nil {
+		oa.log.Errorf("HTTP %s %s failed: %v (took %s)", req.Method, req.URL, err, time.Since(start))
+		returnErr(err, "failed to request logout/accept endpoint", "oauth.unknown_error")
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		returnErr(fmt.Errorf("unexpected status code %d", resp.StatusCode), "failed to request logout/accept endpoint", "oauth.unknown_error")
+		return
+	}
+
+	var result struct {
+		RedirectTo string `json:"redirect_to"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		returnErr(err, "failed to unmarshall logout/accept response", "oauth.unknown_error")
+		return
+	}
+
+	oa.log.Infof("accepted the logout request of subject: %s", logoutRequest.Subject)
+	if result.RedirectTo == "" {
+		http.Redirect(w, r, frontendURL, http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, result.RedirectTo, http.StatusFound)
+}
@@ -0,0 +1,81 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const fakeFrontendLogoutURL = "http://localhost:3000/logout"
+
+// newFakeLogoutHydra serves the logout request of the "fake-challenge" and accepts it with
+// redirectTo, any other challenge is unknown to it
+func newFakeLogoutHydra(t *testing.T, redirectTo string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("logout_challenge") != "fake-challenge" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/oauth2/auth/requests/logout":
+			json.NewEncoder(w).Encode(map[string]any{"subject": "fake-subject-1", "sid": "fake-sid", "rp_initiated": true})
+		case r.Method == http.MethodPut && r.URL.Path == "/oauth2/auth/requests/logout/accept":
+			json.NewEncoder(w).Encode(map[string]string{"redirect_to": redirectTo})
+		default:
+			t.Errorf("unexpected hydra request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLogout(t *testing.T) {
+	oauthTestTranslationsInit(t, "oauth.logout_challenge.missing", "oauth.logout_challenge.invalid")
+
+	logout := func(t *testing.T, redirectTo, query string) *url.URL {
+		t.Helper()
+		hydra := newFakeLogoutHydra(t, redirectTo)
+		oa, _ := newSocialTestOAuth(t, "http://issuer.invalid", hydra.URL)
+		oa.srvCfg.OAuth.FrontendLogoutURL = fakeFrontendLogoutURL
+
+		rec := httptest.NewRecorder()
+		oa.routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logout"+query, nil))
+		require.Equal(t, http.StatusFound, rec.Code)
+
+		location, err := url.Parse(rec.Header().Get("Location"))
+		require.NoError(t, err)
+		return location
+	}
+
+	t.Run("the accepted logout redirects to hydra", func(t *testing.T) {
+		location := logout(t, "http://hydra.local/logged-out", "?logout_challenge=fake-challenge")
+		require.Equal(t, "http://hydra.local/logged-out", location.String())
+	})
+
+	t.Run("the frontend is used when hydra doesn't redirect", func(t *testing.T) {
+		location := logout(t, "", "?logout_challenge=fake-challenge")
+		require.Equal(t, fakeFrontendLogoutURL, location.String())
+	})
+
+	tests := map[string]struct {
+		query string
+		msgID string
+	}{
+		"a missing challenge":  {query: "", msgID: "oauth.logout_challenge.missing"},
+		"an unknown challenge": {query: "?logout_challenge=unknown", msgID: "oauth.logout_challenge.invalid"},
+	}
+	for name, tc := range tests {
+		t.Run(name+" redirects to the frontend with the error", func(t *testing.T) {
+			location := logout(t, "http://hydra.local/logged-out", tc.query)
+			require.Equal(t, fakeFrontendLogoutURL, location.Scheme+"://"+location.Host+location.Path)
+			require.Equal(t, tc.msgID, location.Query().Get("error_description"))
+			require.Equal(t, "true", location.Query().Get("translated"))
+		})
+	}
+}
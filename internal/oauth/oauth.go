@@ -2,36 +2,75 @@
 package oauth
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	common "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/common/v1"
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/logger"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/store"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
 )
 
+// LoginAcceptor accepts the OAuth login requests of the users signed in by the OAuth server
+// itself, the controller implements it so these logins are tracked like the grpc ones
+type LoginAcceptor interface {
+	SocialLoginAccept(ctx *models.Context, user *pb.User, challenge string, session intModels.LoginSessionOptions) (string, *models.AppError)
+}
+
 type OAuth struct {
-	config     func() *common.Config
-	log        *logger.Logger
-	errCh      chan *models.InternalError
-	server     *http.Server
-	httpClient *http.Client
+	config        func() *common.Config
+	srvCfg        *intModels.Config
+	store         store.UsersStore
+	loginAcceptor LoginAcceptor
+	log           *logger.Logger
+	errCh         chan *models.InternalError
+	server        *http.Server
+	httpClient    *http.Client
+	// socialProviders caches the upstream providers that passed the OIDC discovery
+	socialProviders map[string]*socialProvider
+	socialMux       sync.Mutex
+	// emailDomainPolicy returns the current email domains policy of the social signups
+	emailDomainPolicy func() *intModels.EmailDomainPolicy
 }
 
 type OAuthArgs struct {
 	Config func() *common.Config
-	Log    *logger.Logger
-	ErrCh  chan *models.InternalError
+	SrvCfg *intModels.Config
+	Store  store.UsersStore
+	// LoginAcceptor accepts the social logins
+	LoginAcceptor LoginAcceptor
+	Log           *logger.Logger
+	ErrCh         chan *models.InternalError
+	// EmailDomainPolicy returns the email domains policy of the signups, nil accepts any domain
+	EmailDomainPolicy func() *intModels.EmailDomainPolicy
 }
 
 func NewOauth(oa OAuthArgs) *OAuth {
 	if oa.ErrCh == nil {
 		oa.ErrCh = make(chan *models.InternalError, 10)
 	}
-	return &OAuth{config: oa.Config, log: oa.Log, errCh: oa.ErrCh, httpClient: utils.GetHTTPClient()}
+	if oa.EmailDomainPolicy == nil {
+		oa.EmailDomainPolicy = func() *intModels.EmailDomainPolicy { return nil }
+	}
+	return &OAuth{
+		config:            oa.Config,
+		srvCfg:            oa.SrvCfg,
+		store:             oa.Store,
+		loginAcceptor:     oa.LoginAcceptor,
+		log:               oa.Log,
+		errCh:             oa.ErrCh,
+		httpClient:        utils.GetHTTPClient(),
+		socialProviders:   map[string]*socialProvider{},
+		emailDomainPolicy: oa.EmailDomainPolicy,
+	}
 }
 
 func (oa *OAuth) Run() error {
@@ -73,3 +112,20 @@ func (oa *OAuth) Run() error {
 func (oa *OAuth) ErrorChannel() <-chan *models.InternalError {
 	return oa.errCh
 }
+
+// requestContext returns the context of the http request, with the client details that
+// the grpc requests carry in their metadata
+func requestContext(ctx context.Context, r *http.Request, lang string) *models.Context {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return &models.Context{
+		Context:        ctx,
+		AcceptLanguage: lang,
+		UserAgent:      r.UserAgent(),
+		IPAddress:      ip,
+		XForwardedFor:  r.Header.Get("X-Forwarded-For"),
+		Path:           r.URL.Path,
+	}
+}
@@ -52,6 +52,10 @@ func (oa *OAuth) routes() *chi.Mux {
 	mux.Get("/login", oa.Login)
 	mux.Get("/error", oa.Error)
 	mux.Get("/consent", oa.Consent)
+	mux.Get("/logout", oa.Logout)
+	mux.Get("/social/{provider}", oa.SocialStart)
+	mux.Get("/social/{provider}/callback", oa.SocialCallback)
+	mux.Post("/social/{provider}/callback", oa.SocialCallback)
 
 	return mux
 }
@@ -0,0 +1,444 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/oauth2"
+)
+
+var errSocialProviderUnknown = errors.New("the social provider is unknown or disabled")
+
+// socialProvider is a configured upstream provider, the OIDC ones verify the id token,
+// the plain OAuth2 ones read the identity from the userinfo endpoint
+type socialProvider struct {
+	name     string
+	cfg      intModels.SocialProvider
+	oauth2   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// SocialStart redirects the user to the upstream provider to sign in, the login_challenge
+// of the Hydra login request is kept along with the state and the PKCE verifier, the
+// optional platform and remember query params select the session lifetime after the callback
+//
+// a link_ticket (issued by the IdentityLinkBegin RPC) replaces the login_challenge when an
+// authenticated user links the provider account instead of signing in
+//
+// TODO: track metrics
+func (oa *OAuth) SocialStart(w http.ResponseWriter, r *http.Request) {
+	lang := oa.config().GetLocalization().GetDefaultClientLocale()
+	config := oa.config().Oauth
+	challenge := r.URL.Query().Get("login_challenge")
+	ticket := r.URL.Query().Get("link_ticket")
+
+	returnErr := func(err error, errDetails, msgID string) {
+		oa.log.ErrorStruct(errDetails, err)
+		msg := models.Tr(lang, "An error occurred during authentication.", nil)
+		desc := models.Tr(lang, msgID, nil)
+		u := fmt.Sprintf("%s?error=%s&error_description=%s&translated=true", config.GetFrontendLoginErrorUrl(), url.QueryEscape(msg), url.QueryEscape(desc))
+		http.Redirect(w, r, u, http.StatusFound)
+	}
+
+	if challenge == "" && ticket == "" {
+		returnErr(nil, "", "oauth.login_challenge.missing")
+		return
+	}
+
+	p, err := oa.socialProviderGet(chi.URLParam(r, "provider"))
+	if err != nil {
+		returnErr(err, "failed to get the social provider", "oauth.social.provider.unknown")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+	sCtx := &models.Context{Context: ctx, AcceptLanguage: lang}
+
+	state := &intModels.SocialLoginState{
+		ID:             intModels.SocialStateNewID(),
+		Provider:       p.name,
+		LoginChallenge: challenge,
+		CodeVerifier:   oauth2.GenerateVerifier(),
+		Nonce:          intModels.SocialStateNewID(),
+		Platform:       intModels.ClientPlatformParse(r.URL.Query().Get("platform")),
+		Remember:       r.URL.Query().Get("remember") == "true",
+		ExpiresAt:      time.Now().Add(oa.socialStateDuration()).UnixMilli(),
+	}
+
+	if ticket != "" {
+		link, dbErr := oa.store.SocialLoginStatesTake(sCtx, ticket)
+		if dbErr != nil {
+			returnErr(dbErr, "failed to get the identity link ticket", "oauth.social.state.invalid")
+			return
+		}
+		if link.LinkUserID == "" || link.Provider != p.name || time.Now().UnixMilli() > link.ExpiresAt {
+			returnErr(nil, "the identity link ticket is expired or issued for another provider", "oauth.social.state.invalid")
+			return
+		}
+		state.LinkUserID = link.LinkUserID
+		state.LoginChallenge = ""
+	}
+
+	if err := oa.store.SocialLoginStatesAdd(sCtx, state); err != nil {
+		returnErr(err, "failed to store the social login state", "oauth.server_error.internal")
+		return
+	}
+
+	oa.socialStateCookieSet(w, r, p, state.ID, int(oa.socialStateDuration().Seconds()))
+
+	opts := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(state.CodeVerifier)}
+	if p.verifier != nil {
+		opts = append(opts, oidc.Nonce(state.Nonce))
+	}
+	if p.cfg.ResponseMode != "" {
+		opts = append(opts, oauth2.SetAuthURLParam("response_mode", p.cfg.ResponseMode))
+	}
+
+	http.Redirect(w, r, p.oauth2.AuthCodeURL(state.ID, opts...), http.StatusFound)
+}
+
+// SocialCallback finishes the social login, the signed in provider account is matched to
+// a user by its identity, then by the verified email (linking the identity), and a new
+// customer is created otherwise, the Hydra login request is accepted for that user, the
+// users with the MFA enabled are refused, they sign in with their password and code
+//
+// the callbacks of the link flow save the provider account as a pending link of the user
+// who started it, and land on the frontend identities page, which confirms the link
+//
+// TODO: track metrics
+func (oa *OAuth) SocialCallback(w http.ResponseWriter, r *http.Request) {
+	lang := oa.config().GetLocalization().GetDefaultClientLocale()
+	config := oa.config().Oauth
+	errorURL := config.GetFrontendLoginErrorUrl()
+
+	returnErr := func(err error, errDetails, msgID string, params map[string]any) {
+		oa.log.ErrorStruct(errDetails, err)
+		msg := models.Tr(lang, "An error occurred during authentication.", nil)
+		desc := models.Tr(lang, msgID, params)
+		u := fmt.Sprintf("%s?error=%s&error_description=%s&translated=true", errorURL, url.QueryEscape(msg), url.QueryEscape(desc))
+		http.Redirect(w, r, u, http.StatusFound)
+	}
+
+	p, err := oa.socialProviderGet(chi.URLParam(r, "provider"))
+	if err != nil {
+		returnErr(err, "failed to get the social provider", "oauth.social.provider.unknown", nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*20)
+	defer cancel()
+	sCtx := requestContext(ctx, r, lang)
+
+	stateID := r.FormValue("state")
+	cookie, cookieErr := r.Cookie(intModels.SocialStateCookie)
+	oa.socialStateCookieSet(w, r, p, "", -1)
+	if stateID == "" || cookieErr != nil || cookie.Value != stateID {
+		returnErr(cookieErr, "the social login state doesn't match the browser's one", "oauth.social.state.invalid", nil)
+		return
+	}
+
+	state, dbErr := oa.store.SocialLoginStatesTake(sCtx, stateID)
+	if dbErr != nil {
+		if dbErr.ErrType == models.DBErrorTypeNoRows {
+			returnErr(dbErr, "the social login state is not found", "oauth.social.state.invalid", nil)
+			return
+		}
+		returnErr(dbErr, "failed to get the social login state", "oauth.server_error.internal", nil)
+		return
+	}
+	if state.LinkUserID != "" {
+		errorURL = oa.srvCfg.OAuth.FrontendIdentitiesURL
+	}
+	if state.Provider != p.name || time.Now().UnixMilli() > state.ExpiresAt {
+		returnErr(nil, "the social login state is expired or issued for another provider", "oauth.social.state.invalid", nil)
+		return
+	}
+
+	if e := r.FormValue("error"); e != "" {
+		returnErr(fmt.Errorf("%s: %s", e, r.FormValue("error_description")), "the social provider refused the login", "oauth.access_denied.user", nil)
+		return
+	}
+
+	info, err := oa.socialUserInfoGet(ctx, p, state, r.FormValue("code"))
+	if err != nil {
+		returnErr(err, "failed to get the user info from the social provider", "oauth.social.provider.error", nil)
+		return
+	}
+	if info.FirstName == "" && info.LastName == "" {
+		// Apple sends the name only once, on the first sign in, along with the callback
+		info.FirstName, info.LastName = socialAppleUserName(r.FormValue("user"))
+	}
+	if state.LinkUserID != "" {
+		if info.Subject == "" {
+			returnErr(nil, "the social provider returned no subject", "oauth.social.provider.error", nil)
+			return
+		}
+		// the identity is only linked when the user confirms it from the identities page, the
+		// browser finishing the flow may not be the one of the user who got the link ticket
+		pending := &intModels.SocialLoginState{
+			ID:           intModels.SocialStateNewID(),
+			Provider:     p.name,
+			LinkUserID:   state.LinkUserID,
+			LinkIdentity: info,
+			ExpiresAt:    time.Now().Add(oa.socialStateDuration()).UnixMilli(),
+		}
+		if err := oa.store.SocialLoginStatesAdd(sCtx, pending); err != nil {
+			returnErr(err, "failed to store the pending identity link", "oauth.server_error.internal", nil)
+			return
+		}
+		u := fmt.Sprintf("%s?provider=%s&link_id=%s", oa.srvCfg.OAuth.FrontendIdentitiesURL, url.QueryEscape(p.name), url.QueryEscape(pending.ID))
+		http.Redirect(w, r, u, http.StatusFound)
+		return
+	}
+
+	if info.Subject == "" || info.Email == "" || !info.EmailVerified {
+		returnErr(nil, "the social provider returned no verified email", "oauth.social.email_not_verified", nil)
+		return
+	}
+
+	user, msgID, err := oa.socialUserResolve(sCtx, p.name, info)
+	if err != nil {
+		returnErr(err, "failed to resolve the user of the social login", msgID, nil)
+		return
+	}
+
+	lockedUntil, dbErr := oa.store.UsersGetLockedUntil(sCtx, user.GetId())
+	if dbErr != nil {
+		returnErr(dbErr, "failed to get the lock of the user", "oauth.server_error.internal", nil)
+		return
+	}
+	if time.Now().UnixMilli() < lockedUntil {
+		returnErr(nil, "the user of the social login is locked", "user.login.locked.error", map[string]any{"Minutes": int(math.Ceil(time.Until(time.UnixMilli(lockedUntil)).Minutes()))})
+		return
+	}
+
+	// the provider account stands for the password only, the second factor can't be skipped
+	if user.GetMfaActive() {
+		returnErr(nil, "the user of the social login has the MFA enabled", "oauth.social.mfa_active", nil)
+		return
+	}
+
+	session := intModels.LoginSessionOptions{Platform: state.Platform, Remember: state.Remember}
+	redirectTo, appErr := oa.loginAcceptor.SocialLoginAccept(sCtx, user, state.LoginChallenge, session)
+	if appErr != nil {
+		returnErr(appErr, "failed to accept the social login", "oauth.unknown_error", nil)
+		return
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusFound)
+}
+
+// socialProviderGet returns the named provider, the OIDC discovery runs on the first use,
+// so an unreachable provider doesn't prevent the server from starting
+func (oa *OAuth) socialProviderGet(name string) (*socialProvider, error) {
+	oa.socialMux.Lock()
+	defer oa.socialMux.Unlock()
+
+	if p, ok := oa.socialProviders[name]; ok {
+		return p, nil
+	}
+
+	cfg, ok := oa.srvCfg.OAuth.SocialProviders[name]
+	if !ok || cfg.ClientID == "" {
+		return nil, errSocialProviderUnknown
+	}
+
+	p := &socialProvider{
+		name: name,
+		cfg:  cfg,
+		oauth2: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			Endpoint:     oauth2.Endpoint{AuthURL: cfg.AuthURL, TokenURL: cfg.TokenURL},
+			RedirectURL:  fmt.Sprintf("%s/social/%s/callback", strings.TrimSuffix(oa.config().Oauth.GetOauthBackendUrl(), "/"), url.PathEscape(name)),
+			Scopes:       cfg.Scopes,
+		},
+	}
+
+	if cfg.Issuer != "" {
+		// the provider keeps the context to refresh its keys later, so it must not be canceled
+		ctx := oidc.ClientContext(context.Background(), oa.httpClient)
+		provider, err := oidc.NewProvider(ctx, cfg.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover the %s OIDC provider: %w", name, err)
+		}
+		p.oauth2.Endpoint = provider.Endpoint()
+		p.verifier = provider.Verifier(&oidc.Config{ClientID: cfg.ClientID})
+	}
+
+	oa.socialProviders[name] = p
+	return p, nil
+}
+
+// socialUserInfoGet exchanges the code (with the PKCE verifier) for the tokens, and reads
+// the identity from the verified id token, or from the userinfo endpoint of the plain
+// OAuth2 providers
+func (oa *OAuth) socialUserInfoGet(ctx context.Context, p *socialProvider, state *intModels.SocialLoginState, code string) (*intModels.SocialUserInfo, error) {
+	if code == "" {
+		return nil, errors.New("the authorization code is missing")
+	}
+
+	ctx = oidc.ClientContext(ctx, oa.httpClient)
+	token, err := p.oauth2.Exchange(ctx, code, oauth2.VerifierOption(state.CodeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange the authorization code: %w", err)
+	}
+
+	if p.verifier != nil {
+		raw, ok := token.Extra("id_token").(string)
+		if !ok || raw == "" {
+			return nil, errors.New("the token response has no id_token")
+		}
+		idToken, err := p.verifier.Verify(ctx, raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify the id_token: %w", err)
+		}
+		if idToken.Nonce != state.Nonce {
+			return nil, errors.New("the id_token nonce doesn't match the login state")
+		}
+
+		var claims map[string]any
+		if err := idToken.Claims(&claims); err != nil {
+			return nil, fmt.Errorf("failed to decode the id_token claims: %w", err)
+		}
+		return intModels.SocialUserInfoFromClaims(claims), nil
+	}
+
+	client := p.oauth2.Client(ctx, token)
+	var claims map[string]any
+	if err := socialGetJSON(ctx, client, p.cfg.UserInfoURL, &claims); err != nil {
+		return nil, err
+	}
+	info := intModels.SocialUserInfoFromClaims(claims)
+
+	if p.cfg.EmailsURL != "" {
+		var emails []intModels.SocialEmail
+		if err := socialGetJSON(ctx, client, p.cfg.EmailsURL, &emails); err != nil {
+			return nil, err
+		}
+		info.Email, info.EmailVerified = intModels.SocialPrimaryEmail(emails)
+	}
+
+	return info, nil
+}
+
+// socialUserResolve returns the user of the provider account, creating the user or linking
+// the identity when needed, the returned message id describes the error to the user
+//
+// an identity is linked by email only to the accounts whose email is verified, otherwise
+// whoever registered the email before its owner would share the account with them, and
+// never to the accounts with the MFA enabled, the provider account would bypass it
+func (oa *OAuth) socialUserResolve(ctx *models.Context, provider string, info *intModels.SocialUserInfo) (*pb.User, string, error) {
+	user, err := oa.store.UsersGetByIdentity(ctx, provider, info.Subject)
+	if err == nil {
+		return user, "", nil
+	}
+	if err.ErrType != models.DBErrorTypeNoRows {
+		return nil, "oauth.server_error.internal", err
+	}
+
+	user, err = oa.store.UsersGetByEmail(ctx, info.Email)
+	if err != nil {
+		if err.ErrType != models.DBErrorTypeNoRows {
+			return nil, "oauth.server_error.internal", err
+		}
+
+		// the social signups are held to the email domains policy of the other signups
+		if reason := oa.emailDomainPolicy().Check(ctx.Context, info.Email, intModels.UserTypeCustomer); reason != "" {
+			return nil, fmt.Sprintf("user.create.email.%s.error", reason), fmt.Errorf("the email domain is refused: %s", reason)
+		}
+
+		user = intModels.SocialUserNew(info, ctx.AcceptLanguage)
+		if err := oa.store.UsersSocialCreate(ctx, user, intModels.SocialIdentityNew(user.GetId(), provider, info)); err != nil {
+			return nil, "oauth.server_error.internal", err
+		}
+		return user, "", nil
+	}
+
+	if !user.GetIsEmailVerified() {
+		return nil, "oauth.social.link_unverified", errors.New("the email of the matching account is not verified")
+	}
+	if user.GetMfaActive() {
+		return nil, "oauth.social.mfa_active", errors.New("the matching account has the MFA enabled")
+	}
+
+	if err := oa.store.IdentitiesAdd(ctx, intModels.SocialIdentityNew(user.GetId(), provider, info)); err != nil {
+		return nil, "oauth.server_error.internal", err
+	}
+	return user, "", nil
+}
+
+func (oa *OAuth) socialStateDuration() time.Duration {
+	if m := oa.srvCfg.OAuth.SocialStateMinutes; m > 0 {
+		return time.Duration(m) * time.Minute
+	}
+	return time.Minute * 10
+}
+
+// socialStateCookieSet sets (or removes with a negative maxAge) the state cookie, the
+// form_post callbacks are cross site POST requests, so their cookie must be SameSite=None
+func (oa *OAuth) socialStateCookieSet(w http.ResponseWriter, r *http.Request, p *socialProvider, value string, maxAge int) {
+	cookie := &http.Cookie{
+		Name:     intModels.SocialStateCookie,
+		Value:    value,
+		Path:     "/social/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if p.cfg.ResponseMode == "form_post" {
+		cookie.SameSite = http.SameSiteNoneMode
+		cookie.Secure = true
+	}
+	http.SetCookie(w, cookie)
+}
+
+func socialGetJSON(ctx context.Context, client *http.Client, u string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create the request of %s: %w", u, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, u)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the response of %s: %w", u, err)
+	}
+	return nil
+}
+
+// socialAppleUserName reads the name from the user form value that Apple posts on the first sign in
+func socialAppleUserName(raw string) (string, string) {
+	var user struct {
+		Name struct {
+			FirstName string `json:"firstName"`
+			LastName  string `json:"lastName"`
+		} `json:"name"`
+	}
+	if raw == "" || json.Unmarshal([]byte(raw), &user) != nil {
+		return "", ""
+	}
+	return user.Name.FirstName, user.Name.LastName
+}
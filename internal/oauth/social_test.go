@@ -0,0 +1,347 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	com "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/common/v1"
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/logger"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	storeMocks "github.com/ahmad-khatib0-org/megacommerce-user/internal/store/mocks"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fakeOIDCClientID = "megacommerce"
+	fakeOIDCSubject  = "fake-subject-1"
+	fakeOIDCEmail    = "jane@example.com"
+)
+
+// fakeOIDCProvider is a minimal OIDC provider, its token endpoint checks the PKCE verifier
+// against the challenge of the authorization request, and signs an id token for the nonce
+type fakeOIDCProvider struct {
+	t         *testing.T
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	nonce     string
+}
+
+func newFakeOIDCProvider(t *testing.T) *fakeOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &fakeOIDCProvider{t: t, key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                p.server.URL,
+			"authorization_endpoint":                p.server.URL + "/auth",
+			"token_endpoint":                        p.server.URL + "/token",
+			"jwks_uri":                              p.server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		jwk := jose.JSONWebKey{Key: &p.key.PublicKey, KeyID: "fake", Algorithm: "RS256", Use: "sig"}
+		json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{jwk}})
+	})
+	mux.HandleFunc("/token", p.token)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+func (p *fakeOIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	require.NoError(p.t, r.ParseForm())
+	require.Equal(p.t, "fake-code", r.PostForm.Get("code"))
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != p.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: p.key, KeyID: "fake"}}, nil)
+	require.NoError(p.t, err)
+
+	claims, _ := json.Marshal(map[string]any{
+		"iss":            p.server.URL,
+		"sub":            fakeOIDCSubject,
+		"aud":            fakeOIDCClientID,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"nonce":          p.nonce,
+		"email":          fakeOIDCEmail,
+		"email_verified": true,
+		"given_name":     "Jane",
+		"family_name":    "Doe",
+	})
+	signed, err := signer.Sign(claims)
+	require.NoError(p.t, err)
+	idToken, err := signed.CompactSerialize()
+	require.NoError(p.t, err)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"access_token": "fake-access", "token_type": "Bearer", "expires_in": 60, "id_token": idToken})
+}
+
+// fakeLoginAcceptor accepts the login request of the "fake-challenge", and records the
+// accepted user and session options
+type fakeLoginAcceptor struct {
+	user    *pb.User
+	session intModels.LoginSessionOptions
+}
+
+func (a *fakeLoginAcceptor) SocialLoginAccept(ctx *models.Context, user *pb.User, challenge string, session intModels.LoginSessionOptions) (string, *models.AppError) {
+	if challenge != "fake-challenge" {
+		return "", &models.AppError{ID: "login.error"}
+	}
+	a.user, a.session = user, session
+	return "http://hydra.local/done", nil
+}
+
+// oauthTestTranslationsInit initializes the translations of the error redirects
+func oauthTestTranslationsInit(t *testing.T, ids ...string) {
+	trans := &com.TranslationElements{}
+	for _, id := range append([]string{"An error occurred during authentication."}, ids...) {
+		trans.Trans = append(trans.Trans, &com.TranslationElement{Id: id, Tr: id})
+	}
+	require.NoError(t, models.TranslationsInit(map[string]*com.TranslationElements{"en": trans}, "en"))
+}
+
+func newSocialTestOAuth(t *testing.T, issuer, hydraURL string) (*OAuth, *storeMocks.MockUsersStore) {
+	log, err := logger.InitLogger("dev")
+	require.NoError(t, err)
+
+	cfg := &com.Config{
+		Localization: &com.ConfigLocalization{DefaultClientLocale: utils.NewPointer("en")},
+		Security: &com.ConfigSecurity{
+			AccessTokenExpiryWebInHours:    utils.NewPointer(int32(24)),
+			AccessTokenExpiryMobileInHours: utils.NewPointer(int32(720)),
+		},
+		Oauth: &com.ConfigOAuth{
+			OauthAdminUrl:         utils.NewPointer(hydraURL),
+			OauthBackendUrl:       utils.NewPointer("http://localhost:4000"),
+			FrontendLoginErrorUrl: utils.NewPointer("http://localhost:3000/login/error"),
+		},
+	}
+	srvCfg := &intModels.Config{OAuth: intModels.OAuth{
+		SocialStateMinutes: 10,
+		SocialProviders: map[string]intModels.SocialProvider{
+			"fake": {Issuer: issuer, ClientID: fakeOIDCClientID, ClientSecret: "secret", Scopes: []string{"openid", "email", "profile"}},
+		},
+	}}
+
+	store := storeMocks.NewMockUsersStore(t)
+	oa := NewOauth(OAuthArgs{Config: func() *com.Config { return cfg }, SrvCfg: srvCfg, Store: store, LoginAcceptor: &fakeLoginAcceptor{}, Log: log})
+	return oa, store
+}
+
+// socialLoginStart runs the first leg, and returns the stored state and the cookie binding it
+func socialLoginStart(t *testing.T, oa *OAuth, store *storeMocks.MockUsersStore, provider *fakeOIDCProvider) (*intModels.SocialLoginState, *http.Cookie) {
+	var state *intModels.SocialLoginState
+	store.EXPECT().SocialLoginStatesAdd(mock.Anything, mock.Anything).
+		Run(func(_ *models.Context, s *intModels.SocialLoginState) { state = s }).
+		Return(nil).Once()
+
+	rec := httptest.NewRecorder()
+	oa.routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/social/fake?login_challenge=fake-challenge&platform=mobile&remember=true", nil))
+	require.Equal(t, http.StatusFound, rec.Code)
+
+	location, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, provider.server.URL+"/auth", location.Scheme+"://"+location.Host+location.Path)
+
+	query := location.Query()
+	require.Equal(t, state.ID, query.Get("state"))
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+	require.Equal(t, "http://localhost:4000/social/fake/callback", query.Get("redirect_uri"))
+	require.Equal(t, state.Nonce, query.Get("nonce"))
+	require.Equal(t, "fake-challenge", state.LoginChallenge)
+	require.Equal(t, intModels.ClientPlatformMobile, state.Platform)
+	require.True(t, state.Remember)
+
+	provider.challenge = query.Get("code_challenge")
+	provider.nonce = query.Get("nonce")
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, state.ID, cookies[0].Value)
+
+	store.EXPECT().SocialLoginStatesTake(mock.Anything, state.ID).Return(state, nil).Once()
+	return state, cookies[0]
+}
+
+func socialLoginCallback(t *testing.T, oa *OAuth, state *intModels.SocialLoginState, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/social/fake/callback?code=fake-code&state="+url.QueryEscape(state.ID), nil)
+	req.AddCookie(cookie)
+
+	rec := httptest.NewRecorder()
+	oa.routes().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSocialLoginCreatesUser(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+	oa, store := newSocialTestOAuth(t, provider.server.URL, "http://hydra.invalid")
+
+	state, cookie := socialLoginStart(t, oa, store, provider)
+
+	noRows := &models.DBError{ErrType: models.DBErrorTypeNoRows}
+	var created *pb.User
+	var identity *intModels.Identity
+	store.EXPECT().UsersGetByIdentity(mock.Anything, "fake", fakeOIDCSubject).Return(nil, noRows).Once()
+	store.EXPECT().UsersGetByEmail(mock.Anything, fakeOIDCEmail).Return(nil, noRows).Once()
+	store.EXPECT().UsersSocialCreate(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(_ *models.Context, u *pb.User, i *intModels.Identity) { created, identity = u, i }).
+		Return(nil).Once()
+	store.EXPECT().UsersGetLockedUntil(mock.Anything, mock.Anything).Return(0, nil).Once()
+
+	rec := socialLoginCallback(t, oa, state, cookie)
+	require.Equal(t, http.StatusFound, rec.Code)
+	require.Equal(t, "http://hydra.local/done", rec.Header().Get("Location"))
+
+	require.Equal(t, fakeOIDCEmail, created.GetEmail())
+	require.Empty(t, created.GetAuthService(), "the provider account is kept in the identities only")
+	require.Empty(t, created.GetAuthData())
+	require.Equal(t, "Jane", created.GetFirstName())
+	require.True(t, created.GetIsEmailVerified())
+	require.Empty(t, created.GetPassword())
+	require.Equal(t, created.GetId(), identity.UserID)
+	require.Equal(t, "fake", identity.Provider)
+	require.Equal(t, fakeOIDCSubject, identity.Subject)
+
+	acceptor := oa.loginAcceptor.(*fakeLoginAcceptor)
+	require.Equal(t, created.GetId(), acceptor.user.GetId())
+	require.Equal(t, intModels.LoginSessionOptions{Platform: intModels.ClientPlatformMobile, Remember: true}, acceptor.session)
+}
+
+func TestSocialLoginLinksVerifiedEmail(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+	oa, store := newSocialTestOAuth(t, provider.server.URL, "http://hydra.invalid")
+
+	state, cookie := socialLoginStart(t, oa, store, provider)
+
+	existing := &pb.User{Id: utils.NewIDPointer(), Email: utils.NewPointer(fakeOIDCEmail), IsEmailVerified: utils.NewPointer(true)}
+	store.EXPECT().UsersGetByIdentity(mock.Anything, "fake", fakeOIDCSubject).Return(nil, &models.DBError{ErrType: models.DBErrorTypeNoRows}).Once()
+	store.EXPECT().UsersGetByEmail(mock.Anything, fakeOIDCEmail).Return(existing, nil).Once()
+	store.EXPECT().IdentitiesAdd(mock.Anything, mock.MatchedBy(func(i *intModels.Identity) bool {
+		return i.UserID == existing.GetId() && i.Provider == "fake" && i.Subject == fakeOIDCSubject
+	})).Return(nil).Once()
+	store.EXPECT().UsersGetLockedUntil(mock.Anything, existing.GetId()).Return(0, nil).Once()
+
+	rec := socialLoginCallback(t, oa, state, cookie)
+	require.Equal(t, http.StatusFound, rec.Code)
+	require.Equal(t, "http://hydra.local/done", rec.Header().Get("Location"))
+	require.Equal(t, existing.GetId(), oa.loginAcceptor.(*fakeLoginAcceptor).user.GetId())
+}
+
+func TestSocialLoginRefusesMfaUsers(t *testing.T) {
+	oauthTestTranslationsInit(t, "oauth.social.mfa_active")
+	noRows := &models.DBError{ErrType: models.DBErrorTypeNoRows}
+	mfaUser := &pb.User{Id: utils.NewIDPointer(), Email: utils.NewPointer(fakeOIDCEmail), IsEmailVerified: utils.NewPointer(true), MfaActive: utils.NewPointer(true)}
+
+	tests := map[string]func(store *storeMocks.MockUsersStore){
+		"a linked identity doesn't skip the second factor": func(store *storeMocks.MockUsersStore) {
+			store.EXPECT().UsersGetByIdentity(mock.Anything, "fake", fakeOIDCSubject).Return(mfaUser, nil).Once()
+			store.EXPECT().UsersGetLockedUntil(mock.Anything, mfaUser.GetId()).Return(0, nil).Once()
+		},
+		"the identity isn't linked by the verified email": func(store *storeMocks.MockUsersStore) {
+			store.EXPECT().UsersGetByIdentity(mock.Anything, "fake", fakeOIDCSubject).Return(nil, noRows).Once()
+			store.EXPECT().UsersGetByEmail(mock.Anything, fakeOIDCEmail).Return(mfaUser, nil).Once()
+		},
+	}
+	for name, expect := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider := newFakeOIDCProvider(t)
+			oa, store := newSocialTestOAuth(t, provider.server.URL, "http://hydra.invalid")
+			state, cookie := socialLoginStart(t, oa, store, provider)
+			expect(store)
+
+			rec := socialLoginCallback(t, oa, state, cookie)
+			require.Equal(t, http.StatusFound, rec.Code)
+			location, err := url.Parse(rec.Header().Get("Location"))
+			require.NoError(t, err)
+			require.Equal(t, "http://localhost:3000/login/error", location.Scheme+"://"+location.Host+location.Path)
+			require.Equal(t, "oauth.social.mfa_active", location.Query().Get("error_description"))
+			require.Nil(t, oa.loginAcceptor.(*fakeLoginAcceptor).user)
+		})
+	}
+}
+
+func TestSocialLoginRefusedEmailDomain(t *testing.T) {
+	oauthTestTranslationsInit(t, "user.create.email.denied.error")
+	policy := intModels.EmailDomainPolicyNew(&intModels.EmailDomainPolicyArgs{Config: &intModels.EmailDomain{Deny: []string{"example.com"}}})
+
+	provider := newFakeOIDCProvider(t)
+	oa, store := newSocialTestOAuth(t, provider.server.URL, "http://hydra.invalid")
+	oa.emailDomainPolicy = func() *intModels.EmailDomainPolicy { return policy }
+	state, cookie := socialLoginStart(t, oa, store, provider)
+	noRows := &models.DBError{ErrType: models.DBErrorTypeNoRows}
+	store.EXPECT().UsersGetByIdentity(mock.Anything, "fake", fakeOIDCSubject).Return(nil, noRows).Once()
+	store.EXPECT().UsersGetByEmail(mock.Anything, fakeOIDCEmail).Return(nil, noRows).Once()
+
+	rec := socialLoginCallback(t, oa, state, cookie)
+	require.Equal(t, http.StatusFound, rec.Code)
+	location, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, "user.create.email.denied.error", location.Query().Get("error_description"))
+	store.AssertNotCalled(t, "UsersSocialCreate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSocialLoginLinkFlow(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+	oa, store := newSocialTestOAuth(t, provider.server.URL, "http://hydra.invalid")
+	oa.srvCfg.OAuth.FrontendIdentitiesURL = "http://localhost:3000/account/identities"
+
+	userID := utils.NewID()
+	ticket := &intModels.SocialLoginState{ID: "fake-ticket", Provider: "fake", LinkUserID: userID, ExpiresAt: time.Now().Add(time.Minute).UnixMilli()}
+	store.EXPECT().SocialLoginStatesTake(mock.Anything, ticket.ID).Return(ticket, nil).Once()
+
+	var state *intModels.SocialLoginState
+	store.EXPECT().SocialLoginStatesAdd(mock.Anything, mock.Anything).
+		Run(func(_ *models.Context, s *intModels.SocialLoginState) { state = s }).
+		Return(nil).Once()
+
+	rec := httptest.NewRecorder()
+	oa.routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/social/fake?link_ticket="+ticket.ID, nil))
+	require.Equal(t, http.StatusFound, rec.Code)
+	require.Equal(t, userID, state.LinkUserID)
+	require.Empty(t, state.LoginChallenge)
+
+	location, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+	provider.challenge = location.Query().Get("code_challenge")
+	provider.nonce = location.Query().Get("nonce")
+
+	store.EXPECT().SocialLoginStatesTake(mock.Anything, state.ID).Return(state, nil).Once()
+	var pending *intModels.SocialLoginState
+	store.EXPECT().SocialLoginStatesAdd(mock.Anything, mock.Anything).
+		Run(func(_ *models.Context, s *intModels.SocialLoginState) { pending = s }).
+		Return(nil).Once()
+
+	rec = socialLoginCallback(t, oa, state, rec.Result().Cookies()[0])
+	require.Equal(t, http.StatusFound, rec.Code)
+	require.Equal(t, "http://localhost:3000/account/identities?provider=fake&link_id="+url.QueryEscape(pending.ID), rec.Header().Get("Location"))
+
+	// the identity is only linked when the user confirms the pending link
+	require.Equal(t, userID, pending.LinkUserID)
+	require.Equal(t, fakeOIDCSubject, pending.LinkIdentity.Subject)
+	store.AssertNotCalled(t, "IdentitiesAdd", mock.Anything, mock.Anything)
+}
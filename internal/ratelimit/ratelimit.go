@@ -0,0 +1,213 @@
+// Package ratelimit limits the rate of the grpc requests per method, by the client IP,
+// the email sent in the request, or the authenticated user
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/logger"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/redis/go-redis/v9"
+	"github.com/throttled/throttled/v2"
+	"github.com/throttled/throttled/v2/store/goredisstore.v9"
+	"github.com/throttled/throttled/v2/store/memstore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// RetryAfterHeader is the grpc header holding the seconds to wait before retrying a limited request
+const RetryAfterHeader = "retry-after"
+
+// the keys that a policy can limit by
+const (
+	KeyIP    = "ip"
+	KeyEmail = "email"
+	KeyUser  = "user"
+)
+
+type Limiter struct {
+	log *logger.Logger
+	// policies are keyed by the lowercased method name
+	policies       map[string][]*policy
+	trustedProxies int
+}
+
+type policy struct {
+	key     string
+	limiter *throttled.GCRARateLimiterCtx
+}
+
+type LimiterArgs struct {
+	Config *intModels.RateLimit
+	// Redis is the store shared by the instances of the service, if nil only the memory is used
+	Redis redis.UniversalClient
+	Log   *logger.Logger
+	// TrustedProxies is Service.TrustedProxies, used to find the client IP
+	TrustedProxies int
+}
+
+func NewLimiter(la *LimiterArgs) (*Limiter, *models.InternalError) {
+	path := "user.ratelimit.NewLimiter"
+	cfg := la.Config
+
+	memory, err := memstore.NewCtx(cfg.MemoryMaxKeys)
+	if err != nil {
+		return nil, &models.InternalError{Path: path, Err: err, Msg: "failed to create the rate limit memory store"}
+	}
+	store := &fallbackStore{memory: memory, log: la.Log}
+	if la.Redis != nil {
+		primary, err := goredisstore.NewCtx(la.Redis, cfg.KeyPrefix)
+		if err != nil {
+			return nil, &models.InternalError{Path: path, Err: err, Msg: "failed to create the rate limit redis store"}
+		}
+		store.primary = primary
+	}
+
+	l := &Limiter{log: la.Log, policies: map[string][]*policy{}, trustedProxies: la.TrustedProxies}
+	for method, policies := range cfg.Policies {
+		for _, p := range policies {
+			if p.Key != KeyIP && p.Key != KeyEmail && p.Key != KeyUser {
+				return nil, &models.InternalError{Path: path, Msg: fmt.Sprintf("invalid rate limit key %q of the method %s", p.Key, method)}
+			}
+			if p.Requests <= 0 || p.PeriodSeconds <= 0 {
+				return nil, &models.InternalError{Path: path, Msg: fmt.Sprintf("invalid rate limit policy of the method %s", method)}
+			}
+
+			quota := throttled.RateQuota{MaxRate: throttled.PerDuration(p.Requests, time.Duration(p.PeriodSeconds)*time.Second), MaxBurst: p.Burst}
+			limiter, err := throttled.NewGCRARateLimiterCtx(store, quota)
+			if err != nil {
+				return nil, &models.InternalError{Path: path, Err: err, Msg: fmt.Sprintf("failed to create the rate limiter of the method %s", method)}
+			}
+			name := strings.ToLower(method)
+			l.policies[name] = append(l.policies[name], &policy{key: p.Key, limiter: limiter})
+		}
+	}
+
+	return l, nil
+}
+
+// UnaryServerInterceptor rejects the requests exceeding any policy of their method, it must
+// run after models.UnaryMetadataInterceptor, which builds the request context. The methods
+// answering with a "response" oneof get the AppError in its error field, like the handlers
+// return their errors, the others get a ResourceExhausted status
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(gctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		_, method, _ := strings.Cut(strings.TrimPrefix(info.FullMethod, "/"), "/")
+		policies := l.policies[strings.ToLower(method)]
+		if len(policies) == 0 {
+			return handler(gctx, req)
+		}
+
+		ctx, err := models.ContextGet(gctx)
+		if err != nil {
+			return handler(gctx, req)
+		}
+
+		retryAfter := l.check(ctx, method, policies, req)
+		if retryAfter <= 0 {
+			return handler(gctx, req)
+		}
+
+		seconds := int(math.Ceil(retryAfter.Seconds()))
+		_ = grpc.SetHeader(gctx, metadata.Pairs(RetryAfterHeader, strconv.Itoa(seconds)))
+
+		path := "user.ratelimit.UnaryServerInterceptor"
+		appErr := models.NewAppError(ctx, path, "error.rate_limited", map[string]any{"Seconds": seconds}, fmt.Sprintf("rate limit of %s exceeded", method), int(codes.ResourceExhausted), nil)
+		if res := errorResponse(info.FullMethod, appErr); res != nil {
+			return res, nil
+		}
+		return nil, status.Error(codes.ResourceExhausted, appErr.Message)
+	}
+}
+
+// check returns how long to wait before retrying, or zero if the request is allowed, the
+// store errors let the request through, the limits shouldn't take the service down
+func (l *Limiter) check(ctx *models.Context, method string, policies []*policy, req any) time.Duration {
+	var retryAfter time.Duration
+	for _, p := range policies {
+		value := l.keyValue(ctx, p.key, req)
+		if value == "" {
+			continue
+		}
+
+		limited, result, err := p.limiter.RateLimitCtx(ctx.Context, fmt.Sprintf("%s:%s:%s", strings.ToLower(method), p.key, value), 1)
+		if err != nil {
+			l.log.ErrorStruct("failed to check the rate limit", err)
+			continue
+		}
+		if limited && result.RetryAfter > retryAfter {
+			retryAfter = result.RetryAfter
+		}
+	}
+	return retryAfter
+}
+
+// keyValue returns the value of the key for the request, empty if the request has none
+// (E,g no email), the policy is skipped then
+func (l *Limiter) keyValue(ctx *models.Context, key string, req any) string {
+	switch key {
+	case KeyIP:
+		return intModels.ClientIPAddress(ctx, l.trustedProxies)
+	case KeyEmail:
+		if r, ok := req.(interface{ GetEmail() string }); ok {
+			return strings.ToLower(strings.TrimSpace(r.GetEmail()))
+		}
+	case KeyUser:
+		if ctx.Session != nil {
+			return ctx.Session.UserID
+		}
+	}
+	return ""
+}
+
+// errorResponse builds the response message of the method with the error set in its
+// "response" oneof, nil if the method's response has no such oneof
+func errorResponse(fullMethod string, appErr *models.AppError) protoreflect.ProtoMessage {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil
+	}
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(md.Output().FullName())
+	if err != nil {
+		return nil
+	}
+
+	res := mt.New()
+	oneof := res.Descriptor().Oneofs().ByName("response")
+	if oneof == nil {
+		return nil
+	}
+	field := oneof.Fields().ByName("error")
+	if field == nil || field.Message() == nil {
+		return nil
+	}
+
+	pbErr := models.AppErrorToProto(appErr)
+	if field.Message().FullName() != pbErr.ProtoReflect().Descriptor().FullName() {
+		return nil
+	}
+	res.Set(field, protoreflect.ValueOfMessage(pbErr.ProtoReflect()))
+	return res.Interface()
+}
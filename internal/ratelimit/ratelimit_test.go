@@ -0,0 +1,149 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	com "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/common/v1"
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/logger"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled/v2/store/memstore"
+	"google.golang.org/grpc"
+)
+
+func limiterNew(t *testing.T, policies map[string][]intModels.RateLimitPolicy, trustedProxies int) *Limiter {
+	t.Helper()
+	trans := map[string]*com.TranslationElements{
+		"en": {Trans: []*com.TranslationElement{{Id: "error.rate_limited", Tr: "Too many requests, try again in {{.Seconds}} seconds"}}},
+	}
+	require.NoError(t, models.TranslationsInit(trans, "en"))
+
+	log, err := logger.InitLogger("dev")
+	require.NoError(t, err)
+
+	l, limErr := NewLimiter(&LimiterArgs{Config: &intModels.RateLimit{Enabled: true, MemoryMaxKeys: 100, Policies: policies}, Log: log, TrustedProxies: trustedProxies})
+	require.Nil(t, limErr)
+	return l
+}
+
+func limiterCall(l *Limiter, ip string, req *pb.LoginRequest) (any, bool) {
+	return limiterCallForwarded(l, ip, "", req)
+}
+
+func limiterCallForwarded(l *Limiter, ip, xForwardedFor string, req *pb.LoginRequest) (any, bool) {
+	ctx := &models.Context{IPAddress: ip, XForwardedFor: xForwardedFor, AcceptLanguage: "en", Session: &models.Session{}}
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return &pb.LoginResponse{}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: pb.UsersService_Login_FullMethodName}
+	res, _ := l.UnaryServerInterceptor()(models.ContextWith(context.Background(), ctx), req, info, handler)
+	return res, called
+}
+
+func TestUnaryServerInterceptorByIP(t *testing.T) {
+	l := limiterNew(t, map[string][]intModels.RateLimitPolicy{"login": {{Key: KeyIP, Requests: 1, PeriodSeconds: 60}}}, 0)
+	req := &pb.LoginRequest{Email: "a@example.com"}
+
+	_, called := limiterCall(l, "203.0.113.1", req)
+	require.True(t, called)
+
+	res, called := limiterCall(l, "203.0.113.1", req)
+	require.False(t, called)
+	appErr := res.(*pb.LoginResponse).GetError()
+	require.NotNil(t, appErr)
+	require.Equal(t, "error.rate_limited", appErr.GetId())
+	require.Contains(t, appErr.GetMessage(), "seconds")
+
+	_, called = limiterCall(l, "203.0.113.2", req)
+	require.True(t, called, "another client isn't limited")
+}
+
+func TestUnaryServerInterceptorBehindProxy(t *testing.T) {
+	l := limiterNew(t, map[string][]intModels.RateLimitPolicy{"login": {{Key: KeyIP, Requests: 1, PeriodSeconds: 60}}}, 1)
+	req := &pb.LoginRequest{Email: "a@example.com"}
+
+	_, called := limiterCallForwarded(l, "10.0.0.1", "203.0.113.1", req)
+	require.True(t, called)
+	_, called = limiterCallForwarded(l, "10.0.0.1", "198.51.100.1, 203.0.113.1", req)
+	require.False(t, called, "a spoofed forwarded for entry doesn't change the client")
+	_, called = limiterCallForwarded(l, "10.0.0.1", "203.0.113.2", req)
+	require.True(t, called, "another client behind the same proxy isn't limited")
+}
+
+func TestUnaryServerInterceptorByEmail(t *testing.T) {
+	l := limiterNew(t, map[string][]intModels.RateLimitPolicy{"login": {{Key: KeyEmail, Requests: 1, PeriodSeconds: 60}}}, 0)
+
+	_, called := limiterCall(l, "203.0.113.1", &pb.LoginRequest{Email: "a@example.com"})
+	require.True(t, called)
+	_, called = limiterCall(l, "203.0.113.2", &pb.LoginRequest{Email: "A@example.com "})
+	require.False(t, called, "the email is limited from any address")
+	_, called = limiterCall(l, "203.0.113.2", &pb.LoginRequest{Email: "b@example.com"})
+	require.True(t, called)
+}
+
+func TestUnaryServerInterceptorOtherMethods(t *testing.T) {
+	l := limiterNew(t, map[string][]intModels.RateLimitPolicy{"passwordforgot": {{Key: KeyIP, Requests: 1, PeriodSeconds: 60}}}, 0)
+
+	for range 3 {
+		_, called := limiterCall(l, "203.0.113.1", &pb.LoginRequest{})
+		require.True(t, called)
+	}
+}
+
+func TestNewLimiterInvalidPolicy(t *testing.T) {
+	_, err := NewLimiter(&LimiterArgs{Config: &intModels.RateLimit{MemoryMaxKeys: 10, Policies: map[string][]intModels.RateLimitPolicy{
+		"login": {{Key: "country", Requests: 1, PeriodSeconds: 1}},
+	}}})
+	require.NotNil(t, err)
+}
+
+type failingStore struct{ calls int }
+
+func (s *failingStore) GetWithTime(ctx context.Context, key string) (int64, time.Time, error) {
+	s.calls++
+	return 0, time.Time{}, errors.New("connection refused")
+}
+
+func (s *failingStore) SetIfNotExistsWithTTL(ctx context.Context, key string, value int64, ttl time.Duration) (bool, error) {
+	s.calls++
+	return false, errors.New("connection refused")
+}
+
+func (s *failingStore) CompareAndSwapWithTTL(ctx context.Context, key string, old, new int64, ttl time.Duration) (bool, error) {
+	s.calls++
+	return false, errors.New("connection refused")
+}
+
+func TestFallbackStore(t *testing.T) {
+	log, err := logger.InitLogger("dev")
+	require.NoError(t, err)
+	memory, err := memstore.NewCtx(10)
+	require.NoError(t, err)
+
+	primary := &failingStore{}
+	s := &fallbackStore{primary: primary, memory: memory, log: log}
+	ctx := context.Background()
+
+	ok, err := s.SetIfNotExistsWithTTL(ctx, "k", 1, time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, primary.calls)
+
+	// the primary isn't retried until fallbackRetryAfter passes
+	v, _, err := s.GetWithTime(ctx, "k")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), v)
+	require.Equal(t, 1, primary.calls)
+
+	s.downUntil = time.Now().Add(-time.Second)
+	_, _, err = s.GetWithTime(ctx, "k")
+	require.NoError(t, err)
+	require.Equal(t, 2, primary.calls)
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/logger"
+	"github.com/throttled/throttled/v2"
+)
+
+// fallbackRetryAfter is how long the memory store is used after a Redis failure, before
+// Redis is tried again
+const fallbackRetryAfter = time.Second * 30
+
+// fallbackStore keeps the state in the primary (Redis) store, and switches to the memory
+// store while the primary fails, so an outage of Redis doesn't disable the rate limits
+type fallbackStore struct {
+	primary   throttled.GCRAStoreCtx
+	memory    throttled.GCRAStoreCtx
+	log       *logger.Logger
+	mux       sync.Mutex
+	downUntil time.Time
+}
+
+func (s *fallbackStore) GetWithTime(ctx context.Context, key string) (int64, time.Time, error) {
+	if s.primaryUp() {
+		v, t, err := s.primary.GetWithTime(ctx, key)
+		if err == nil {
+			return v, t, nil
+		}
+		s.primaryFailed(err)
+	}
+	return s.memory.GetWithTime(ctx, key)
+}
+
+func (s *fallbackStore) SetIfNotExistsWithTTL(ctx context.Context, key string, value int64, ttl time.Duration) (bool, error) {
+	if s.primaryUp() {
+		ok, err := s.primary.SetIfNotExistsWithTTL(ctx, key, value, ttl)
+		if err == nil {
+			return ok, nil
+		}
+		s.primaryFailed(err)
+	}
+	return s.memory.SetIfNotExistsWithTTL(ctx, key, value, ttl)
+}
+
+func (s *fallbackStore) CompareAndSwapWithTTL(ctx context.Context, key string, old, new int64, ttl time.Duration) (bool, error) {
+	if s.primaryUp() {
+		ok, err := s.primary.CompareAndSwapWithTTL(ctx, key, old, new, ttl)
+		if err == nil {
+			return ok, nil
+		}
+		s.primaryFailed(err)
+	}
+	return s.memory.CompareAndSwapWithTTL(ctx, key, old, new, ttl)
+}
+
+func (s *fallbackStore) primaryUp() bool {
+	if s.primary == nil {
+		return false
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return time.Now().After(s.downUntil)
+}
+
+func (s *fallbackStore) primaryFailed(err error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if time.Now().After(s.downUntil) {
+		s.log.Warnf("the rate limit store is unreachable, falling back to memory for %s: %v", fallbackRetryAfter, err)
+	}
+	s.downUntil = time.Now().Add(fallbackRetryAfter)
+}
@@ -3,6 +3,7 @@ package server
 import (
 	com "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/common/v1"
 	"github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
@@ -18,6 +19,25 @@ func (s *Server) initSharedConfig() {
 	s.configMux.Unlock()
 }
 
+// watchServiceConfig reloads the settings that the admins change at runtime when the service
+// config file is edited (the email domains deny and allow lists), the other settings need
+// a restart. The shared config of the common service has no place for these lists
+func (s *Server) watchServiceConfig() {
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		var c models.Config
+		if err := viper.Unmarshal(&c); err != nil {
+			s.log.Errorf("failed to reload the service config %s: %v", e.Name, err)
+			return
+		}
+		if err := s.emailDomainPolicyLoad(&c.EmailDomain); err != nil {
+			s.log.ErrorStruct("failed to reload the email domains policy", err)
+			return
+		}
+		s.log.Infof("reloaded the email domains policy from %s", e.Name)
+	})
+	viper.WatchConfig()
+}
+
 func LoadServiceConfig(fileName string) (*models.Config, error) {
 	viper.AddConfigPath(".")
 	viper.SetConfigFile(fileName)
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"net"
 	"net/url"
 	"time"
 
@@ -10,10 +11,13 @@ import (
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/mailer"
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/oauth"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/ratelimit"
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/store/dbstore"
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/worker"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
 	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 )
 
 func (s *Server) initTrans() map[string]*com.TranslationElements {
@@ -107,13 +111,18 @@ func (s *Server) initWorker() {
 	}()
 }
 
-func (s *Server) initOauthServer() {
+// initOauthServer starts the OAuth server, the controller accepts its social logins
+func (s *Server) initOauthServer(loginAcceptor oauth.LoginAcceptor) {
 	path := "user.server.initOauthServer"
 
 	oauth := oauth.NewOauth(oauth.OAuthArgs{
-		Config: s.configFn,
-		Log:    s.log,
-		ErrCh:  make(chan *models.InternalError),
+		Config:            s.configFn,
+		SrvCfg:            s.cfg,
+		Store:             s.dbStore,
+		LoginAcceptor:     loginAcceptor,
+		Log:               s.log,
+		ErrCh:             make(chan *models.InternalError),
+		EmailDomainPolicy: s.emailDomainPolicyGet,
 	})
 
 	if err := oauth.Run(); err != nil {
@@ -129,3 +138,99 @@ func (s *Server) initOauthServer() {
 		}
 	}()
 }
+
+func (s *Server) initPasswordHasher() {
+	h, err := intModels.PasswordHasherNew(&s.cfg.Auth)
+	if err != nil {
+		s.errors <- &models.InternalError{Err: err, Msg: "failed to init the password hasher", Path: "user.server.initPasswordHasher"}
+		return
+	}
+	s.passwordHasher = h
+}
+
+func (s *Server) initBreachedPasswords() {
+	path := s.cfg.Auth.BreachedPasswordsFile
+	if path == "" {
+		return
+	}
+
+	b, err := intModels.BreachedPasswordsOpen(path)
+	if err != nil {
+		s.errors <- &models.InternalError{
+			Err:  err,
+			Msg:  "failed to open the breached passwords file",
+			Path: "user.server.initBreachedPasswords",
+		}
+		return
+	}
+	s.breachedPasswords = b
+}
+
+// initEmailDomainPolicy sets the email domains policy of the signup, with the disposable
+// domains list if configured, the policy is reloaded with the service config file
+func (s *Server) initEmailDomainPolicy() {
+	if err := s.emailDomainPolicyLoad(&s.cfg.EmailDomain); err != nil {
+		s.errors <- err
+	}
+}
+
+func (s *Server) emailDomainPolicyLoad(cfg *intModels.EmailDomain) *models.InternalError {
+	var disposable []string
+	if cfg.DisposableFile != "" {
+		list, err := intModels.EmailDomainListOpen(cfg.DisposableFile)
+		if err != nil {
+			return &models.InternalError{
+				Err:  err,
+				Msg:  "failed to open the disposable email domains file",
+				Path: "user.server.emailDomainPolicyLoad",
+			}
+		}
+		disposable = list
+	}
+
+	policy := intModels.EmailDomainPolicyNew(&intModels.EmailDomainPolicyArgs{
+		Config:     cfg,
+		Disposable: disposable,
+		Resolver:   net.DefaultResolver,
+	})
+	s.emailDomainPolicyMux.Lock()
+	s.emailDomainPolicy = policy
+	s.emailDomainPolicyMux.Unlock()
+	return nil
+}
+
+func (s *Server) emailDomainPolicyGet() *intModels.EmailDomainPolicy {
+	s.emailDomainPolicyMux.RLock()
+	defer s.emailDomainPolicyMux.RUnlock()
+	return s.emailDomainPolicy
+}
+
+// initRateLimiter creates the rate limiter of the grpc methods, its state is kept in the
+// cache Redis, the limiter falls back to memory while Redis is unreachable
+func (s *Server) initRateLimiter() {
+	if !s.cfg.RateLimit.Enabled {
+		return
+	}
+
+	var client redis.UniversalClient
+	u, err := url.Parse(s.config.GetCache().GetRedisAddress())
+	if err != nil {
+		s.log.Warnf("failed to parse the redis connection URL, the rate limits are kept in memory: %v", err)
+	} else {
+		client = redis.NewClient(&redis.Options{
+			Addr:         u.Host,
+			Password:     s.config.Cache.GetRedisPassword(),
+			DB:           int(s.config.Cache.GetRedisDb()),
+			DialTimeout:  time.Second * 2,
+			ReadTimeout:  time.Second,
+			WriteTimeout: time.Second,
+		})
+	}
+
+	limiter, limErr := ratelimit.NewLimiter(&ratelimit.LimiterArgs{Config: &s.cfg.RateLimit, Redis: client, Log: s.log, TrustedProxies: s.cfg.Service.TrustedProxies})
+	if limErr != nil {
+		s.errors <- limErr
+		return
+	}
+	s.rateLimiter = limiter
+}
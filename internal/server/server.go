@@ -12,6 +12,7 @@ import (
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/common"
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/controller"
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/mailer"
+	"github.com/ahmad-khatib0-org/megacommerce-user/internal/ratelimit"
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/store"
 	"github.com/ahmad-khatib0-org/megacommerce-user/internal/worker"
 	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
@@ -33,6 +34,14 @@ type Server struct {
 	dbStore        store.UsersStore
 	mailer         mailer.MailerService
 	tasker         worker.TaskDistributor
+	cfg            *intModels.Config
+	rateLimiter    *ratelimit.Limiter
+	passwordHasher intModels.PasswordHasher
+	// breachedPasswords is nil if no breached passwords corpus is configured
+	breachedPasswords *intModels.BreachedPasswords
+	// emailDomainPolicy is replaced when the service config file changes
+	emailDomainPolicyMux sync.RWMutex
+	emailDomainPolicy    *intModels.EmailDomainPolicy
 }
 
 type ServerArgs struct {
@@ -46,6 +55,7 @@ func RunServer(s *ServerArgs) error {
 		commonClient: com,
 		errors:       make(chan *models.InternalError, 1),
 		log:          s.Log,
+		cfg:          s.Cfg,
 	}
 
 	if err != nil {
@@ -56,6 +66,10 @@ func RunServer(s *ServerArgs) error {
 
 	app.initSharedConfig()
 	app.initTrans()
+	app.initPasswordHasher()
+	app.initBreachedPasswords()
+	app.initEmailDomainPolicy()
+	app.watchServiceConfig()
 	app.initObjectStorage()
 
 	app.initDB()
@@ -63,18 +77,25 @@ func RunServer(s *ServerArgs) error {
 	app.initStore()
 	app.initMailer()
 	app.initWorker()
-	app.initOauthServer()
+	app.initRateLimiter()
 
-	_, err = controller.NewController(&controller.ControllerArgs{
-		Config:         app.configFn,
-		Store:          app.dbStore,
-		ObjStorage:     app.objectStorage,
-		TracerProvider: app.tracerProvider,
-		Log:            app.log,
-		Tasker:         app.tasker,
+	ctrl, err := controller.NewController(&controller.ControllerArgs{
+		Config:            app.configFn,
+		Store:             app.dbStore,
+		ObjStorage:        app.objectStorage,
+		TracerProvider:    app.tracerProvider,
+		Log:               app.log,
+		Tasker:            app.tasker,
+		SrvCfg:            app.cfg,
+		RateLimiter:       app.rateLimiter,
+		PasswordHasher:    app.passwordHasher,
+		BreachedPasswords: app.breachedPasswords,
+		EmailDomainPolicy: app.emailDomainPolicyGet,
 	})
 	if err != nil {
 		app.errors <- err
+	} else {
+		app.initOauthServer(ctrl)
 	}
 
 	err = <-app.errors
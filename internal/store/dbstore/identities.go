@@ -0,0 +1,107 @@
+package dbstore
+
+import (
+	"fmt"
+
+	usersPb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const identitiesInsertStatement = `
+	INSERT INTO user_identities(id, user_id, provider, subject, email, created_at) VALUES($1, $2, $3, $4, $5, $6)
+`
+
+func identitiesInsertArgs(i *intModels.Identity) []any {
+	return []any{i.ID, i.UserID, i.Provider, i.Subject, i.Email, i.CreatedAt}
+}
+
+// UsersGetByIdentity returns the user owning the provider account
+func (ds *DBStore) UsersGetByIdentity(ctx *models.Context, provider, subject string) (*usersPb.User, *models.DBError) {
+	path := "users.store.UsersGetByIdentity"
+	where := "WHERE id = (SELECT user_id FROM user_identities WHERE provider = $1 AND subject = $2)"
+	row := ds.db.QueryRow(ctx.Context, fmt.Sprintf("%s %s", SelectUserStatment, where), provider, subject)
+
+	return ds.scanUser(ctx, row, path)
+}
+
+// IdentitiesGetByUserID returns the identities of the user, oldest first
+func (ds *DBStore) IdentitiesGetByUserID(ctx *models.Context, userID string) ([]*intModels.Identity, *models.DBError) {
+	path := "users.store.IdentitiesGetByUserID"
+	stmt := `
+	  SELECT id, user_id, provider, subject, email, created_at
+	  FROM user_identities WHERE user_id = $1 ORDER BY created_at
+	`
+
+	rows, err := ds.db.Query(ctx.Context, stmt, userID)
+	if err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+	defer rows.Close()
+
+	result := []*intModels.Identity{}
+	for rows.Next() {
+		i := &intModels.Identity{}
+		if err := rows.Scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt); err != nil {
+			return nil, models.HandleDBError(ctx, err, path, nil)
+		}
+		result = append(result, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+
+	return result, nil
+}
+
+// IdentitiesAdd links the identity to its user, DBErrorTypeUniqueViolation means the
+// provider account is already linked (to this user or another one)
+func (ds *DBStore) IdentitiesAdd(ctx *models.Context, i *intModels.Identity) *models.DBError {
+	path := "users.store.IdentitiesAdd"
+	_, err := ds.db.Exec(ctx.Context, identitiesInsertStatement, identitiesInsertArgs(i)...)
+
+	return models.HandleDBError(ctx, err, path, nil)
+}
+
+// IdentitiesDelete unlinks the identity of the user unless it's the last way the user can
+// sign in with, the login methods are counted under a lock of the user row, so concurrent
+// unlinks can't remove them all, false is returned for the last login method,
+// DBErrorTypeNoRows means the user has no such identity
+func (ds *DBStore) IdentitiesDelete(ctx *models.Context, userID, identityID string) (bool, *models.DBError) {
+	path := "users.store.IdentitiesDelete"
+	tr, err := ds.db.BeginTx(ctx.Context, pgx.TxOptions{})
+	if err != nil {
+		return false, models.StartTransactionError(err, path)
+	}
+
+	stmt := `
+	  SELECT
+	    COALESCE(password, '') <> '',
+	    (SELECT COUNT(*) FROM user_identities WHERE user_id = users.id),
+	    (SELECT COUNT(*) FROM webauthn_credentials WHERE user_id = users.id)
+	  FROM users WHERE id = $1 FOR UPDATE
+	`
+	var hasPassword bool
+	var identities, passkeys int
+	if err := tr.QueryRow(ctx.Context, stmt, userID).Scan(&hasPassword, &identities, &passkeys); err != nil {
+		return false, models.HandleDBError(ctx, err, path, tr)
+	}
+	if !intModels.IdentityCanUnlink(hasPassword, identities, passkeys) {
+		if err := tr.Rollback(ctx.Context); err != nil {
+			return false, models.HandleDBError(ctx, err, path, nil)
+		}
+		return false, nil
+	}
+
+	var id string
+	stmt = `DELETE FROM user_identities WHERE id = $1 AND user_id = $2 RETURNING id`
+	if err := tr.QueryRow(ctx.Context, stmt, identityID, userID).Scan(&id); err != nil {
+		return false, models.HandleDBError(ctx, err, path, tr)
+	}
+
+	if err := tr.Commit(ctx.Context); err != nil {
+		return false, models.CommitTransactionError(err, path)
+	}
+	return true, nil
+}
@@ -0,0 +1,43 @@
+package dbstore
+
+import (
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// UserLoginDevicesAdd stores the login device, and returns what was known about it before,
+// so the caller can tell whether the login came from a new device or network
+func (ds *DBStore) UserLoginDevicesAdd(ctx *models.Context, d *intModels.LoginDevice) (*intModels.LoginDeviceHistory, *models.DBError) {
+	path := "users.store.UserLoginDevicesAdd"
+	tr, err := ds.db.BeginTx(ctx.Context, pgx.TxOptions{})
+	if err != nil {
+		return nil, models.StartTransactionError(err, path)
+	}
+
+	stmt := `
+	  SELECT
+	    EXISTS(SELECT 1 FROM user_login_devices WHERE user_id = $1),
+	    EXISTS(SELECT 1 FROM user_login_devices WHERE user_id = $1 AND fingerprint = $2),
+	    EXISTS(SELECT 1 FROM user_login_devices WHERE user_id = $1 AND ip_range = $3)
+	`
+
+	h := &intModels.LoginDeviceHistory{}
+	if err := tr.QueryRow(ctx.Context, stmt, d.UserID, d.Fingerprint, d.IPRange).Scan(&h.Any, &h.Fingerprint, &h.IPRange); err != nil {
+		return nil, models.HandleDBError(ctx, err, path, tr)
+	}
+
+	stmt = `
+	  INSERT INTO user_login_devices(user_id, fingerprint, ip_range, created_at, last_seen_at)
+	  VALUES($1, $2, $3, $4, $5)
+	  ON CONFLICT (user_id, fingerprint, ip_range) DO UPDATE SET last_seen_at = EXCLUDED.last_seen_at
+	`
+	if _, err := tr.Exec(ctx.Context, stmt, d.UserID, d.Fingerprint, d.IPRange, d.CreatedAt, d.LastSeenAt); err != nil {
+		return nil, models.HandleDBError(ctx, err, path, tr)
+	}
+
+	if err := tr.Commit(ctx.Context); err != nil {
+		return nil, models.CommitTransactionError(err, path)
+	}
+	return h, nil
+}
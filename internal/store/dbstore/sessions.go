@@ -0,0 +1,88 @@
+package dbstore
+
+import (
+	"time"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+// UserSessionsAdd stores the session, the OAuth server reuses the login session id when
+// the user logs in again from a remembered device, the device details are refreshed then
+func (ds *DBStore) UserSessionsAdd(ctx *models.Context, s *intModels.UserSession) *models.DBError {
+	path := "users.store.UserSessionsAdd"
+	stmt := `
+	  INSERT INTO user_sessions(id, user_id, device_id, platform, user_agent, ip_address, created_at, last_seen_at)
+	  VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+	  ON CONFLICT (id) DO UPDATE SET
+	    device_id = EXCLUDED.device_id,
+	    platform = EXCLUDED.platform,
+	    user_agent = EXCLUDED.user_agent,
+	    ip_address = EXCLUDED.ip_address,
+	    last_seen_at = EXCLUDED.last_seen_at
+	  WHERE user_sessions.user_id = EXCLUDED.user_id
+	`
+
+	args := []any{s.ID, s.UserID, s.DeviceID, s.Platform, s.UserAgent, s.IPAddress, s.CreatedAt, s.LastSeenAt}
+	_, err := ds.db.Exec(ctx.Context, stmt, args...)
+
+	return models.HandleDBError(ctx, err, path, nil)
+}
+
+// UserSessionsGetByUserID returns the sessions of the user, the most recently seen first
+func (ds *DBStore) UserSessionsGetByUserID(ctx *models.Context, userID string) ([]*intModels.UserSession, *models.DBError) {
+	path := "users.store.UserSessionsGetByUserID"
+	stmt := `
+	  SELECT id, user_id, device_id, platform, user_agent, ip_address, created_at, last_seen_at
+	  FROM user_sessions WHERE user_id = $1 ORDER BY last_seen_at DESC
+	`
+
+	rows, err := ds.db.Query(ctx.Context, stmt, userID)
+	if err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+	defer rows.Close()
+
+	result := []*intModels.UserSession{}
+	for rows.Next() {
+		s := &intModels.UserSession{}
+		if err := rows.Scan(&s.ID, &s.UserID, &s.DeviceID, &s.Platform, &s.UserAgent, &s.IPAddress, &s.CreatedAt, &s.LastSeenAt); err != nil {
+			return nil, models.HandleDBError(ctx, err, path, nil)
+		}
+		result = append(result, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+
+	return result, nil
+}
+
+// UserSessionsTouch updates the last time the session was seen, unless it was updated
+// within the interval
+func (ds *DBStore) UserSessionsTouch(ctx *models.Context, userID, sessionID string, at int64, interval time.Duration) *models.DBError {
+	path := "users.store.UserSessionsTouch"
+	stmt := `UPDATE user_sessions SET last_seen_at = $1 WHERE id = $2 AND user_id = $3 AND last_seen_at <= $4`
+	_, err := ds.db.Exec(ctx.Context, stmt, at, sessionID, userID, at-interval.Milliseconds())
+
+	return models.HandleDBError(ctx, err, path, nil)
+}
+
+// UserSessionsDelete removes the session of the user, DBErrorTypeNoRows means the user has no such session
+func (ds *DBStore) UserSessionsDelete(ctx *models.Context, userID, sessionID string) *models.DBError {
+	path := "users.store.UserSessionsDelete"
+	stmt := `DELETE FROM user_sessions WHERE id = $1 AND user_id = $2 RETURNING id`
+
+	var id string
+	err := ds.db.QueryRow(ctx.Context, stmt, sessionID, userID).Scan(&id)
+	return models.HandleDBError(ctx, err, path, nil)
+}
+
+// UserSessionsDeleteAll removes the sessions of the user, except the one of keepID (if not empty)
+func (ds *DBStore) UserSessionsDeleteAll(ctx *models.Context, userID, keepID string) *models.DBError {
+	path := "users.store.UserSessionsDeleteAll"
+	stmt := `DELETE FROM user_sessions WHERE user_id = $1 AND id <> $2`
+	_, err := ds.db.Exec(ctx.Context, stmt, userID, keepID)
+
+	return models.HandleDBError(ctx, err, path, nil)
+}
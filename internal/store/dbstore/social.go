@@ -0,0 +1,103 @@
+package dbstore
+
+import (
+	"encoding/json"
+
+	usersPb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// UsersSocialCreate stores a user signed up through a social provider along with the
+// identity, unlike the regular signups no email confirmation token is issued, the
+// provider verified the email
+func (ds *DBStore) UsersSocialCreate(ctx *models.Context, u *usersPb.User, identity *intModels.Identity) *models.DBError {
+	path := "users.store.UsersSocialCreate"
+	tr, err := ds.db.BeginTx(ctx.Context, pgx.TxOptions{})
+	if err != nil {
+		return models.StartTransactionError(err, path)
+	}
+
+	stmt := `
+	  INSERT INTO users(
+			id,
+			username,
+			first_name,
+			last_name,
+			email,
+			user_type,
+			membership,
+			is_email_verified,
+			password,
+			auth_data,
+			auth_service,
+			roles,
+			locale,
+			is_mfa_active,
+			created_at
+	  ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	args := []any{
+		u.GetId(),
+		u.GetUsername(),
+		u.GetFirstName(),
+		u.GetLastName(),
+		u.GetEmail(),
+		u.GetUserType(),
+		u.GetMembership(),
+		u.GetIsEmailVerified(),
+		u.GetPassword(),
+		u.GetAuthData(),
+		u.GetAuthService(),
+		u.GetRoles(),
+		u.GetLocale(),
+		u.GetMfaActive(),
+		u.GetCreatedAt(),
+	}
+
+	if _, err := tr.Exec(ctx.Context, stmt, args...); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	if _, err := tr.Exec(ctx.Context, identitiesInsertStatement, identitiesInsertArgs(identity)...); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	if err := tr.Commit(ctx.Context); err != nil {
+		return models.CommitTransactionError(err, path)
+	}
+	return nil
+}
+
+func (ds *DBStore) SocialLoginStatesAdd(ctx *models.Context, s *intModels.SocialLoginState) *models.DBError {
+	path := "users.store.SocialLoginStatesAdd"
+	data, err := json.Marshal(s)
+	if err != nil {
+		return models.JSONMarshalError(err, path, "an error occurred while trying to encode social_login_states.data")
+	}
+
+	stmt := `INSERT INTO social_login_states(id, data, expires_at) VALUES($1, $2, $3)`
+	_, err = ds.db.Exec(ctx.Context, stmt, s.ID, data, s.ExpiresAt)
+
+	return models.HandleDBError(ctx, err, path, nil)
+}
+
+// SocialLoginStatesTake deletes and returns the state, so a callback can only be handled once
+func (ds *DBStore) SocialLoginStatesTake(ctx *models.Context, id string) (*intModels.SocialLoginState, *models.DBError) {
+	path := "users.store.SocialLoginStatesTake"
+	stmt := `DELETE FROM social_login_states WHERE id = $1 RETURNING data`
+
+	var data []byte
+	if err := ds.db.QueryRow(ctx.Context, stmt, id).Scan(&data); err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+
+	s := &intModels.SocialLoginState{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, models.JSONUnmarshalError(err, path, "an error occurred while trying to decode social_login_states.data")
+	}
+
+	return s, nil
+}
@@ -5,13 +5,52 @@ import (
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
 	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/jackc/pgx/v5"
 )
 
-func (ds *DBStore) MarkEmailAsConfirmed(ctx *models.Context, tokenID string) *models.DBError {
-	stmt := `UPDATE tokens SET used = TRUE WHERE id = $1`
-	_, err := ds.db.Exec(ctx.Context, stmt, tokenID)
+// MarkEmailAsConfirmed consumes the email confirmation token and marks the email of its owner
+// as verified in one transaction, the token must belong to the user having the given email
+func (ds *DBStore) MarkEmailAsConfirmed(ctx *models.Context, tokenID, email string) *models.DBError {
+	path := "users.store.MarkEmailAsConfirmed"
+	tr, err := ds.db.BeginTx(ctx.Context, pgx.TxOptions{})
+	if err != nil {
+		return models.StartTransactionError(err, path)
+	}
+
+	stmt := `
+	  UPDATE tokens SET used = TRUE
+	  WHERE id = $1 AND type = $2 AND user_id IN (SELECT id FROM users WHERE LOWER(email) = LOWER($3))
+	  RETURNING user_id
+	`
+	var userID string
+	if err := tr.QueryRow(ctx.Context, stmt, tokenID, string(intModels.TokenTypeEmailConfirmation), email).Scan(&userID); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	stmt = `UPDATE users SET is_email_verified = TRUE, updated_at = $2 WHERE id = $1`
+	if _, err := tr.Exec(ctx.Context, stmt, userID, utils.TimeGetMillis()); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	if err := tr.Commit(ctx.Context); err != nil {
+		return models.CommitTransactionError(err, path)
+	}
+	return nil
+}
 
-	return models.HandleDBError(ctx, err, "users.store.MarkEmailAsConfirmed", nil)
+// TokensMarkUsed consumes the token, it returns DBErrorTypeNoRows if the token doesn't exist
+// or is already used, so only one of two concurrent requests with the same token succeeds
+func (ds *DBStore) TokensMarkUsed(ctx *models.Context, tokenID string) *models.DBError {
+	path := "users.store.TokensMarkUsed"
+	stmt := `UPDATE tokens SET used = TRUE WHERE id = $1 AND used = FALSE`
+	res, err := ds.db.Exec(ctx.Context, stmt, tokenID)
+	if err != nil {
+		return models.HandleDBError(ctx, err, path, nil)
+	}
+	if res.RowsAffected() == 0 {
+		return models.HandleDBError(ctx, pgx.ErrNoRows, path, nil)
+	}
+	return nil
 }
 
 func (ds *DBStore) TokensGet(ctx *models.Context, tokenID string) (*pb.Token, *models.DBError) {
@@ -86,3 +125,127 @@ func (ds *DBStore) TokensDeleteAllPasswordResetByUserID(ctx *models.Context, use
 
 	return res.RowsAffected(), nil
 }
+
+// TokensGetUnusedByType returns the unused and unexpired tokens of the given type for a user
+func (ds *DBStore) TokensGetUnusedByType(ctx *models.Context, userID string, tokenType intModels.TokenType) ([]*pb.Token, *models.DBError) {
+	path := "users.store.TokensGetUnusedByType"
+	stmt := `
+	  SELECT id, user_id, token, type, used, created_at, expires_at FROM tokens
+	  WHERE user_id = $1 AND type = $2 AND used = FALSE AND expires_at > $3
+	`
+
+	rows, err := ds.db.Query(ctx.Context, stmt, userID, string(tokenType), utils.TimeGetMillis())
+	if err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+	defer rows.Close()
+
+	result := []*pb.Token{}
+	for rows.Next() {
+		t := &pb.Token{}
+		if err := rows.Scan(&t.Id, &t.UserId, &t.Token, &t.Type, &t.Used, &t.CreatedAt, &t.ExpiresAt); err != nil {
+			return nil, models.HandleDBError(ctx, err, path, nil)
+		}
+		result = append(result, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+
+	return result, nil
+}
+
+// TokensMfaRecoveryCodesReplace removes the existing recovery codes of the user and stores the new ones
+func (ds *DBStore) TokensMfaRecoveryCodesReplace(ctx *models.Context, userID string, tokens []*utils.Token) *models.DBError {
+	path := "users.store.TokensMfaRecoveryCodesReplace"
+	tr, err := ds.db.BeginTx(ctx.Context, pgx.TxOptions{})
+	if err != nil {
+		return models.StartTransactionError(err, path)
+	}
+
+	stmt := `DELETE FROM tokens WHERE user_id = $1 AND type = $2`
+	if _, err := tr.Exec(ctx.Context, stmt, userID, string(intModels.TokenTypeMfaRecoveryCode)); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	stmt = `INSERT INTO tokens(id, user_id, token, type, created_at, expires_at) VALUES($1, $2, $3, $4, $5, $6)`
+	now := utils.TimeGetMillis()
+	for _, t := range tokens {
+		args := []any{t.ID, userID, string(t.Hash), string(intModels.TokenTypeMfaRecoveryCode), now, utils.TimeGetMillisFromTime(t.Expiry)}
+		if _, err := tr.Exec(ctx.Context, stmt, args...); err != nil {
+			return models.HandleDBError(ctx, err, path, tr)
+		}
+	}
+
+	if err := tr.Commit(ctx.Context); err != nil {
+		return models.CommitTransactionError(err, path)
+	}
+	return nil
+}
+
+// TokensGetCreatedAtSince returns the creation times (in millis) of the user's tokens of
+// the given type that are created after since, the expired and used tokens are included
+func (ds *DBStore) TokensGetCreatedAtSince(ctx *models.Context, userID string, tokenType intModels.TokenType, since int64) ([]int64, *models.DBError) {
+	path := "users.store.TokensGetCreatedAtSince"
+	stmt := `SELECT created_at FROM tokens WHERE user_id = $1 AND type = $2 AND created_at > $3`
+
+	rows, err := ds.db.Query(ctx.Context, stmt, userID, string(tokenType), since)
+	if err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+	defer rows.Close()
+
+	result := []int64{}
+	for rows.Next() {
+		var createdAt int64
+		if err := rows.Scan(&createdAt); err != nil {
+			return nil, models.HandleDBError(ctx, err, path, nil)
+		}
+		result = append(result, createdAt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+
+	return result, nil
+}
+
+// TokensRotate expires the outstanding tokens of the given type of the user and stores the
+// new one, so only the latest issued token can be redeemed, the old tokens are kept (not
+// deleted) so they still count towards the issue limits (E,g the resend cooldown)
+func (ds *DBStore) TokensRotate(ctx *models.Context, userID string, token *utils.Token, tokenType intModels.TokenType) *models.DBError {
+	path := "users.store.TokensRotate"
+	tr, err := ds.db.BeginTx(ctx.Context, pgx.TxOptions{})
+	if err != nil {
+		return models.StartTransactionError(err, path)
+	}
+
+	now := utils.TimeGetMillis()
+	stmt := `UPDATE tokens SET expires_at = $3 WHERE user_id = $1 AND type = $2 AND used = FALSE AND expires_at > $3`
+	if _, err := tr.Exec(ctx.Context, stmt, userID, string(tokenType), now); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	stmt = `INSERT INTO tokens(id, user_id, token, type, created_at, expires_at) VALUES($1, $2, $3, $4, $5, $6)`
+	args := []any{token.ID, userID, string(token.Hash), string(tokenType), now, utils.TimeGetMillisFromTime(token.Expiry)}
+	if _, err := tr.Exec(ctx.Context, stmt, args...); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	if err := tr.Commit(ctx.Context); err != nil {
+		return models.CommitTransactionError(err, path)
+	}
+	return nil
+}
+
+// TokensAttemptsIncrement increments the failed redemption attempts of the token and returns the new count
+func (ds *DBStore) TokensAttemptsIncrement(ctx *models.Context, tokenID string) (int32, *models.DBError) {
+	stmt := `UPDATE tokens SET attempts = COALESCE(attempts, 0) + 1 WHERE id = $1 RETURNING attempts`
+
+	var attempts int32
+	if err := ds.db.QueryRow(ctx.Context, stmt, tokenID).Scan(&attempts); err != nil {
+		return 0, models.HandleDBError(ctx, err, "users.store.TokensAttemptsIncrement", nil)
+	}
+
+	return attempts, nil
+}
@@ -6,6 +6,8 @@ import (
 
 	usersPb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -41,13 +43,61 @@ const SelectUserStatment = `
 	FROM users
 `
 
+// UsersGetByEmail matches the email case insensitively, the signup stores the emails lower
+// cased, an exact match wins over the accounts created before that
 func (ds *DBStore) UsersGetByEmail(ctx *models.Context, email string) (*usersPb.User, *models.DBError) {
 	path := "users.store.UserGetByEmail"
-	row := ds.db.QueryRow(ctx.Context, fmt.Sprintf("%s %s", SelectUserStatment, "WHERE email = $1"), email)
+	where := "WHERE LOWER(email) = LOWER($1) ORDER BY (email = $1) DESC LIMIT 1"
+	row := ds.db.QueryRow(ctx.Context, fmt.Sprintf("%s %s", SelectUserStatment, where), email)
 
 	return ds.scanUser(ctx, row, path)
 }
 
+// UsersGetByUsername matches the username case insensitively, signup treats usernames that
+// differ only in case as distinct, so an exact match wins over the others, and a username
+// matching several users in case only is ambiguous (DBErrorTypeNoRows)
+func (ds *DBStore) UsersGetByUsername(ctx *models.Context, username string) (*usersPb.User, *models.DBError) {
+	path := "users.store.UsersGetByUsername"
+	where := "WHERE LOWER(username) = LOWER($1) ORDER BY (username = $1) DESC LIMIT 2"
+	rows, err := ds.db.Query(ctx.Context, fmt.Sprintf("%s %s", SelectUserStatment, where), username)
+	if err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+	defer rows.Close()
+
+	users := []*usersPb.User{}
+	for rows.Next() {
+		user, dbErr := ds.scanUser(ctx, rows, path)
+		if dbErr != nil {
+			return nil, dbErr
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+
+	user := usersUsernameMatch(users, username)
+	if user == nil {
+		return nil, models.HandleDBError(ctx, pgx.ErrNoRows, path, nil)
+	}
+	return user, nil
+}
+
+// usersUsernameMatch picks the user of the username among the case insensitive matches, the
+// exact match, or the only match, nil if there is none or the match is ambiguous
+func usersUsernameMatch(users []*usersPb.User, username string) *usersPb.User {
+	for _, u := range users {
+		if u.GetUsername() == username {
+			return u
+		}
+	}
+	if len(users) == 1 {
+		return users[0]
+	}
+	return nil
+}
+
 func (ds *DBStore) UsersGetByID(ctx *models.Context, userID string) (*usersPb.User, *models.DBError) {
 	path := "users.store.UsersGetByID"
 	row := ds.db.QueryRow(ctx.Context, fmt.Sprintf("%s %s", SelectUserStatment, "WHERE id = $1"), userID)
@@ -124,3 +174,228 @@ func (ds *DBStore) scanUser(ctx *models.Context, row pgx.Row, path string) (*use
 
 	return user, nil
 }
+
+// UsersGetLockedUntil returns the time (in millis) the user's account is locked until, or 0
+func (ds *DBStore) UsersGetLockedUntil(ctx *models.Context, userID string) (int64, *models.DBError) {
+	stmt := `SELECT COALESCE(locked_until, 0) FROM users WHERE id = $1`
+
+	var lockedUntil int64
+	if err := ds.db.QueryRow(ctx.Context, stmt, userID).Scan(&lockedUntil); err != nil {
+		return 0, models.HandleDBError(ctx, err, "users.store.UsersGetLockedUntil", nil)
+	}
+
+	return lockedUntil, nil
+}
+
+// UsersFailedAttemptsIncrement increments the failed login attempts and returns the new count
+func (ds *DBStore) UsersFailedAttemptsIncrement(ctx *models.Context, userID string) (int32, *models.DBError) {
+	stmt := `UPDATE users SET failed_attempts = COALESCE(failed_attempts, 0) + 1 WHERE id = $1 RETURNING failed_attempts`
+
+	var attempts int32
+	if err := ds.db.QueryRow(ctx.Context, stmt, userID).Scan(&attempts); err != nil {
+		return 0, models.HandleDBError(ctx, err, "users.store.UsersFailedAttemptsIncrement", nil)
+	}
+
+	return attempts, nil
+}
+
+// UsersLock locks the user's account until the given time (in millis)
+func (ds *DBStore) UsersLock(ctx *models.Context, userID string, until int64) *models.DBError {
+	stmt := `UPDATE users SET locked_until = $2 WHERE id = $1`
+	_, err := ds.db.Exec(ctx.Context, stmt, userID, until)
+
+	return models.HandleDBError(ctx, err, "users.store.UsersLock", nil)
+}
+
+// UsersLoginSucceeded resets the failed login attempts and the lockout of the user, and
+// marks the last login time
+func (ds *DBStore) UsersLoginSucceeded(ctx *models.Context, userID string) *models.DBError {
+	stmt := `UPDATE users SET failed_attempts = 0, locked_until = NULL, last_login = $2 WHERE id = $1`
+	_, err := ds.db.Exec(ctx.Context, stmt, userID, utils.TimeGetMillis())
+
+	return models.HandleDBError(ctx, err, "users.store.UsersLoginSucceeded", nil)
+}
+
+// UsersMfaSecretSet stores a pending (not yet activated) MFA secret for the user
+func (ds *DBStore) UsersMfaSecretSet(ctx *models.Context, userID string, secret string) *models.DBError {
+	stmt := `UPDATE users SET mfa_secret = $2, is_mfa_active = FALSE, mfa_last_counter = NULL, updated_at = $3 WHERE id = $1`
+	_, err := ds.db.Exec(ctx.Context, stmt, userID, secret, utils.TimeGetMillis())
+
+	return models.HandleDBError(ctx, err, "users.store.UsersMfaSecretSet", nil)
+}
+
+func (ds *DBStore) UsersMfaActivate(ctx *models.Context, userID string) *models.DBError {
+	stmt := `UPDATE users SET is_mfa_active = TRUE, updated_at = $2 WHERE id = $1`
+	_, err := ds.db.Exec(ctx.Context, stmt, userID, utils.TimeGetMillis())
+
+	return models.HandleDBError(ctx, err, "users.store.UsersMfaActivate", nil)
+}
+
+// UsersMfaCounterAdvance stores the time step of the last accepted TOTP code, it returns
+// DBErrorTypeNoRows if the counter isn't greater than the stored one (a replayed code).
+// The condition and the update are a single statement, so two concurrent requests with
+// the same code can't both succeed
+func (ds *DBStore) UsersMfaCounterAdvance(ctx *models.Context, userID string, counter int64) *models.DBError {
+	path := "users.store.UsersMfaCounterAdvance"
+	stmt := `
+	  UPDATE users SET mfa_last_counter = $2
+	  WHERE id = $1 AND (mfa_last_counter IS NULL OR mfa_last_counter < $2)
+	`
+	res, err := ds.db.Exec(ctx.Context, stmt, userID, counter)
+	if err != nil {
+		return models.HandleDBError(ctx, err, path, nil)
+	}
+	if res.RowsAffected() == 0 {
+		return models.HandleDBError(ctx, pgx.ErrNoRows, path, nil)
+	}
+	return nil
+}
+
+// UsersMfaDeactivate disables MFA and removes the user's secret and recovery codes
+func (ds *DBStore) UsersMfaDeactivate(ctx *models.Context, userID string) *models.DBError {
+	path := "users.store.UsersMfaDeactivate"
+	tr, err := ds.db.BeginTx(ctx.Context, pgx.TxOptions{})
+	if err != nil {
+		return models.StartTransactionError(err, path)
+	}
+
+	stmt := `UPDATE users SET is_mfa_active = FALSE, mfa_secret = NULL, mfa_last_counter = NULL, updated_at = $2 WHERE id = $1`
+	if _, err := tr.Exec(ctx.Context, stmt, userID, utils.TimeGetMillis()); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	stmt = `DELETE FROM tokens WHERE user_id = $1 AND type = $2`
+	if _, err := tr.Exec(ctx.Context, stmt, userID, string(intModels.TokenTypeMfaRecoveryCode)); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	if err := tr.Commit(ctx.Context); err != nil {
+		return models.CommitTransactionError(err, path)
+	}
+	return nil
+}
+
+// UsersPasswordReset sets the new password of the user and consumes the reset token, all
+// the other password reset tokens of the user are removed in the same transaction, the
+// previous password is kept in the history (see usersPasswordHistoryArchive)
+func (ds *DBStore) UsersPasswordReset(ctx *models.Context, userID, tokenID, password string, historySize int) *models.DBError {
+	path := "users.store.UsersPasswordReset"
+	tr, err := ds.db.BeginTx(ctx.Context, pgx.TxOptions{})
+	if err != nil {
+		return models.StartTransactionError(err, path)
+	}
+
+	// the used = FALSE condition guards against two concurrent resets with the same token
+	stmt := `UPDATE tokens SET used = TRUE WHERE id = $1 AND user_id = $2 AND type = $3 AND used = FALSE`
+	res, err := tr.Exec(ctx.Context, stmt, tokenID, userID, string(intModels.TokenTypePasswordReset))
+	if err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+	if res.RowsAffected() == 0 {
+		return models.HandleDBError(ctx, pgx.ErrNoRows, path, tr)
+	}
+
+	now := utils.TimeGetMillis()
+	if err := ds.usersPasswordHistoryArchive(ctx, tr, userID, historySize, now); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	stmt = `UPDATE users SET password = $2, last_password_update = $3, updated_at = $3 WHERE id = $1`
+	if _, err := tr.Exec(ctx.Context, stmt, userID, password, now); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	stmt = `DELETE FROM tokens WHERE user_id = $1 AND type = $2 AND id <> $3`
+	if _, err := tr.Exec(ctx.Context, stmt, userID, string(intModels.TokenTypePasswordReset), tokenID); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	if err := tr.Commit(ctx.Context); err != nil {
+		return models.CommitTransactionError(err, path)
+	}
+	return nil
+}
+
+// UsersPasswordUpdate sets the new password of the user, the previous password is kept
+// in the history (see usersPasswordHistoryArchive)
+func (ds *DBStore) UsersPasswordUpdate(ctx *models.Context, userID, password string, historySize int) *models.DBError {
+	path := "users.store.UsersPasswordUpdate"
+	tr, err := ds.db.BeginTx(ctx.Context, pgx.TxOptions{})
+	if err != nil {
+		return models.StartTransactionError(err, path)
+	}
+
+	now := utils.TimeGetMillis()
+	if err := ds.usersPasswordHistoryArchive(ctx, tr, userID, historySize, now); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	stmt := `UPDATE users SET password = $2, last_password_update = $3, updated_at = $3 WHERE id = $1`
+	if _, err := tr.Exec(ctx.Context, stmt, userID, password, now); err != nil {
+		return models.HandleDBError(ctx, err, path, tr)
+	}
+
+	if err := tr.Commit(ctx.Context); err != nil {
+		return models.CommitTransactionError(err, path)
+	}
+	return nil
+}
+
+// usersPasswordHistoryArchive moves the current password hash of the user to the history, and
+// trims the history to keep the historySize - 1 latest entries (the current password is
+// the remaining one), a historySize less than 2 clears the history
+func (ds *DBStore) usersPasswordHistoryArchive(ctx *models.Context, tr pgx.Tx, userID string, historySize int, now int64) error {
+	keep := max(historySize-1, 0)
+	if keep > 0 {
+		stmt := `
+		  INSERT INTO password_history(id, user_id, password, created_at)
+		  SELECT $2, id, password, $3 FROM users WHERE id = $1 AND password IS NOT NULL AND password <> ''
+		`
+		if _, err := tr.Exec(ctx.Context, stmt, userID, utils.NewID(), now); err != nil {
+			return err
+		}
+	}
+
+	stmt := `
+	  DELETE FROM password_history WHERE user_id = $1 AND id NOT IN (
+	    SELECT id FROM password_history WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2
+	  )
+	`
+	_, err := tr.Exec(ctx.Context, stmt, userID, keep)
+	return err
+}
+
+// UsersPasswordHistoryGet returns the latest limit previous password hashes of the user, newest first
+func (ds *DBStore) UsersPasswordHistoryGet(ctx *models.Context, userID string, limit int) ([]string, *models.DBError) {
+	path := "users.store.UsersPasswordHistoryGet"
+	stmt := `SELECT password FROM password_history WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2`
+
+	rows, err := ds.db.Query(ctx.Context, stmt, userID, limit)
+	if err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+	defer rows.Close()
+
+	result := []string{}
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, models.HandleDBError(ctx, err, path, nil)
+		}
+		result = append(result, hash)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+
+	return result, nil
+}
+
+// UsersPasswordRehash replaces the password hash (of the same password) with one made by the
+// current hasher, unlike UsersPasswordUpdate it keeps last_password_update untouched
+func (ds *DBStore) UsersPasswordRehash(ctx *models.Context, userID, password string) *models.DBError {
+	stmt := `UPDATE users SET password = $2 WHERE id = $1`
+	_, err := ds.db.Exec(ctx.Context, stmt, userID, password)
+
+	return models.HandleDBError(ctx, err, "users.store.UsersPasswordRehash", nil)
+}
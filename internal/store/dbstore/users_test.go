@@ -0,0 +1,89 @@
+package dbstore
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+
+	usersPb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+)
+
+// fakeTx records the statements executed in the transaction
+type fakeTx struct {
+	pgx.Tx
+	execs []fakeExec
+}
+
+type fakeExec struct {
+	sql  string
+	args []any
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tx.execs = append(tx.execs, fakeExec{sql: strings.Join(strings.Fields(sql), " "), args: args})
+	return pgconn.CommandTag{}, nil
+}
+
+func TestUsersPasswordHistoryArchive(t *testing.T) {
+	ds := &DBStore{}
+	ctx := &models.Context{Context: context.Background()}
+
+	tests := map[string]struct {
+		historySize int
+		archived    bool
+		keep        int
+	}{
+		"the history keeps the previous passwords": {historySize: 3, archived: true, keep: 2},
+		"a history of two keeps one":               {historySize: 2, archived: true, keep: 1},
+		"a history of one is cleared":              {historySize: 1, keep: 0},
+		"no history is cleared":                    {historySize: 0, keep: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tx := &fakeTx{}
+			require.NoError(t, ds.usersPasswordHistoryArchive(ctx, tx, "user-id", tc.historySize, 1000))
+
+			execs := tx.execs
+			if tc.archived {
+				require.Len(t, execs, 2)
+				require.True(t, strings.HasPrefix(execs[0].sql, "INSERT INTO password_history"))
+				require.Equal(t, "user-id", execs[0].args[0])
+				execs = execs[1:]
+			}
+
+			// the newest keep entries survive the trim, the archived password among them
+			require.Len(t, execs, 1)
+			require.True(t, strings.HasPrefix(execs[0].sql, "DELETE FROM password_history"))
+			require.Contains(t, execs[0].sql, "ORDER BY created_at DESC LIMIT $2")
+			require.Equal(t, []any{"user-id", tc.keep}, execs[0].args)
+		})
+	}
+}
+
+func TestUsersUsernameMatch(t *testing.T) {
+	user := func(username string) *usersPb.User { return &usersPb.User{Username: &username} }
+	upper, lower := user("Alice"), user("alice")
+
+	tests := map[string]struct {
+		users    []*usersPb.User
+		username string
+		expects  *usersPb.User
+	}{
+		"no match":                    {users: nil, username: "alice"},
+		"the only match in any case":  {users: []*usersPb.User{upper}, username: "ALICE", expects: upper},
+		"the exact match wins":        {users: []*usersPb.User{upper, lower}, username: "alice", expects: lower},
+		"several matches are refused": {users: []*usersPb.User{upper, lower}, username: "ALICE"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expects, usersUsernameMatch(tc.users, tc.username))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package dbstore
+
+import (
+	"encoding/json"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+)
+
+func (ds *DBStore) WebauthnCredentialsGetByUserID(ctx *models.Context, userID string) ([]*intModels.WebauthnCredential, *models.DBError) {
+	path := "users.store.WebauthnCredentialsGetByUserID"
+	stmt := `
+	  SELECT id, user_id, name, credential, created_at, COALESCE(last_used_at, 0)
+	  FROM webauthn_credentials WHERE user_id = $1 ORDER BY created_at
+	`
+
+	rows, err := ds.db.Query(ctx.Context, stmt, userID)
+	if err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+	defer rows.Close()
+
+	result := []*intModels.WebauthnCredential{}
+	for rows.Next() {
+		c := &intModels.WebauthnCredential{}
+		var credential []byte
+		if err := rows.Scan(&c.ID, &c.UserID, &c.Name, &credential, &c.CreatedAt, &c.LastUsedAt); err != nil {
+			return nil, models.HandleDBError(ctx, err, path, nil)
+		}
+		if err := json.Unmarshal(credential, &c.Credential); err != nil {
+			return nil, models.JSONUnmarshalError(err, path, "an error occurred while trying to decode webauthn_credentials.credential")
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+
+	return result, nil
+}
+
+func (ds *DBStore) WebauthnCredentialsAdd(ctx *models.Context, c *intModels.WebauthnCredential) *models.DBError {
+	path := "users.store.WebauthnCredentialsAdd"
+	credential, err := json.Marshal(c.Credential)
+	if err != nil {
+		return models.JSONMarshalError(err, path, "an error occurred while trying to encode webauthn_credentials.credential")
+	}
+
+	stmt := `INSERT INTO webauthn_credentials(id, user_id, name, credential, created_at) VALUES($1, $2, $3, $4, $5)`
+	_, err = ds.db.Exec(ctx.Context, stmt, c.ID, c.UserID, c.Name, credential, c.CreatedAt)
+
+	return models.HandleDBError(ctx, err, path, nil)
+}
+
+// WebauthnCredentialsUpdateUsage stores the credential after a login (E,g the new sign count)
+// and marks it as used now
+func (ds *DBStore) WebauthnCredentialsUpdateUsage(ctx *models.Context, c *intModels.WebauthnCredential) *models.DBError {
+	path := "users.store.WebauthnCredentialsUpdateUsage"
+	credential, err := json.Marshal(c.Credential)
+	if err != nil {
+		return models.JSONMarshalError(err, path, "an error occurred while trying to encode webauthn_credentials.credential")
+	}
+
+	stmt := `UPDATE webauthn_credentials SET credential = $2, last_used_at = $3 WHERE id = $1`
+	_, err = ds.db.Exec(ctx.Context, stmt, c.ID, credential, utils.TimeGetMillis())
+
+	return models.HandleDBError(ctx, err, path, nil)
+}
+
+func (ds *DBStore) WebauthnSessionsAdd(ctx *models.Context, s *intModels.WebauthnSession) *models.DBError {
+	path := "users.store.WebauthnSessionsAdd"
+	data, err := json.Marshal(s.Data)
+	if err != nil {
+		return models.JSONMarshalError(err, path, "an error occurred while trying to encode webauthn_sessions.data")
+	}
+
+	stmt := `INSERT INTO webauthn_sessions(id, user_id, type, data, expires_at) VALUES($1, $2, $3, $4, $5)`
+	_, err = ds.db.Exec(ctx.Context, stmt, s.ID, s.UserID, string(s.Type), data, s.ExpiresAt)
+
+	return models.HandleDBError(ctx, err, path, nil)
+}
+
+// WebauthnSessionsTake deletes and returns the session, so a ceremony can only be finished once
+func (ds *DBStore) WebauthnSessionsTake(ctx *models.Context, id string) (*intModels.WebauthnSession, *models.DBError) {
+	path := "users.store.WebauthnSessionsTake"
+	stmt := `DELETE FROM webauthn_sessions WHERE id = $1 RETURNING id, user_id, type, data, expires_at`
+
+	s := &intModels.WebauthnSession{}
+	var data []byte
+	err := ds.db.QueryRow(ctx.Context, stmt, id).Scan(&s.ID, &s.UserID, &s.Type, &data, &s.ExpiresAt)
+	if err != nil {
+		return nil, models.HandleDBError(ctx, err, path, nil)
+	}
+	if err := json.Unmarshal(data, &s.Data); err != nil {
+		return nil, models.JSONUnmarshalError(err, path, "an error occurred while trying to decode webauthn_sessions.data")
+	}
+
+	return s, nil
+}
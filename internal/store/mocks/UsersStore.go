@@ -5,6 +5,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
@@ -39,17 +41,2526 @@ func (_m *MockUsersStore) EXPECT() *MockUsersStore_Expecter {
 	return &MockUsersStore_Expecter{mock: &_m.Mock}
 }
 
+// IdentitiesAdd provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) IdentitiesAdd(ctx *models.Context, i *models0.Identity) *models.DBError {
+	ret := _mock.Called(ctx, i)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IdentitiesAdd")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, *models0.Identity) *models.DBError); ok {
+		r0 = returnFunc(ctx, i)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_IdentitiesAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IdentitiesAdd'
+type MockUsersStore_IdentitiesAdd_Call struct {
+	*mock.Call
+}
+
+// IdentitiesAdd is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - i *models0.Identity
+func (_e *MockUsersStore_Expecter) IdentitiesAdd(ctx interface{}, i interface{}) *MockUsersStore_IdentitiesAdd_Call {
+	return &MockUsersStore_IdentitiesAdd_Call{Call: _e.mock.On("IdentitiesAdd", ctx, i)}
+}
+
+func (_c *MockUsersStore_IdentitiesAdd_Call) Run(run func(ctx *models.Context, i *models0.Identity)) *MockUsersStore_IdentitiesAdd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 *models0.Identity
+		if args[1] != nil {
+			arg1 = args[1].(*models0.Identity)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_IdentitiesAdd_Call) Return(dBError *models.DBError) *MockUsersStore_IdentitiesAdd_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_IdentitiesAdd_Call) RunAndReturn(run func(ctx *models.Context, i *models0.Identity) *models.DBError) *MockUsersStore_IdentitiesAdd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IdentitiesDelete provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) IdentitiesDelete(ctx *models.Context, userID string, identityID string) (bool, *models.DBError) {
+	ret := _mock.Called(ctx, userID, identityID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IdentitiesDelete")
+	}
+
+	var r0 bool
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string) (bool, *models.DBError)); ok {
+		return returnFunc(ctx, userID, identityID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, userID, identityID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, userID, identityID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_IdentitiesDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IdentitiesDelete'
+type MockUsersStore_IdentitiesDelete_Call struct {
+	*mock.Call
+}
+
+// IdentitiesDelete is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - identityID string
+func (_e *MockUsersStore_Expecter) IdentitiesDelete(ctx interface{}, userID interface{}, identityID interface{}) *MockUsersStore_IdentitiesDelete_Call {
+	return &MockUsersStore_IdentitiesDelete_Call{Call: _e.mock.On("IdentitiesDelete", ctx, userID, identityID)}
+}
+
+func (_c *MockUsersStore_IdentitiesDelete_Call) Run(run func(ctx *models.Context, userID string, identityID string)) *MockUsersStore_IdentitiesDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_IdentitiesDelete_Call) Return(b bool, dBError *models.DBError) *MockUsersStore_IdentitiesDelete_Call {
+	_c.Call.Return(b, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_IdentitiesDelete_Call) RunAndReturn(run func(ctx *models.Context, userID string, identityID string) (bool, *models.DBError)) *MockUsersStore_IdentitiesDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IdentitiesGetByUserID provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) IdentitiesGetByUserID(ctx *models.Context, userID string) ([]*models0.Identity, *models.DBError) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IdentitiesGetByUserID")
+	}
+
+	var r0 []*models0.Identity
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) ([]*models0.Identity, *models.DBError)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) []*models0.Identity); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models0.Identity)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_IdentitiesGetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IdentitiesGetByUserID'
+type MockUsersStore_IdentitiesGetByUserID_Call struct {
+	*mock.Call
+}
+
+// IdentitiesGetByUserID is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+func (_e *MockUsersStore_Expecter) IdentitiesGetByUserID(ctx interface{}, userID interface{}) *MockUsersStore_IdentitiesGetByUserID_Call {
+	return &MockUsersStore_IdentitiesGetByUserID_Call{Call: _e.mock.On("IdentitiesGetByUserID", ctx, userID)}
+}
+
+func (_c *MockUsersStore_IdentitiesGetByUserID_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_IdentitiesGetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_IdentitiesGetByUserID_Call) Return(identitys []*models0.Identity, dBError *models.DBError) *MockUsersStore_IdentitiesGetByUserID_Call {
+	_c.Call.Return(identitys, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_IdentitiesGetByUserID_Call) RunAndReturn(run func(ctx *models.Context, userID string) ([]*models0.Identity, *models.DBError)) *MockUsersStore_IdentitiesGetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MarkEmailAsConfirmed provides a mock function for the type MockUsersStore
-func (_mock *MockUsersStore) MarkEmailAsConfirmed(ctx *models.Context, tokenID string) *models.DBError {
-	ret := _mock.Called(ctx, tokenID)
+func (_mock *MockUsersStore) MarkEmailAsConfirmed(ctx *models.Context, tokenID string, email string) *models.DBError {
+	ret := _mock.Called(ctx, tokenID, email)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MarkEmailAsConfirmed")
 	}
 
 	var r0 *models.DBError
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *models.DBError); ok {
-		r0 = returnFunc(ctx, tokenID)
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string) *models.DBError); ok {
+		r0 = returnFunc(ctx, tokenID, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_MarkEmailAsConfirmed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkEmailAsConfirmed'
+type MockUsersStore_MarkEmailAsConfirmed_Call struct {
+	*mock.Call
+}
+
+// MarkEmailAsConfirmed is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - tokenID string
+//   - email string
+func (_e *MockUsersStore_Expecter) MarkEmailAsConfirmed(ctx interface{}, tokenID interface{}, email interface{}) *MockUsersStore_MarkEmailAsConfirmed_Call {
+	return &MockUsersStore_MarkEmailAsConfirmed_Call{Call: _e.mock.On("MarkEmailAsConfirmed", ctx, tokenID, email)}
+}
+
+func (_c *MockUsersStore_MarkEmailAsConfirmed_Call) Run(run func(ctx *models.Context, tokenID string, email string)) *MockUsersStore_MarkEmailAsConfirmed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_MarkEmailAsConfirmed_Call) Return(dBError *models.DBError) *MockUsersStore_MarkEmailAsConfirmed_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_MarkEmailAsConfirmed_Call) RunAndReturn(run func(ctx *models.Context, tokenID string, email string) *models.DBError) *MockUsersStore_MarkEmailAsConfirmed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignupCustomer provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) SignupCustomer(ctx *models.Context, c *v1.User, token *utils.Token) *models.DBError {
+	ret := _mock.Called(ctx, c, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignupCustomer")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, *v1.User, *utils.Token) *models.DBError); ok {
+		r0 = returnFunc(ctx, c, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_SignupCustomer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignupCustomer'
+type MockUsersStore_SignupCustomer_Call struct {
+	*mock.Call
+}
+
+// SignupCustomer is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - c *v1.User
+//   - token *utils.Token
+func (_e *MockUsersStore_Expecter) SignupCustomer(ctx interface{}, c interface{}, token interface{}) *MockUsersStore_SignupCustomer_Call {
+	return &MockUsersStore_SignupCustomer_Call{Call: _e.mock.On("SignupCustomer", ctx, c, token)}
+}
+
+func (_c *MockUsersStore_SignupCustomer_Call) Run(run func(ctx *models.Context, c *v1.User, token *utils.Token)) *MockUsersStore_SignupCustomer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 *v1.User
+		if args[1] != nil {
+			arg1 = args[1].(*v1.User)
+		}
+		var arg2 *utils.Token
+		if args[2] != nil {
+			arg2 = args[2].(*utils.Token)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_SignupCustomer_Call) Return(dBError *models.DBError) *MockUsersStore_SignupCustomer_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_SignupCustomer_Call) RunAndReturn(run func(ctx *models.Context, c *v1.User, token *utils.Token) *models.DBError) *MockUsersStore_SignupCustomer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignupSupplier provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) SignupSupplier(ctx *models.Context, s *v1.User, token *utils.Token) *models.DBError {
+	ret := _mock.Called(ctx, s, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignupSupplier")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, *v1.User, *utils.Token) *models.DBError); ok {
+		r0 = returnFunc(ctx, s, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_SignupSupplier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignupSupplier'
+type MockUsersStore_SignupSupplier_Call struct {
+	*mock.Call
+}
+
+// SignupSupplier is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - s *v1.User
+//   - token *utils.Token
+func (_e *MockUsersStore_Expecter) SignupSupplier(ctx interface{}, s interface{}, token interface{}) *MockUsersStore_SignupSupplier_Call {
+	return &MockUsersStore_SignupSupplier_Call{Call: _e.mock.On("SignupSupplier", ctx, s, token)}
+}
+
+func (_c *MockUsersStore_SignupSupplier_Call) Run(run func(ctx *models.Context, s *v1.User, token *utils.Token)) *MockUsersStore_SignupSupplier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 *v1.User
+		if args[1] != nil {
+			arg1 = args[1].(*v1.User)
+		}
+		var arg2 *utils.Token
+		if args[2] != nil {
+			arg2 = args[2].(*utils.Token)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_SignupSupplier_Call) Return(dBError *models.DBError) *MockUsersStore_SignupSupplier_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_SignupSupplier_Call) RunAndReturn(run func(ctx *models.Context, s *v1.User, token *utils.Token) *models.DBError) *MockUsersStore_SignupSupplier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SocialLoginStatesAdd provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) SocialLoginStatesAdd(ctx *models.Context, s *models0.SocialLoginState) *models.DBError {
+	ret := _mock.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SocialLoginStatesAdd")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, *models0.SocialLoginState) *models.DBError); ok {
+		r0 = returnFunc(ctx, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_SocialLoginStatesAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SocialLoginStatesAdd'
+type MockUsersStore_SocialLoginStatesAdd_Call struct {
+	*mock.Call
+}
+
+// SocialLoginStatesAdd is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - s *models0.SocialLoginState
+func (_e *MockUsersStore_Expecter) SocialLoginStatesAdd(ctx interface{}, s interface{}) *MockUsersStore_SocialLoginStatesAdd_Call {
+	return &MockUsersStore_SocialLoginStatesAdd_Call{Call: _e.mock.On("SocialLoginStatesAdd", ctx, s)}
+}
+
+func (_c *MockUsersStore_SocialLoginStatesAdd_Call) Run(run func(ctx *models.Context, s *models0.SocialLoginState)) *MockUsersStore_SocialLoginStatesAdd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 *models0.SocialLoginState
+		if args[1] != nil {
+			arg1 = args[1].(*models0.SocialLoginState)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_SocialLoginStatesAdd_Call) Return(dBError *models.DBError) *MockUsersStore_SocialLoginStatesAdd_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_SocialLoginStatesAdd_Call) RunAndReturn(run func(ctx *models.Context, s *models0.SocialLoginState) *models.DBError) *MockUsersStore_SocialLoginStatesAdd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SocialLoginStatesTake provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) SocialLoginStatesTake(ctx *models.Context, id string) (*models0.SocialLoginState, *models.DBError) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SocialLoginStatesTake")
+	}
+
+	var r0 *models0.SocialLoginState
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (*models0.SocialLoginState, *models.DBError)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *models0.SocialLoginState); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models0.SocialLoginState)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_SocialLoginStatesTake_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SocialLoginStatesTake'
+type MockUsersStore_SocialLoginStatesTake_Call struct {
+	*mock.Call
+}
+
+// SocialLoginStatesTake is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - id string
+func (_e *MockUsersStore_Expecter) SocialLoginStatesTake(ctx interface{}, id interface{}) *MockUsersStore_SocialLoginStatesTake_Call {
+	return &MockUsersStore_SocialLoginStatesTake_Call{Call: _e.mock.On("SocialLoginStatesTake", ctx, id)}
+}
+
+func (_c *MockUsersStore_SocialLoginStatesTake_Call) Run(run func(ctx *models.Context, id string)) *MockUsersStore_SocialLoginStatesTake_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_SocialLoginStatesTake_Call) Return(socialLoginState *models0.SocialLoginState, dBError *models.DBError) *MockUsersStore_SocialLoginStatesTake_Call {
+	_c.Call.Return(socialLoginState, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_SocialLoginStatesTake_Call) RunAndReturn(run func(ctx *models.Context, id string) (*models0.SocialLoginState, *models.DBError)) *MockUsersStore_SocialLoginStatesTake_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokensAdd provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) TokensAdd(ctx *models.Context, userID string, token *utils.Token, tokenType models0.TokenType, path string) *models.DBError {
+	ret := _mock.Called(ctx, userID, token, tokenType, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokensAdd")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, *utils.Token, models0.TokenType, string) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, token, tokenType, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_TokensAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensAdd'
+type MockUsersStore_TokensAdd_Call struct {
+	*mock.Call
+}
+
+// TokensAdd is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - token *utils.Token
+//   - tokenType models0.TokenType
+//   - path string
+func (_e *MockUsersStore_Expecter) TokensAdd(ctx interface{}, userID interface{}, token interface{}, tokenType interface{}, path interface{}) *MockUsersStore_TokensAdd_Call {
+	return &MockUsersStore_TokensAdd_Call{Call: _e.mock.On("TokensAdd", ctx, userID, token, tokenType, path)}
+}
+
+func (_c *MockUsersStore_TokensAdd_Call) Run(run func(ctx *models.Context, userID string, token *utils.Token, tokenType models0.TokenType, path string)) *MockUsersStore_TokensAdd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *utils.Token
+		if args[2] != nil {
+			arg2 = args[2].(*utils.Token)
+		}
+		var arg3 models0.TokenType
+		if args[3] != nil {
+			arg3 = args[3].(models0.TokenType)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_TokensAdd_Call) Return(dBError *models.DBError) *MockUsersStore_TokensAdd_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_TokensAdd_Call) RunAndReturn(run func(ctx *models.Context, userID string, token *utils.Token, tokenType models0.TokenType, path string) *models.DBError) *MockUsersStore_TokensAdd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokensAttemptsIncrement provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) TokensAttemptsIncrement(ctx *models.Context, tokenID string) (int32, *models.DBError) {
+	ret := _mock.Called(ctx, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokensAttemptsIncrement")
+	}
+
+	var r0 int32
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (int32, *models.DBError)); ok {
+		return returnFunc(ctx, tokenID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) int32); ok {
+		r0 = returnFunc(ctx, tokenID)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, tokenID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_TokensAttemptsIncrement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensAttemptsIncrement'
+type MockUsersStore_TokensAttemptsIncrement_Call struct {
+	*mock.Call
+}
+
+// TokensAttemptsIncrement is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - tokenID string
+func (_e *MockUsersStore_Expecter) TokensAttemptsIncrement(ctx interface{}, tokenID interface{}) *MockUsersStore_TokensAttemptsIncrement_Call {
+	return &MockUsersStore_TokensAttemptsIncrement_Call{Call: _e.mock.On("TokensAttemptsIncrement", ctx, tokenID)}
+}
+
+func (_c *MockUsersStore_TokensAttemptsIncrement_Call) Run(run func(ctx *models.Context, tokenID string)) *MockUsersStore_TokensAttemptsIncrement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_TokensAttemptsIncrement_Call) Return(n int32, dBError *models.DBError) *MockUsersStore_TokensAttemptsIncrement_Call {
+	_c.Call.Return(n, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_TokensAttemptsIncrement_Call) RunAndReturn(run func(ctx *models.Context, tokenID string) (int32, *models.DBError)) *MockUsersStore_TokensAttemptsIncrement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokensDeleteAllPasswordResetByUserID provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) TokensDeleteAllPasswordResetByUserID(ctx *models.Context, userID string) (int64, *models.DBError) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokensDeleteAllPasswordResetByUserID")
+	}
+
+	var r0 int64
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (int64, *models.DBError)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) int64); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensDeleteAllPasswordResetByUserID'
+type MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call struct {
+	*mock.Call
+}
+
+// TokensDeleteAllPasswordResetByUserID is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+func (_e *MockUsersStore_Expecter) TokensDeleteAllPasswordResetByUserID(ctx interface{}, userID interface{}) *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call {
+	return &MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call{Call: _e.mock.On("TokensDeleteAllPasswordResetByUserID", ctx, userID)}
+}
+
+func (_c *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call) Return(n int64, dBError *models.DBError) *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call {
+	_c.Call.Return(n, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call) RunAndReturn(run func(ctx *models.Context, userID string) (int64, *models.DBError)) *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokensGet provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) TokensGet(ctx *models.Context, tokenID string) (*v1.Token, *models.DBError) {
+	ret := _mock.Called(ctx, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokensGet")
+	}
+
+	var r0 *v1.Token
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (*v1.Token, *models.DBError)); ok {
+		return returnFunc(ctx, tokenID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *v1.Token); ok {
+		r0 = returnFunc(ctx, tokenID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Token)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, tokenID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_TokensGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensGet'
+type MockUsersStore_TokensGet_Call struct {
+	*mock.Call
+}
+
+// TokensGet is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - tokenID string
+func (_e *MockUsersStore_Expecter) TokensGet(ctx interface{}, tokenID interface{}) *MockUsersStore_TokensGet_Call {
+	return &MockUsersStore_TokensGet_Call{Call: _e.mock.On("TokensGet", ctx, tokenID)}
+}
+
+func (_c *MockUsersStore_TokensGet_Call) Run(run func(ctx *models.Context, tokenID string)) *MockUsersStore_TokensGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_TokensGet_Call) Return(token *v1.Token, dBError *models.DBError) *MockUsersStore_TokensGet_Call {
+	_c.Call.Return(token, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_TokensGet_Call) RunAndReturn(run func(ctx *models.Context, tokenID string) (*v1.Token, *models.DBError)) *MockUsersStore_TokensGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokensGetAllByUserID provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) TokensGetAllByUserID(ctx *models.Context, userID string) ([]*v1.Token, *models.DBError) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokensGetAllByUserID")
+	}
+
+	var r0 []*v1.Token
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) ([]*v1.Token, *models.DBError)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) []*v1.Token); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Token)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_TokensGetAllByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensGetAllByUserID'
+type MockUsersStore_TokensGetAllByUserID_Call struct {
+	*mock.Call
+}
+
+// TokensGetAllByUserID is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+func (_e *MockUsersStore_Expecter) TokensGetAllByUserID(ctx interface{}, userID interface{}) *MockUsersStore_TokensGetAllByUserID_Call {
+	return &MockUsersStore_TokensGetAllByUserID_Call{Call: _e.mock.On("TokensGetAllByUserID", ctx, userID)}
+}
+
+func (_c *MockUsersStore_TokensGetAllByUserID_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_TokensGetAllByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_TokensGetAllByUserID_Call) Return(tokens []*v1.Token, dBError *models.DBError) *MockUsersStore_TokensGetAllByUserID_Call {
+	_c.Call.Return(tokens, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_TokensGetAllByUserID_Call) RunAndReturn(run func(ctx *models.Context, userID string) ([]*v1.Token, *models.DBError)) *MockUsersStore_TokensGetAllByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokensGetCreatedAtSince provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) TokensGetCreatedAtSince(ctx *models.Context, userID string, tokenType models0.TokenType, since int64) ([]int64, *models.DBError) {
+	ret := _mock.Called(ctx, userID, tokenType, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokensGetCreatedAtSince")
+	}
+
+	var r0 []int64
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, models0.TokenType, int64) ([]int64, *models.DBError)); ok {
+		return returnFunc(ctx, userID, tokenType, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, models0.TokenType, int64) []int64); ok {
+		r0 = returnFunc(ctx, userID, tokenType, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string, models0.TokenType, int64) *models.DBError); ok {
+		r1 = returnFunc(ctx, userID, tokenType, since)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_TokensGetCreatedAtSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensGetCreatedAtSince'
+type MockUsersStore_TokensGetCreatedAtSince_Call struct {
+	*mock.Call
+}
+
+// TokensGetCreatedAtSince is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - tokenType models0.TokenType
+//   - since int64
+func (_e *MockUsersStore_Expecter) TokensGetCreatedAtSince(ctx interface{}, userID interface{}, tokenType interface{}, since interface{}) *MockUsersStore_TokensGetCreatedAtSince_Call {
+	return &MockUsersStore_TokensGetCreatedAtSince_Call{Call: _e.mock.On("TokensGetCreatedAtSince", ctx, userID, tokenType, since)}
+}
+
+func (_c *MockUsersStore_TokensGetCreatedAtSince_Call) Run(run func(ctx *models.Context, userID string, tokenType models0.TokenType, since int64)) *MockUsersStore_TokensGetCreatedAtSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 models0.TokenType
+		if args[2] != nil {
+			arg2 = args[2].(models0.TokenType)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_TokensGetCreatedAtSince_Call) Return(ns []int64, dBError *models.DBError) *MockUsersStore_TokensGetCreatedAtSince_Call {
+	_c.Call.Return(ns, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_TokensGetCreatedAtSince_Call) RunAndReturn(run func(ctx *models.Context, userID string, tokenType models0.TokenType, since int64) ([]int64, *models.DBError)) *MockUsersStore_TokensGetCreatedAtSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokensGetUnusedByType provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) TokensGetUnusedByType(ctx *models.Context, userID string, tokenType models0.TokenType) ([]*v1.Token, *models.DBError) {
+	ret := _mock.Called(ctx, userID, tokenType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokensGetUnusedByType")
+	}
+
+	var r0 []*v1.Token
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, models0.TokenType) ([]*v1.Token, *models.DBError)); ok {
+		return returnFunc(ctx, userID, tokenType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, models0.TokenType) []*v1.Token); ok {
+		r0 = returnFunc(ctx, userID, tokenType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v1.Token)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string, models0.TokenType) *models.DBError); ok {
+		r1 = returnFunc(ctx, userID, tokenType)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_TokensGetUnusedByType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensGetUnusedByType'
+type MockUsersStore_TokensGetUnusedByType_Call struct {
+	*mock.Call
+}
+
+// TokensGetUnusedByType is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - tokenType models0.TokenType
+func (_e *MockUsersStore_Expecter) TokensGetUnusedByType(ctx interface{}, userID interface{}, tokenType interface{}) *MockUsersStore_TokensGetUnusedByType_Call {
+	return &MockUsersStore_TokensGetUnusedByType_Call{Call: _e.mock.On("TokensGetUnusedByType", ctx, userID, tokenType)}
+}
+
+func (_c *MockUsersStore_TokensGetUnusedByType_Call) Run(run func(ctx *models.Context, userID string, tokenType models0.TokenType)) *MockUsersStore_TokensGetUnusedByType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 models0.TokenType
+		if args[2] != nil {
+			arg2 = args[2].(models0.TokenType)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_TokensGetUnusedByType_Call) Return(tokens []*v1.Token, dBError *models.DBError) *MockUsersStore_TokensGetUnusedByType_Call {
+	_c.Call.Return(tokens, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_TokensGetUnusedByType_Call) RunAndReturn(run func(ctx *models.Context, userID string, tokenType models0.TokenType) ([]*v1.Token, *models.DBError)) *MockUsersStore_TokensGetUnusedByType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokensMarkUsed provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) TokensMarkUsed(ctx *models.Context, tokenID string) *models.DBError {
+	ret := _mock.Called(ctx, tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokensMarkUsed")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *models.DBError); ok {
+		r0 = returnFunc(ctx, tokenID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_TokensMarkUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensMarkUsed'
+type MockUsersStore_TokensMarkUsed_Call struct {
+	*mock.Call
+}
+
+// TokensMarkUsed is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - tokenID string
+func (_e *MockUsersStore_Expecter) TokensMarkUsed(ctx interface{}, tokenID interface{}) *MockUsersStore_TokensMarkUsed_Call {
+	return &MockUsersStore_TokensMarkUsed_Call{Call: _e.mock.On("TokensMarkUsed", ctx, tokenID)}
+}
+
+func (_c *MockUsersStore_TokensMarkUsed_Call) Run(run func(ctx *models.Context, tokenID string)) *MockUsersStore_TokensMarkUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_TokensMarkUsed_Call) Return(dBError *models.DBError) *MockUsersStore_TokensMarkUsed_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_TokensMarkUsed_Call) RunAndReturn(run func(ctx *models.Context, tokenID string) *models.DBError) *MockUsersStore_TokensMarkUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokensMfaRecoveryCodesReplace provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) TokensMfaRecoveryCodesReplace(ctx *models.Context, userID string, tokens []*utils.Token) *models.DBError {
+	ret := _mock.Called(ctx, userID, tokens)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokensMfaRecoveryCodesReplace")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, []*utils.Token) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, tokens)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_TokensMfaRecoveryCodesReplace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensMfaRecoveryCodesReplace'
+type MockUsersStore_TokensMfaRecoveryCodesReplace_Call struct {
+	*mock.Call
+}
+
+// TokensMfaRecoveryCodesReplace is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - tokens []*utils.Token
+func (_e *MockUsersStore_Expecter) TokensMfaRecoveryCodesReplace(ctx interface{}, userID interface{}, tokens interface{}) *MockUsersStore_TokensMfaRecoveryCodesReplace_Call {
+	return &MockUsersStore_TokensMfaRecoveryCodesReplace_Call{Call: _e.mock.On("TokensMfaRecoveryCodesReplace", ctx, userID, tokens)}
+}
+
+func (_c *MockUsersStore_TokensMfaRecoveryCodesReplace_Call) Run(run func(ctx *models.Context, userID string, tokens []*utils.Token)) *MockUsersStore_TokensMfaRecoveryCodesReplace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []*utils.Token
+		if args[2] != nil {
+			arg2 = args[2].([]*utils.Token)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_TokensMfaRecoveryCodesReplace_Call) Return(dBError *models.DBError) *MockUsersStore_TokensMfaRecoveryCodesReplace_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_TokensMfaRecoveryCodesReplace_Call) RunAndReturn(run func(ctx *models.Context, userID string, tokens []*utils.Token) *models.DBError) *MockUsersStore_TokensMfaRecoveryCodesReplace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TokensRotate provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) TokensRotate(ctx *models.Context, userID string, token *utils.Token, tokenType models0.TokenType) *models.DBError {
+	ret := _mock.Called(ctx, userID, token, tokenType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TokensRotate")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, *utils.Token, models0.TokenType) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, token, tokenType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_TokensRotate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensRotate'
+type MockUsersStore_TokensRotate_Call struct {
+	*mock.Call
+}
+
+// TokensRotate is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - token *utils.Token
+//   - tokenType models0.TokenType
+func (_e *MockUsersStore_Expecter) TokensRotate(ctx interface{}, userID interface{}, token interface{}, tokenType interface{}) *MockUsersStore_TokensRotate_Call {
+	return &MockUsersStore_TokensRotate_Call{Call: _e.mock.On("TokensRotate", ctx, userID, token, tokenType)}
+}
+
+func (_c *MockUsersStore_TokensRotate_Call) Run(run func(ctx *models.Context, userID string, token *utils.Token, tokenType models0.TokenType)) *MockUsersStore_TokensRotate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *utils.Token
+		if args[2] != nil {
+			arg2 = args[2].(*utils.Token)
+		}
+		var arg3 models0.TokenType
+		if args[3] != nil {
+			arg3 = args[3].(models0.TokenType)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_TokensRotate_Call) Return(dBError *models.DBError) *MockUsersStore_TokensRotate_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_TokensRotate_Call) RunAndReturn(run func(ctx *models.Context, userID string, token *utils.Token, tokenType models0.TokenType) *models.DBError) *MockUsersStore_TokensRotate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserLoginDevicesAdd provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UserLoginDevicesAdd(ctx *models.Context, d *models0.LoginDevice) (*models0.LoginDeviceHistory, *models.DBError) {
+	ret := _mock.Called(ctx, d)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserLoginDevicesAdd")
+	}
+
+	var r0 *models0.LoginDeviceHistory
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, *models0.LoginDevice) (*models0.LoginDeviceHistory, *models.DBError)); ok {
+		return returnFunc(ctx, d)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, *models0.LoginDevice) *models0.LoginDeviceHistory); ok {
+		r0 = returnFunc(ctx, d)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models0.LoginDeviceHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, *models0.LoginDevice) *models.DBError); ok {
+		r1 = returnFunc(ctx, d)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_UserLoginDevicesAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserLoginDevicesAdd'
+type MockUsersStore_UserLoginDevicesAdd_Call struct {
+	*mock.Call
+}
+
+// UserLoginDevicesAdd is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - d *models0.LoginDevice
+func (_e *MockUsersStore_Expecter) UserLoginDevicesAdd(ctx interface{}, d interface{}) *MockUsersStore_UserLoginDevicesAdd_Call {
+	return &MockUsersStore_UserLoginDevicesAdd_Call{Call: _e.mock.On("UserLoginDevicesAdd", ctx, d)}
+}
+
+func (_c *MockUsersStore_UserLoginDevicesAdd_Call) Run(run func(ctx *models.Context, d *models0.LoginDevice)) *MockUsersStore_UserLoginDevicesAdd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 *models0.LoginDevice
+		if args[1] != nil {
+			arg1 = args[1].(*models0.LoginDevice)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UserLoginDevicesAdd_Call) Return(loginDeviceHistory *models0.LoginDeviceHistory, dBError *models.DBError) *MockUsersStore_UserLoginDevicesAdd_Call {
+	_c.Call.Return(loginDeviceHistory, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UserLoginDevicesAdd_Call) RunAndReturn(run func(ctx *models.Context, d *models0.LoginDevice) (*models0.LoginDeviceHistory, *models.DBError)) *MockUsersStore_UserLoginDevicesAdd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserSessionsAdd provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UserSessionsAdd(ctx *models.Context, s *models0.UserSession) *models.DBError {
+	ret := _mock.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserSessionsAdd")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, *models0.UserSession) *models.DBError); ok {
+		r0 = returnFunc(ctx, s)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_UserSessionsAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserSessionsAdd'
+type MockUsersStore_UserSessionsAdd_Call struct {
+	*mock.Call
+}
+
+// UserSessionsAdd is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - s *models0.UserSession
+func (_e *MockUsersStore_Expecter) UserSessionsAdd(ctx interface{}, s interface{}) *MockUsersStore_UserSessionsAdd_Call {
+	return &MockUsersStore_UserSessionsAdd_Call{Call: _e.mock.On("UserSessionsAdd", ctx, s)}
+}
+
+func (_c *MockUsersStore_UserSessionsAdd_Call) Run(run func(ctx *models.Context, s *models0.UserSession)) *MockUsersStore_UserSessionsAdd_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 *models0.UserSession
+		if args[1] != nil {
+			arg1 = args[1].(*models0.UserSession)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UserSessionsAdd_Call) Return(dBError *models.DBError) *MockUsersStore_UserSessionsAdd_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UserSessionsAdd_Call) RunAndReturn(run func(ctx *models.Context, s *models0.UserSession) *models.DBError) *MockUsersStore_UserSessionsAdd_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserSessionsDelete provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UserSessionsDelete(ctx *models.Context, userID string, sessionID string) *models.DBError {
+	ret := _mock.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserSessionsDelete")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, sessionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_UserSessionsDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserSessionsDelete'
+type MockUsersStore_UserSessionsDelete_Call struct {
+	*mock.Call
+}
+
+// UserSessionsDelete is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - sessionID string
+func (_e *MockUsersStore_Expecter) UserSessionsDelete(ctx interface{}, userID interface{}, sessionID interface{}) *MockUsersStore_UserSessionsDelete_Call {
+	return &MockUsersStore_UserSessionsDelete_Call{Call: _e.mock.On("UserSessionsDelete", ctx, userID, sessionID)}
+}
+
+func (_c *MockUsersStore_UserSessionsDelete_Call) Run(run func(ctx *models.Context, userID string, sessionID string)) *MockUsersStore_UserSessionsDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UserSessionsDelete_Call) Return(dBError *models.DBError) *MockUsersStore_UserSessionsDelete_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UserSessionsDelete_Call) RunAndReturn(run func(ctx *models.Context, userID string, sessionID string) *models.DBError) *MockUsersStore_UserSessionsDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserSessionsDeleteAll provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UserSessionsDeleteAll(ctx *models.Context, userID string, keepID string) *models.DBError {
+	ret := _mock.Called(ctx, userID, keepID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserSessionsDeleteAll")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, keepID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_UserSessionsDeleteAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserSessionsDeleteAll'
+type MockUsersStore_UserSessionsDeleteAll_Call struct {
+	*mock.Call
+}
+
+// UserSessionsDeleteAll is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - keepID string
+func (_e *MockUsersStore_Expecter) UserSessionsDeleteAll(ctx interface{}, userID interface{}, keepID interface{}) *MockUsersStore_UserSessionsDeleteAll_Call {
+	return &MockUsersStore_UserSessionsDeleteAll_Call{Call: _e.mock.On("UserSessionsDeleteAll", ctx, userID, keepID)}
+}
+
+func (_c *MockUsersStore_UserSessionsDeleteAll_Call) Run(run func(ctx *models.Context, userID string, keepID string)) *MockUsersStore_UserSessionsDeleteAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UserSessionsDeleteAll_Call) Return(dBError *models.DBError) *MockUsersStore_UserSessionsDeleteAll_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UserSessionsDeleteAll_Call) RunAndReturn(run func(ctx *models.Context, userID string, keepID string) *models.DBError) *MockUsersStore_UserSessionsDeleteAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserSessionsGetByUserID provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UserSessionsGetByUserID(ctx *models.Context, userID string) ([]*models0.UserSession, *models.DBError) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserSessionsGetByUserID")
+	}
+
+	var r0 []*models0.UserSession
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) ([]*models0.UserSession, *models.DBError)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) []*models0.UserSession); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models0.UserSession)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_UserSessionsGetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserSessionsGetByUserID'
+type MockUsersStore_UserSessionsGetByUserID_Call struct {
+	*mock.Call
+}
+
+// UserSessionsGetByUserID is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+func (_e *MockUsersStore_Expecter) UserSessionsGetByUserID(ctx interface{}, userID interface{}) *MockUsersStore_UserSessionsGetByUserID_Call {
+	return &MockUsersStore_UserSessionsGetByUserID_Call{Call: _e.mock.On("UserSessionsGetByUserID", ctx, userID)}
+}
+
+func (_c *MockUsersStore_UserSessionsGetByUserID_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_UserSessionsGetByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UserSessionsGetByUserID_Call) Return(userSessions []*models0.UserSession, dBError *models.DBError) *MockUsersStore_UserSessionsGetByUserID_Call {
+	_c.Call.Return(userSessions, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UserSessionsGetByUserID_Call) RunAndReturn(run func(ctx *models.Context, userID string) ([]*models0.UserSession, *models.DBError)) *MockUsersStore_UserSessionsGetByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserSessionsTouch provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UserSessionsTouch(ctx *models.Context, userID string, sessionID string, at int64, interval time.Duration) *models.DBError {
+	ret := _mock.Called(ctx, userID, sessionID, at, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserSessionsTouch")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string, int64, time.Duration) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, sessionID, at, interval)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_UserSessionsTouch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserSessionsTouch'
+type MockUsersStore_UserSessionsTouch_Call struct {
+	*mock.Call
+}
+
+// UserSessionsTouch is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - sessionID string
+//   - at int64
+//   - interval time.Duration
+func (_e *MockUsersStore_Expecter) UserSessionsTouch(ctx interface{}, userID interface{}, sessionID interface{}, at interface{}, interval interface{}) *MockUsersStore_UserSessionsTouch_Call {
+	return &MockUsersStore_UserSessionsTouch_Call{Call: _e.mock.On("UserSessionsTouch", ctx, userID, sessionID, at, interval)}
+}
+
+func (_c *MockUsersStore_UserSessionsTouch_Call) Run(run func(ctx *models.Context, userID string, sessionID string, at int64, interval time.Duration)) *MockUsersStore_UserSessionsTouch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		var arg4 time.Duration
+		if args[4] != nil {
+			arg4 = args[4].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UserSessionsTouch_Call) Return(dBError *models.DBError) *MockUsersStore_UserSessionsTouch_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UserSessionsTouch_Call) RunAndReturn(run func(ctx *models.Context, userID string, sessionID string, at int64, interval time.Duration) *models.DBError) *MockUsersStore_UserSessionsTouch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersFailedAttemptsIncrement provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersFailedAttemptsIncrement(ctx *models.Context, userID string) (int32, *models.DBError) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersFailedAttemptsIncrement")
+	}
+
+	var r0 int32
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (int32, *models.DBError)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) int32); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_UsersFailedAttemptsIncrement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersFailedAttemptsIncrement'
+type MockUsersStore_UsersFailedAttemptsIncrement_Call struct {
+	*mock.Call
+}
+
+// UsersFailedAttemptsIncrement is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+func (_e *MockUsersStore_Expecter) UsersFailedAttemptsIncrement(ctx interface{}, userID interface{}) *MockUsersStore_UsersFailedAttemptsIncrement_Call {
+	return &MockUsersStore_UsersFailedAttemptsIncrement_Call{Call: _e.mock.On("UsersFailedAttemptsIncrement", ctx, userID)}
+}
+
+func (_c *MockUsersStore_UsersFailedAttemptsIncrement_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_UsersFailedAttemptsIncrement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersFailedAttemptsIncrement_Call) Return(n int32, dBError *models.DBError) *MockUsersStore_UsersFailedAttemptsIncrement_Call {
+	_c.Call.Return(n, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersFailedAttemptsIncrement_Call) RunAndReturn(run func(ctx *models.Context, userID string) (int32, *models.DBError)) *MockUsersStore_UsersFailedAttemptsIncrement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersGetByEmail provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersGetByEmail(ctx *models.Context, email string) (*v1.User, *models.DBError) {
+	ret := _mock.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersGetByEmail")
+	}
+
+	var r0 *v1.User
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (*v1.User, *models.DBError)); ok {
+		return returnFunc(ctx, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *v1.User); ok {
+		r0 = returnFunc(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, email)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_UsersGetByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersGetByEmail'
+type MockUsersStore_UsersGetByEmail_Call struct {
+	*mock.Call
+}
+
+// UsersGetByEmail is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - email string
+func (_e *MockUsersStore_Expecter) UsersGetByEmail(ctx interface{}, email interface{}) *MockUsersStore_UsersGetByEmail_Call {
+	return &MockUsersStore_UsersGetByEmail_Call{Call: _e.mock.On("UsersGetByEmail", ctx, email)}
+}
+
+func (_c *MockUsersStore_UsersGetByEmail_Call) Run(run func(ctx *models.Context, email string)) *MockUsersStore_UsersGetByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersGetByEmail_Call) Return(user *v1.User, dBError *models.DBError) *MockUsersStore_UsersGetByEmail_Call {
+	_c.Call.Return(user, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersGetByEmail_Call) RunAndReturn(run func(ctx *models.Context, email string) (*v1.User, *models.DBError)) *MockUsersStore_UsersGetByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersGetByID provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersGetByID(ctx *models.Context, userID string) (*v1.User, *models.DBError) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersGetByID")
+	}
+
+	var r0 *v1.User
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (*v1.User, *models.DBError)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *v1.User); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_UsersGetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersGetByID'
+type MockUsersStore_UsersGetByID_Call struct {
+	*mock.Call
+}
+
+// UsersGetByID is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+func (_e *MockUsersStore_Expecter) UsersGetByID(ctx interface{}, userID interface{}) *MockUsersStore_UsersGetByID_Call {
+	return &MockUsersStore_UsersGetByID_Call{Call: _e.mock.On("UsersGetByID", ctx, userID)}
+}
+
+func (_c *MockUsersStore_UsersGetByID_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_UsersGetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersGetByID_Call) Return(user *v1.User, dBError *models.DBError) *MockUsersStore_UsersGetByID_Call {
+	_c.Call.Return(user, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersGetByID_Call) RunAndReturn(run func(ctx *models.Context, userID string) (*v1.User, *models.DBError)) *MockUsersStore_UsersGetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersGetByIdentity provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersGetByIdentity(ctx *models.Context, provider string, subject string) (*v1.User, *models.DBError) {
+	ret := _mock.Called(ctx, provider, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersGetByIdentity")
+	}
+
+	var r0 *v1.User
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string) (*v1.User, *models.DBError)); ok {
+		return returnFunc(ctx, provider, subject)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string) *v1.User); ok {
+		r0 = returnFunc(ctx, provider, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, provider, subject)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_UsersGetByIdentity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersGetByIdentity'
+type MockUsersStore_UsersGetByIdentity_Call struct {
+	*mock.Call
+}
+
+// UsersGetByIdentity is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - provider string
+//   - subject string
+func (_e *MockUsersStore_Expecter) UsersGetByIdentity(ctx interface{}, provider interface{}, subject interface{}) *MockUsersStore_UsersGetByIdentity_Call {
+	return &MockUsersStore_UsersGetByIdentity_Call{Call: _e.mock.On("UsersGetByIdentity", ctx, provider, subject)}
+}
+
+func (_c *MockUsersStore_UsersGetByIdentity_Call) Run(run func(ctx *models.Context, provider string, subject string)) *MockUsersStore_UsersGetByIdentity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersGetByIdentity_Call) Return(user *v1.User, dBError *models.DBError) *MockUsersStore_UsersGetByIdentity_Call {
+	_c.Call.Return(user, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersGetByIdentity_Call) RunAndReturn(run func(ctx *models.Context, provider string, subject string) (*v1.User, *models.DBError)) *MockUsersStore_UsersGetByIdentity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersGetByUsername provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersGetByUsername(ctx *models.Context, username string) (*v1.User, *models.DBError) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersGetByUsername")
+	}
+
+	var r0 *v1.User
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (*v1.User, *models.DBError)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *v1.User); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_UsersGetByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersGetByUsername'
+type MockUsersStore_UsersGetByUsername_Call struct {
+	*mock.Call
+}
+
+// UsersGetByUsername is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - username string
+func (_e *MockUsersStore_Expecter) UsersGetByUsername(ctx interface{}, username interface{}) *MockUsersStore_UsersGetByUsername_Call {
+	return &MockUsersStore_UsersGetByUsername_Call{Call: _e.mock.On("UsersGetByUsername", ctx, username)}
+}
+
+func (_c *MockUsersStore_UsersGetByUsername_Call) Run(run func(ctx *models.Context, username string)) *MockUsersStore_UsersGetByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersGetByUsername_Call) Return(user *v1.User, dBError *models.DBError) *MockUsersStore_UsersGetByUsername_Call {
+	_c.Call.Return(user, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersGetByUsername_Call) RunAndReturn(run func(ctx *models.Context, username string) (*v1.User, *models.DBError)) *MockUsersStore_UsersGetByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersGetLockedUntil provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersGetLockedUntil(ctx *models.Context, userID string) (int64, *models.DBError) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersGetLockedUntil")
+	}
+
+	var r0 int64
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (int64, *models.DBError)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) int64); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_UsersGetLockedUntil_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersGetLockedUntil'
+type MockUsersStore_UsersGetLockedUntil_Call struct {
+	*mock.Call
+}
+
+// UsersGetLockedUntil is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+func (_e *MockUsersStore_Expecter) UsersGetLockedUntil(ctx interface{}, userID interface{}) *MockUsersStore_UsersGetLockedUntil_Call {
+	return &MockUsersStore_UsersGetLockedUntil_Call{Call: _e.mock.On("UsersGetLockedUntil", ctx, userID)}
+}
+
+func (_c *MockUsersStore_UsersGetLockedUntil_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_UsersGetLockedUntil_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersGetLockedUntil_Call) Return(n int64, dBError *models.DBError) *MockUsersStore_UsersGetLockedUntil_Call {
+	_c.Call.Return(n, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersGetLockedUntil_Call) RunAndReturn(run func(ctx *models.Context, userID string) (int64, *models.DBError)) *MockUsersStore_UsersGetLockedUntil_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersLock provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersLock(ctx *models.Context, userID string, until int64) *models.DBError {
+	ret := _mock.Called(ctx, userID, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersLock")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, int64) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_UsersLock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersLock'
+type MockUsersStore_UsersLock_Call struct {
+	*mock.Call
+}
+
+// UsersLock is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - until int64
+func (_e *MockUsersStore_Expecter) UsersLock(ctx interface{}, userID interface{}, until interface{}) *MockUsersStore_UsersLock_Call {
+	return &MockUsersStore_UsersLock_Call{Call: _e.mock.On("UsersLock", ctx, userID, until)}
+}
+
+func (_c *MockUsersStore_UsersLock_Call) Run(run func(ctx *models.Context, userID string, until int64)) *MockUsersStore_UsersLock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersLock_Call) Return(dBError *models.DBError) *MockUsersStore_UsersLock_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersLock_Call) RunAndReturn(run func(ctx *models.Context, userID string, until int64) *models.DBError) *MockUsersStore_UsersLock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersLoginSucceeded provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersLoginSucceeded(ctx *models.Context, userID string) *models.DBError {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersLoginSucceeded")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_UsersLoginSucceeded_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersLoginSucceeded'
+type MockUsersStore_UsersLoginSucceeded_Call struct {
+	*mock.Call
+}
+
+// UsersLoginSucceeded is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+func (_e *MockUsersStore_Expecter) UsersLoginSucceeded(ctx interface{}, userID interface{}) *MockUsersStore_UsersLoginSucceeded_Call {
+	return &MockUsersStore_UsersLoginSucceeded_Call{Call: _e.mock.On("UsersLoginSucceeded", ctx, userID)}
+}
+
+func (_c *MockUsersStore_UsersLoginSucceeded_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_UsersLoginSucceeded_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersLoginSucceeded_Call) Return(dBError *models.DBError) *MockUsersStore_UsersLoginSucceeded_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersLoginSucceeded_Call) RunAndReturn(run func(ctx *models.Context, userID string) *models.DBError) *MockUsersStore_UsersLoginSucceeded_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersMfaActivate provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersMfaActivate(ctx *models.Context, userID string) *models.DBError {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersMfaActivate")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_UsersMfaActivate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersMfaActivate'
+type MockUsersStore_UsersMfaActivate_Call struct {
+	*mock.Call
+}
+
+// UsersMfaActivate is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+func (_e *MockUsersStore_Expecter) UsersMfaActivate(ctx interface{}, userID interface{}) *MockUsersStore_UsersMfaActivate_Call {
+	return &MockUsersStore_UsersMfaActivate_Call{Call: _e.mock.On("UsersMfaActivate", ctx, userID)}
+}
+
+func (_c *MockUsersStore_UsersMfaActivate_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_UsersMfaActivate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersMfaActivate_Call) Return(dBError *models.DBError) *MockUsersStore_UsersMfaActivate_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersMfaActivate_Call) RunAndReturn(run func(ctx *models.Context, userID string) *models.DBError) *MockUsersStore_UsersMfaActivate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersMfaCounterAdvance provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersMfaCounterAdvance(ctx *models.Context, userID string, counter int64) *models.DBError {
+	ret := _mock.Called(ctx, userID, counter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersMfaCounterAdvance")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, int64) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, counter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_UsersMfaCounterAdvance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersMfaCounterAdvance'
+type MockUsersStore_UsersMfaCounterAdvance_Call struct {
+	*mock.Call
+}
+
+// UsersMfaCounterAdvance is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - counter int64
+func (_e *MockUsersStore_Expecter) UsersMfaCounterAdvance(ctx interface{}, userID interface{}, counter interface{}) *MockUsersStore_UsersMfaCounterAdvance_Call {
+	return &MockUsersStore_UsersMfaCounterAdvance_Call{Call: _e.mock.On("UsersMfaCounterAdvance", ctx, userID, counter)}
+}
+
+func (_c *MockUsersStore_UsersMfaCounterAdvance_Call) Run(run func(ctx *models.Context, userID string, counter int64)) *MockUsersStore_UsersMfaCounterAdvance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersMfaCounterAdvance_Call) Return(dBError *models.DBError) *MockUsersStore_UsersMfaCounterAdvance_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersMfaCounterAdvance_Call) RunAndReturn(run func(ctx *models.Context, userID string, counter int64) *models.DBError) *MockUsersStore_UsersMfaCounterAdvance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersMfaDeactivate provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersMfaDeactivate(ctx *models.Context, userID string) *models.DBError {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersMfaDeactivate")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_UsersMfaDeactivate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersMfaDeactivate'
+type MockUsersStore_UsersMfaDeactivate_Call struct {
+	*mock.Call
+}
+
+// UsersMfaDeactivate is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+func (_e *MockUsersStore_Expecter) UsersMfaDeactivate(ctx interface{}, userID interface{}) *MockUsersStore_UsersMfaDeactivate_Call {
+	return &MockUsersStore_UsersMfaDeactivate_Call{Call: _e.mock.On("UsersMfaDeactivate", ctx, userID)}
+}
+
+func (_c *MockUsersStore_UsersMfaDeactivate_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_UsersMfaDeactivate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersMfaDeactivate_Call) Return(dBError *models.DBError) *MockUsersStore_UsersMfaDeactivate_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersMfaDeactivate_Call) RunAndReturn(run func(ctx *models.Context, userID string) *models.DBError) *MockUsersStore_UsersMfaDeactivate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersMfaSecretSet provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersMfaSecretSet(ctx *models.Context, userID string, secret string) *models.DBError {
+	ret := _mock.Called(ctx, userID, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersMfaSecretSet")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
+		}
+	}
+	return r0
+}
+
+// MockUsersStore_UsersMfaSecretSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersMfaSecretSet'
+type MockUsersStore_UsersMfaSecretSet_Call struct {
+	*mock.Call
+}
+
+// UsersMfaSecretSet is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - secret string
+func (_e *MockUsersStore_Expecter) UsersMfaSecretSet(ctx interface{}, userID interface{}, secret interface{}) *MockUsersStore_UsersMfaSecretSet_Call {
+	return &MockUsersStore_UsersMfaSecretSet_Call{Call: _e.mock.On("UsersMfaSecretSet", ctx, userID, secret)}
+}
+
+func (_c *MockUsersStore_UsersMfaSecretSet_Call) Run(run func(ctx *models.Context, userID string, secret string)) *MockUsersStore_UsersMfaSecretSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersMfaSecretSet_Call) Return(dBError *models.DBError) *MockUsersStore_UsersMfaSecretSet_Call {
+	_c.Call.Return(dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersMfaSecretSet_Call) RunAndReturn(run func(ctx *models.Context, userID string, secret string) *models.DBError) *MockUsersStore_UsersMfaSecretSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersPasswordHistoryGet provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersPasswordHistoryGet(ctx *models.Context, userID string, limit int) ([]string, *models.DBError) {
+	ret := _mock.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersPasswordHistoryGet")
+	}
+
+	var r0 []string
+	var r1 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, int) ([]string, *models.DBError)); ok {
+		return returnFunc(ctx, userID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, int) []string); ok {
+		r0 = returnFunc(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Context, string, int) *models.DBError); ok {
+		r1 = returnFunc(ctx, userID, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.DBError)
+		}
+	}
+	return r0, r1
+}
+
+// MockUsersStore_UsersPasswordHistoryGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersPasswordHistoryGet'
+type MockUsersStore_UsersPasswordHistoryGet_Call struct {
+	*mock.Call
+}
+
+// UsersPasswordHistoryGet is a helper method to define mock.On call
+//   - ctx *models.Context
+//   - userID string
+//   - limit int
+func (_e *MockUsersStore_Expecter) UsersPasswordHistoryGet(ctx interface{}, userID interface{}, limit interface{}) *MockUsersStore_UsersPasswordHistoryGet_Call {
+	return &MockUsersStore_UsersPasswordHistoryGet_Call{Call: _e.mock.On("UsersPasswordHistoryGet", ctx, userID, limit)}
+}
+
+func (_c *MockUsersStore_UsersPasswordHistoryGet_Call) Run(run func(ctx *models.Context, userID string, limit int)) *MockUsersStore_UsersPasswordHistoryGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Context
+		if args[0] != nil {
+			arg0 = args[0].(*models.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUsersStore_UsersPasswordHistoryGet_Call) Return(ss []string, dBError *models.DBError) *MockUsersStore_UsersPasswordHistoryGet_Call {
+	_c.Call.Return(ss, dBError)
+	return _c
+}
+
+func (_c *MockUsersStore_UsersPasswordHistoryGet_Call) RunAndReturn(run func(ctx *models.Context, userID string, limit int) ([]string, *models.DBError)) *MockUsersStore_UsersPasswordHistoryGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersPasswordRehash provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersPasswordRehash(ctx *models.Context, userID string, password string) *models.DBError {
+	ret := _mock.Called(ctx, userID, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersPasswordRehash")
+	}
+
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.DBError)
@@ -58,19 +2569,20 @@ func (_mock *MockUsersStore) MarkEmailAsConfirmed(ctx *models.Context, tokenID s
 	return r0
 }
 
-// MockUsersStore_MarkEmailAsConfirmed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkEmailAsConfirmed'
-type MockUsersStore_MarkEmailAsConfirmed_Call struct {
+// MockUsersStore_UsersPasswordRehash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersPasswordRehash'
+type MockUsersStore_UsersPasswordRehash_Call struct {
 	*mock.Call
 }
 
-// MarkEmailAsConfirmed is a helper method to define mock.On call
+// UsersPasswordRehash is a helper method to define mock.On call
 //   - ctx *models.Context
-//   - tokenID string
-func (_e *MockUsersStore_Expecter) MarkEmailAsConfirmed(ctx interface{}, tokenID interface{}) *MockUsersStore_MarkEmailAsConfirmed_Call {
-	return &MockUsersStore_MarkEmailAsConfirmed_Call{Call: _e.mock.On("MarkEmailAsConfirmed", ctx, tokenID)}
+//   - userID string
+//   - password string
+func (_e *MockUsersStore_Expecter) UsersPasswordRehash(ctx interface{}, userID interface{}, password interface{}) *MockUsersStore_UsersPasswordRehash_Call {
+	return &MockUsersStore_UsersPasswordRehash_Call{Call: _e.mock.On("UsersPasswordRehash", ctx, userID, password)}
 }
 
-func (_c *MockUsersStore_MarkEmailAsConfirmed_Call) Run(run func(ctx *models.Context, tokenID string)) *MockUsersStore_MarkEmailAsConfirmed_Call {
+func (_c *MockUsersStore_UsersPasswordRehash_Call) Run(run func(ctx *models.Context, userID string, password string)) *MockUsersStore_UsersPasswordRehash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *models.Context
 		if args[0] != nil {
@@ -80,35 +2592,40 @@ func (_c *MockUsersStore_MarkEmailAsConfirmed_Call) Run(run func(ctx *models.Con
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersStore_MarkEmailAsConfirmed_Call) Return(dBError *models.DBError) *MockUsersStore_MarkEmailAsConfirmed_Call {
+func (_c *MockUsersStore_UsersPasswordRehash_Call) Return(dBError *models.DBError) *MockUsersStore_UsersPasswordRehash_Call {
 	_c.Call.Return(dBError)
 	return _c
 }
 
-func (_c *MockUsersStore_MarkEmailAsConfirmed_Call) RunAndReturn(run func(ctx *models.Context, tokenID string) *models.DBError) *MockUsersStore_MarkEmailAsConfirmed_Call {
+func (_c *MockUsersStore_UsersPasswordRehash_Call) RunAndReturn(run func(ctx *models.Context, userID string, password string) *models.DBError) *MockUsersStore_UsersPasswordRehash_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SignupCustomer provides a mock function for the type MockUsersStore
-func (_mock *MockUsersStore) SignupCustomer(ctx *models.Context, c *v1.User, token *utils.Token) *models.DBError {
-	ret := _mock.Called(ctx, c, token)
+// UsersPasswordReset provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersPasswordReset(ctx *models.Context, userID string, tokenID string, password string, historySize int) *models.DBError {
+	ret := _mock.Called(ctx, userID, tokenID, password, historySize)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SignupCustomer")
+		panic("no return value specified for UsersPasswordReset")
 	}
 
 	var r0 *models.DBError
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, *v1.User, *utils.Token) *models.DBError); ok {
-		r0 = returnFunc(ctx, c, token)
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string, string, int) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, tokenID, password, historySize)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.DBError)
@@ -117,63 +2634,75 @@ func (_mock *MockUsersStore) SignupCustomer(ctx *models.Context, c *v1.User, tok
 	return r0
 }
 
-// MockUsersStore_SignupCustomer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignupCustomer'
-type MockUsersStore_SignupCustomer_Call struct {
+// MockUsersStore_UsersPasswordReset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersPasswordReset'
+type MockUsersStore_UsersPasswordReset_Call struct {
 	*mock.Call
 }
 
-// SignupCustomer is a helper method to define mock.On call
+// UsersPasswordReset is a helper method to define mock.On call
 //   - ctx *models.Context
-//   - c *v1.User
-//   - token *utils.Token
-func (_e *MockUsersStore_Expecter) SignupCustomer(ctx interface{}, c interface{}, token interface{}) *MockUsersStore_SignupCustomer_Call {
-	return &MockUsersStore_SignupCustomer_Call{Call: _e.mock.On("SignupCustomer", ctx, c, token)}
+//   - userID string
+//   - tokenID string
+//   - password string
+//   - historySize int
+func (_e *MockUsersStore_Expecter) UsersPasswordReset(ctx interface{}, userID interface{}, tokenID interface{}, password interface{}, historySize interface{}) *MockUsersStore_UsersPasswordReset_Call {
+	return &MockUsersStore_UsersPasswordReset_Call{Call: _e.mock.On("UsersPasswordReset", ctx, userID, tokenID, password, historySize)}
 }
 
-func (_c *MockUsersStore_SignupCustomer_Call) Run(run func(ctx *models.Context, c *v1.User, token *utils.Token)) *MockUsersStore_SignupCustomer_Call {
+func (_c *MockUsersStore_UsersPasswordReset_Call) Run(run func(ctx *models.Context, userID string, tokenID string, password string, historySize int)) *MockUsersStore_UsersPasswordReset_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *models.Context
 		if args[0] != nil {
 			arg0 = args[0].(*models.Context)
 		}
-		var arg1 *v1.User
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*v1.User)
+			arg1 = args[1].(string)
 		}
-		var arg2 *utils.Token
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*utils.Token)
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersStore_SignupCustomer_Call) Return(dBError *models.DBError) *MockUsersStore_SignupCustomer_Call {
+func (_c *MockUsersStore_UsersPasswordReset_Call) Return(dBError *models.DBError) *MockUsersStore_UsersPasswordReset_Call {
 	_c.Call.Return(dBError)
 	return _c
 }
 
-func (_c *MockUsersStore_SignupCustomer_Call) RunAndReturn(run func(ctx *models.Context, c *v1.User, token *utils.Token) *models.DBError) *MockUsersStore_SignupCustomer_Call {
+func (_c *MockUsersStore_UsersPasswordReset_Call) RunAndReturn(run func(ctx *models.Context, userID string, tokenID string, password string, historySize int) *models.DBError) *MockUsersStore_UsersPasswordReset_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SignupSupplier provides a mock function for the type MockUsersStore
-func (_mock *MockUsersStore) SignupSupplier(ctx *models.Context, s *v1.User, token *utils.Token) *models.DBError {
-	ret := _mock.Called(ctx, s, token)
+// UsersPasswordUpdate provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersPasswordUpdate(ctx *models.Context, userID string, password string, historySize int) *models.DBError {
+	ret := _mock.Called(ctx, userID, password, historySize)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SignupSupplier")
+		panic("no return value specified for UsersPasswordUpdate")
 	}
 
 	var r0 *models.DBError
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, *v1.User, *utils.Token) *models.DBError); ok {
-		r0 = returnFunc(ctx, s, token)
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, string, int) *models.DBError); ok {
+		r0 = returnFunc(ctx, userID, password, historySize)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.DBError)
@@ -182,63 +2711,69 @@ func (_mock *MockUsersStore) SignupSupplier(ctx *models.Context, s *v1.User, tok
 	return r0
 }
 
-// MockUsersStore_SignupSupplier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignupSupplier'
-type MockUsersStore_SignupSupplier_Call struct {
+// MockUsersStore_UsersPasswordUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersPasswordUpdate'
+type MockUsersStore_UsersPasswordUpdate_Call struct {
 	*mock.Call
 }
 
-// SignupSupplier is a helper method to define mock.On call
+// UsersPasswordUpdate is a helper method to define mock.On call
 //   - ctx *models.Context
-//   - s *v1.User
-//   - token *utils.Token
-func (_e *MockUsersStore_Expecter) SignupSupplier(ctx interface{}, s interface{}, token interface{}) *MockUsersStore_SignupSupplier_Call {
-	return &MockUsersStore_SignupSupplier_Call{Call: _e.mock.On("SignupSupplier", ctx, s, token)}
+//   - userID string
+//   - password string
+//   - historySize int
+func (_e *MockUsersStore_Expecter) UsersPasswordUpdate(ctx interface{}, userID interface{}, password interface{}, historySize interface{}) *MockUsersStore_UsersPasswordUpdate_Call {
+	return &MockUsersStore_UsersPasswordUpdate_Call{Call: _e.mock.On("UsersPasswordUpdate", ctx, userID, password, historySize)}
 }
 
-func (_c *MockUsersStore_SignupSupplier_Call) Run(run func(ctx *models.Context, s *v1.User, token *utils.Token)) *MockUsersStore_SignupSupplier_Call {
+func (_c *MockUsersStore_UsersPasswordUpdate_Call) Run(run func(ctx *models.Context, userID string, password string, historySize int)) *MockUsersStore_UsersPasswordUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *models.Context
 		if args[0] != nil {
 			arg0 = args[0].(*models.Context)
 		}
-		var arg1 *v1.User
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*v1.User)
+			arg1 = args[1].(string)
 		}
-		var arg2 *utils.Token
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(*utils.Token)
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersStore_SignupSupplier_Call) Return(dBError *models.DBError) *MockUsersStore_SignupSupplier_Call {
+func (_c *MockUsersStore_UsersPasswordUpdate_Call) Return(dBError *models.DBError) *MockUsersStore_UsersPasswordUpdate_Call {
 	_c.Call.Return(dBError)
 	return _c
 }
 
-func (_c *MockUsersStore_SignupSupplier_Call) RunAndReturn(run func(ctx *models.Context, s *v1.User, token *utils.Token) *models.DBError) *MockUsersStore_SignupSupplier_Call {
+func (_c *MockUsersStore_UsersPasswordUpdate_Call) RunAndReturn(run func(ctx *models.Context, userID string, password string, historySize int) *models.DBError) *MockUsersStore_UsersPasswordUpdate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TokensAdd provides a mock function for the type MockUsersStore
-func (_mock *MockUsersStore) TokensAdd(ctx *models.Context, userID string, token *utils.Token, tokenType models0.TokenType, path string) *models.DBError {
-	ret := _mock.Called(ctx, userID, token, tokenType, path)
+// UsersSocialCreate provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) UsersSocialCreate(ctx *models.Context, u *v1.User, identity *models0.Identity) *models.DBError {
+	ret := _mock.Called(ctx, u, identity)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TokensAdd")
+		panic("no return value specified for UsersSocialCreate")
 	}
 
 	var r0 *models.DBError
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string, *utils.Token, models0.TokenType, string) *models.DBError); ok {
-		r0 = returnFunc(ctx, userID, token, tokenType, path)
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, *v1.User, *models0.Identity) *models.DBError); ok {
+		r0 = returnFunc(ctx, u, identity)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.DBError)
@@ -247,113 +2782,92 @@ func (_mock *MockUsersStore) TokensAdd(ctx *models.Context, userID string, token
 	return r0
 }
 
-// MockUsersStore_TokensAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensAdd'
-type MockUsersStore_TokensAdd_Call struct {
+// MockUsersStore_UsersSocialCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersSocialCreate'
+type MockUsersStore_UsersSocialCreate_Call struct {
 	*mock.Call
 }
 
-// TokensAdd is a helper method to define mock.On call
+// UsersSocialCreate is a helper method to define mock.On call
 //   - ctx *models.Context
-//   - userID string
-//   - token *utils.Token
-//   - tokenType models0.TokenType
-//   - path string
-func (_e *MockUsersStore_Expecter) TokensAdd(ctx interface{}, userID interface{}, token interface{}, tokenType interface{}, path interface{}) *MockUsersStore_TokensAdd_Call {
-	return &MockUsersStore_TokensAdd_Call{Call: _e.mock.On("TokensAdd", ctx, userID, token, tokenType, path)}
+//   - u *v1.User
+//   - identity *models0.Identity
+func (_e *MockUsersStore_Expecter) UsersSocialCreate(ctx interface{}, u interface{}, identity interface{}) *MockUsersStore_UsersSocialCreate_Call {
+	return &MockUsersStore_UsersSocialCreate_Call{Call: _e.mock.On("UsersSocialCreate", ctx, u, identity)}
 }
 
-func (_c *MockUsersStore_TokensAdd_Call) Run(run func(ctx *models.Context, userID string, token *utils.Token, tokenType models0.TokenType, path string)) *MockUsersStore_TokensAdd_Call {
+func (_c *MockUsersStore_UsersSocialCreate_Call) Run(run func(ctx *models.Context, u *v1.User, identity *models0.Identity)) *MockUsersStore_UsersSocialCreate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *models.Context
 		if args[0] != nil {
 			arg0 = args[0].(*models.Context)
 		}
-		var arg1 string
+		var arg1 *v1.User
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*v1.User)
 		}
-		var arg2 *utils.Token
+		var arg2 *models0.Identity
 		if args[2] != nil {
-			arg2 = args[2].(*utils.Token)
-		}
-		var arg3 models0.TokenType
-		if args[3] != nil {
-			arg3 = args[3].(models0.TokenType)
-		}
-		var arg4 string
-		if args[4] != nil {
-			arg4 = args[4].(string)
+			arg2 = args[2].(*models0.Identity)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUsersStore_TokensAdd_Call) Return(dBError *models.DBError) *MockUsersStore_TokensAdd_Call {
+func (_c *MockUsersStore_UsersSocialCreate_Call) Return(dBError *models.DBError) *MockUsersStore_UsersSocialCreate_Call {
 	_c.Call.Return(dBError)
 	return _c
 }
 
-func (_c *MockUsersStore_TokensAdd_Call) RunAndReturn(run func(ctx *models.Context, userID string, token *utils.Token, tokenType models0.TokenType, path string) *models.DBError) *MockUsersStore_TokensAdd_Call {
+func (_c *MockUsersStore_UsersSocialCreate_Call) RunAndReturn(run func(ctx *models.Context, u *v1.User, identity *models0.Identity) *models.DBError) *MockUsersStore_UsersSocialCreate_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TokensDeleteAllPasswordResetByUserID provides a mock function for the type MockUsersStore
-func (_mock *MockUsersStore) TokensDeleteAllPasswordResetByUserID(ctx *models.Context, userID string) (int64, *models.DBError) {
-	ret := _mock.Called(ctx, userID)
+// WebauthnCredentialsAdd provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) WebauthnCredentialsAdd(ctx *models.Context, c *models0.WebauthnCredential) *models.DBError {
+	ret := _mock.Called(ctx, c)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TokensDeleteAllPasswordResetByUserID")
+		panic("no return value specified for WebauthnCredentialsAdd")
 	}
 
-	var r0 int64
-	var r1 *models.DBError
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (int64, *models.DBError)); ok {
-		return returnFunc(ctx, userID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) int64); ok {
-		r0 = returnFunc(ctx, userID)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
-		r1 = returnFunc(ctx, userID)
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, *models0.WebauthnCredential) *models.DBError); ok {
+		r0 = returnFunc(ctx, c)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*models.DBError)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DBError)
 		}
 	}
-	return r0, r1
+	return r0
 }
 
-// MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensDeleteAllPasswordResetByUserID'
-type MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call struct {
+// MockUsersStore_WebauthnCredentialsAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebauthnCredentialsAdd'
+type MockUsersStore_WebauthnCredentialsAdd_Call struct {
 	*mock.Call
 }
 
-// TokensDeleteAllPasswordResetByUserID is a helper method to define mock.On call
+// WebauthnCredentialsAdd is a helper method to define mock.On call
 //   - ctx *models.Context
-//   - userID string
-func (_e *MockUsersStore_Expecter) TokensDeleteAllPasswordResetByUserID(ctx interface{}, userID interface{}) *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call {
-	return &MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call{Call: _e.mock.On("TokensDeleteAllPasswordResetByUserID", ctx, userID)}
+//   - c *models0.WebauthnCredential
+func (_e *MockUsersStore_Expecter) WebauthnCredentialsAdd(ctx interface{}, c interface{}) *MockUsersStore_WebauthnCredentialsAdd_Call {
+	return &MockUsersStore_WebauthnCredentialsAdd_Call{Call: _e.mock.On("WebauthnCredentialsAdd", ctx, c)}
 }
 
-func (_c *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call {
+func (_c *MockUsersStore_WebauthnCredentialsAdd_Call) Run(run func(ctx *models.Context, c *models0.WebauthnCredential)) *MockUsersStore_WebauthnCredentialsAdd_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *models.Context
 		if args[0] != nil {
 			arg0 = args[0].(*models.Context)
 		}
-		var arg1 string
+		var arg1 *models0.WebauthnCredential
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*models0.WebauthnCredential)
 		}
 		run(
 			arg0,
@@ -363,38 +2877,38 @@ func (_c *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call) Run(run func
 	return _c
 }
 
-func (_c *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call) Return(n int64, dBError *models.DBError) *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call {
-	_c.Call.Return(n, dBError)
+func (_c *MockUsersStore_WebauthnCredentialsAdd_Call) Return(dBError *models.DBError) *MockUsersStore_WebauthnCredentialsAdd_Call {
+	_c.Call.Return(dBError)
 	return _c
 }
 
-func (_c *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call) RunAndReturn(run func(ctx *models.Context, userID string) (int64, *models.DBError)) *MockUsersStore_TokensDeleteAllPasswordResetByUserID_Call {
+func (_c *MockUsersStore_WebauthnCredentialsAdd_Call) RunAndReturn(run func(ctx *models.Context, c *models0.WebauthnCredential) *models.DBError) *MockUsersStore_WebauthnCredentialsAdd_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TokensGet provides a mock function for the type MockUsersStore
-func (_mock *MockUsersStore) TokensGet(ctx *models.Context, tokenID string) (*v1.Token, *models.DBError) {
-	ret := _mock.Called(ctx, tokenID)
+// WebauthnCredentialsGetByUserID provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) WebauthnCredentialsGetByUserID(ctx *models.Context, userID string) ([]*models0.WebauthnCredential, *models.DBError) {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TokensGet")
+		panic("no return value specified for WebauthnCredentialsGetByUserID")
 	}
 
-	var r0 *v1.Token
+	var r0 []*models0.WebauthnCredential
 	var r1 *models.DBError
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (*v1.Token, *models.DBError)); ok {
-		return returnFunc(ctx, tokenID)
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) ([]*models0.WebauthnCredential, *models.DBError)); ok {
+		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *v1.Token); ok {
-		r0 = returnFunc(ctx, tokenID)
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) []*models0.WebauthnCredential); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.Token)
+			r0 = ret.Get(0).([]*models0.WebauthnCredential)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
-		r1 = returnFunc(ctx, tokenID)
+		r1 = returnFunc(ctx, userID)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*models.DBError)
@@ -403,19 +2917,19 @@ func (_mock *MockUsersStore) TokensGet(ctx *models.Context, tokenID string) (*v1
 	return r0, r1
 }
 
-// MockUsersStore_TokensGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensGet'
-type MockUsersStore_TokensGet_Call struct {
+// MockUsersStore_WebauthnCredentialsGetByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebauthnCredentialsGetByUserID'
+type MockUsersStore_WebauthnCredentialsGetByUserID_Call struct {
 	*mock.Call
 }
 
-// TokensGet is a helper method to define mock.On call
+// WebauthnCredentialsGetByUserID is a helper method to define mock.On call
 //   - ctx *models.Context
-//   - tokenID string
-func (_e *MockUsersStore_Expecter) TokensGet(ctx interface{}, tokenID interface{}) *MockUsersStore_TokensGet_Call {
-	return &MockUsersStore_TokensGet_Call{Call: _e.mock.On("TokensGet", ctx, tokenID)}
+//   - userID string
+func (_e *MockUsersStore_Expecter) WebauthnCredentialsGetByUserID(ctx interface{}, userID interface{}) *MockUsersStore_WebauthnCredentialsGetByUserID_Call {
+	return &MockUsersStore_WebauthnCredentialsGetByUserID_Call{Call: _e.mock.On("WebauthnCredentialsGetByUserID", ctx, userID)}
 }
 
-func (_c *MockUsersStore_TokensGet_Call) Run(run func(ctx *models.Context, tokenID string)) *MockUsersStore_TokensGet_Call {
+func (_c *MockUsersStore_WebauthnCredentialsGetByUserID_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_WebauthnCredentialsGetByUserID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *models.Context
 		if args[0] != nil {
@@ -433,67 +2947,56 @@ func (_c *MockUsersStore_TokensGet_Call) Run(run func(ctx *models.Context, token
 	return _c
 }
 
-func (_c *MockUsersStore_TokensGet_Call) Return(token *v1.Token, dBError *models.DBError) *MockUsersStore_TokensGet_Call {
-	_c.Call.Return(token, dBError)
+func (_c *MockUsersStore_WebauthnCredentialsGetByUserID_Call) Return(webauthnCredentials []*models0.WebauthnCredential, dBError *models.DBError) *MockUsersStore_WebauthnCredentialsGetByUserID_Call {
+	_c.Call.Return(webauthnCredentials, dBError)
 	return _c
 }
 
-func (_c *MockUsersStore_TokensGet_Call) RunAndReturn(run func(ctx *models.Context, tokenID string) (*v1.Token, *models.DBError)) *MockUsersStore_TokensGet_Call {
+func (_c *MockUsersStore_WebauthnCredentialsGetByUserID_Call) RunAndReturn(run func(ctx *models.Context, userID string) ([]*models0.WebauthnCredential, *models.DBError)) *MockUsersStore_WebauthnCredentialsGetByUserID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TokensGetAllByUserID provides a mock function for the type MockUsersStore
-func (_mock *MockUsersStore) TokensGetAllByUserID(ctx *models.Context, userID string) ([]*v1.Token, *models.DBError) {
-	ret := _mock.Called(ctx, userID)
+// WebauthnCredentialsUpdateUsage provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) WebauthnCredentialsUpdateUsage(ctx *models.Context, c *models0.WebauthnCredential) *models.DBError {
+	ret := _mock.Called(ctx, c)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TokensGetAllByUserID")
+		panic("no return value specified for WebauthnCredentialsUpdateUsage")
 	}
 
-	var r0 []*v1.Token
-	var r1 *models.DBError
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) ([]*v1.Token, *models.DBError)); ok {
-		return returnFunc(ctx, userID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) []*v1.Token); ok {
-		r0 = returnFunc(ctx, userID)
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, *models0.WebauthnCredential) *models.DBError); ok {
+		r0 = returnFunc(ctx, c)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*v1.Token)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
-		r1 = returnFunc(ctx, userID)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*models.DBError)
+			r0 = ret.Get(0).(*models.DBError)
 		}
 	}
-	return r0, r1
+	return r0
 }
 
-// MockUsersStore_TokensGetAllByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TokensGetAllByUserID'
-type MockUsersStore_TokensGetAllByUserID_Call struct {
+// MockUsersStore_WebauthnCredentialsUpdateUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebauthnCredentialsUpdateUsage'
+type MockUsersStore_WebauthnCredentialsUpdateUsage_Call struct {
 	*mock.Call
 }
 
-// TokensGetAllByUserID is a helper method to define mock.On call
+// WebauthnCredentialsUpdateUsage is a helper method to define mock.On call
 //   - ctx *models.Context
-//   - userID string
-func (_e *MockUsersStore_Expecter) TokensGetAllByUserID(ctx interface{}, userID interface{}) *MockUsersStore_TokensGetAllByUserID_Call {
-	return &MockUsersStore_TokensGetAllByUserID_Call{Call: _e.mock.On("TokensGetAllByUserID", ctx, userID)}
+//   - c *models0.WebauthnCredential
+func (_e *MockUsersStore_Expecter) WebauthnCredentialsUpdateUsage(ctx interface{}, c interface{}) *MockUsersStore_WebauthnCredentialsUpdateUsage_Call {
+	return &MockUsersStore_WebauthnCredentialsUpdateUsage_Call{Call: _e.mock.On("WebauthnCredentialsUpdateUsage", ctx, c)}
 }
 
-func (_c *MockUsersStore_TokensGetAllByUserID_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_TokensGetAllByUserID_Call {
+func (_c *MockUsersStore_WebauthnCredentialsUpdateUsage_Call) Run(run func(ctx *models.Context, c *models0.WebauthnCredential)) *MockUsersStore_WebauthnCredentialsUpdateUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *models.Context
 		if args[0] != nil {
 			arg0 = args[0].(*models.Context)
 		}
-		var arg1 string
+		var arg1 *models0.WebauthnCredential
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*models0.WebauthnCredential)
 		}
 		run(
 			arg0,
@@ -503,67 +3006,56 @@ func (_c *MockUsersStore_TokensGetAllByUserID_Call) Run(run func(ctx *models.Con
 	return _c
 }
 
-func (_c *MockUsersStore_TokensGetAllByUserID_Call) Return(tokens []*v1.Token, dBError *models.DBError) *MockUsersStore_TokensGetAllByUserID_Call {
-	_c.Call.Return(tokens, dBError)
+func (_c *MockUsersStore_WebauthnCredentialsUpdateUsage_Call) Return(dBError *models.DBError) *MockUsersStore_WebauthnCredentialsUpdateUsage_Call {
+	_c.Call.Return(dBError)
 	return _c
 }
 
-func (_c *MockUsersStore_TokensGetAllByUserID_Call) RunAndReturn(run func(ctx *models.Context, userID string) ([]*v1.Token, *models.DBError)) *MockUsersStore_TokensGetAllByUserID_Call {
+func (_c *MockUsersStore_WebauthnCredentialsUpdateUsage_Call) RunAndReturn(run func(ctx *models.Context, c *models0.WebauthnCredential) *models.DBError) *MockUsersStore_WebauthnCredentialsUpdateUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UsersGetByEmail provides a mock function for the type MockUsersStore
-func (_mock *MockUsersStore) UsersGetByEmail(ctx *models.Context, email string) (*v1.User, *models.DBError) {
-	ret := _mock.Called(ctx, email)
+// WebauthnSessionsAdd provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) WebauthnSessionsAdd(ctx *models.Context, s *models0.WebauthnSession) *models.DBError {
+	ret := _mock.Called(ctx, s)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UsersGetByEmail")
+		panic("no return value specified for WebauthnSessionsAdd")
 	}
 
-	var r0 *v1.User
-	var r1 *models.DBError
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (*v1.User, *models.DBError)); ok {
-		return returnFunc(ctx, email)
-	}
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *v1.User); ok {
-		r0 = returnFunc(ctx, email)
+	var r0 *models.DBError
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, *models0.WebauthnSession) *models.DBError); ok {
+		r0 = returnFunc(ctx, s)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.User)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
-		r1 = returnFunc(ctx, email)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*models.DBError)
+			r0 = ret.Get(0).(*models.DBError)
 		}
 	}
-	return r0, r1
+	return r0
 }
 
-// MockUsersStore_UsersGetByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersGetByEmail'
-type MockUsersStore_UsersGetByEmail_Call struct {
+// MockUsersStore_WebauthnSessionsAdd_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebauthnSessionsAdd'
+type MockUsersStore_WebauthnSessionsAdd_Call struct {
 	*mock.Call
 }
 
-// UsersGetByEmail is a helper method to define mock.On call
+// WebauthnSessionsAdd is a helper method to define mock.On call
 //   - ctx *models.Context
-//   - email string
-func (_e *MockUsersStore_Expecter) UsersGetByEmail(ctx interface{}, email interface{}) *MockUsersStore_UsersGetByEmail_Call {
-	return &MockUsersStore_UsersGetByEmail_Call{Call: _e.mock.On("UsersGetByEmail", ctx, email)}
+//   - s *models0.WebauthnSession
+func (_e *MockUsersStore_Expecter) WebauthnSessionsAdd(ctx interface{}, s interface{}) *MockUsersStore_WebauthnSessionsAdd_Call {
+	return &MockUsersStore_WebauthnSessionsAdd_Call{Call: _e.mock.On("WebauthnSessionsAdd", ctx, s)}
 }
 
-func (_c *MockUsersStore_UsersGetByEmail_Call) Run(run func(ctx *models.Context, email string)) *MockUsersStore_UsersGetByEmail_Call {
+func (_c *MockUsersStore_WebauthnSessionsAdd_Call) Run(run func(ctx *models.Context, s *models0.WebauthnSession)) *MockUsersStore_WebauthnSessionsAdd_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *models.Context
 		if args[0] != nil {
 			arg0 = args[0].(*models.Context)
 		}
-		var arg1 string
+		var arg1 *models0.WebauthnSession
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*models0.WebauthnSession)
 		}
 		run(
 			arg0,
@@ -573,38 +3065,38 @@ func (_c *MockUsersStore_UsersGetByEmail_Call) Run(run func(ctx *models.Context,
 	return _c
 }
 
-func (_c *MockUsersStore_UsersGetByEmail_Call) Return(user *v1.User, dBError *models.DBError) *MockUsersStore_UsersGetByEmail_Call {
-	_c.Call.Return(user, dBError)
+func (_c *MockUsersStore_WebauthnSessionsAdd_Call) Return(dBError *models.DBError) *MockUsersStore_WebauthnSessionsAdd_Call {
+	_c.Call.Return(dBError)
 	return _c
 }
 
-func (_c *MockUsersStore_UsersGetByEmail_Call) RunAndReturn(run func(ctx *models.Context, email string) (*v1.User, *models.DBError)) *MockUsersStore_UsersGetByEmail_Call {
+func (_c *MockUsersStore_WebauthnSessionsAdd_Call) RunAndReturn(run func(ctx *models.Context, s *models0.WebauthnSession) *models.DBError) *MockUsersStore_WebauthnSessionsAdd_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UsersGetByID provides a mock function for the type MockUsersStore
-func (_mock *MockUsersStore) UsersGetByID(ctx *models.Context, userID string) (*v1.User, *models.DBError) {
-	ret := _mock.Called(ctx, userID)
+// WebauthnSessionsTake provides a mock function for the type MockUsersStore
+func (_mock *MockUsersStore) WebauthnSessionsTake(ctx *models.Context, id string) (*models0.WebauthnSession, *models.DBError) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UsersGetByID")
+		panic("no return value specified for WebauthnSessionsTake")
 	}
 
-	var r0 *v1.User
+	var r0 *models0.WebauthnSession
 	var r1 *models.DBError
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (*v1.User, *models.DBError)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) (*models0.WebauthnSession, *models.DBError)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *v1.User); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(*models.Context, string) *models0.WebauthnSession); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1.User)
+			r0 = ret.Get(0).(*models0.WebauthnSession)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(*models.Context, string) *models.DBError); ok {
-		r1 = returnFunc(ctx, userID)
+		r1 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*models.DBError)
@@ -613,19 +3105,19 @@ func (_mock *MockUsersStore) UsersGetByID(ctx *models.Context, userID string) (*
 	return r0, r1
 }
 
-// MockUsersStore_UsersGetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersGetByID'
-type MockUsersStore_UsersGetByID_Call struct {
+// MockUsersStore_WebauthnSessionsTake_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebauthnSessionsTake'
+type MockUsersStore_WebauthnSessionsTake_Call struct {
 	*mock.Call
 }
 
-// UsersGetByID is a helper method to define mock.On call
+// WebauthnSessionsTake is a helper method to define mock.On call
 //   - ctx *models.Context
-//   - userID string
-func (_e *MockUsersStore_Expecter) UsersGetByID(ctx interface{}, userID interface{}) *MockUsersStore_UsersGetByID_Call {
-	return &MockUsersStore_UsersGetByID_Call{Call: _e.mock.On("UsersGetByID", ctx, userID)}
+//   - id string
+func (_e *MockUsersStore_Expecter) WebauthnSessionsTake(ctx interface{}, id interface{}) *MockUsersStore_WebauthnSessionsTake_Call {
+	return &MockUsersStore_WebauthnSessionsTake_Call{Call: _e.mock.On("WebauthnSessionsTake", ctx, id)}
 }
 
-func (_c *MockUsersStore_UsersGetByID_Call) Run(run func(ctx *models.Context, userID string)) *MockUsersStore_UsersGetByID_Call {
+func (_c *MockUsersStore_WebauthnSessionsTake_Call) Run(run func(ctx *models.Context, id string)) *MockUsersStore_WebauthnSessionsTake_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 *models.Context
 		if args[0] != nil {
@@ -643,12 +3135,12 @@ func (_c *MockUsersStore_UsersGetByID_Call) Run(run func(ctx *models.Context, us
 	return _c
 }
 
-func (_c *MockUsersStore_UsersGetByID_Call) Return(user *v1.User, dBError *models.DBError) *MockUsersStore_UsersGetByID_Call {
-	_c.Call.Return(user, dBError)
+func (_c *MockUsersStore_WebauthnSessionsTake_Call) Return(webauthnSession *models0.WebauthnSession, dBError *models.DBError) *MockUsersStore_WebauthnSessionsTake_Call {
+	_c.Call.Return(webauthnSession, dBError)
 	return _c
 }
 
-func (_c *MockUsersStore_UsersGetByID_Call) RunAndReturn(run func(ctx *models.Context, userID string) (*v1.User, *models.DBError)) *MockUsersStore_UsersGetByID_Call {
+func (_c *MockUsersStore_WebauthnSessionsTake_Call) RunAndReturn(run func(ctx *models.Context, id string) (*models0.WebauthnSession, *models.DBError)) *MockUsersStore_WebauthnSessionsTake_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2,6 +2,8 @@
 package store
 
 import (
+	"time"
+
 	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
@@ -11,12 +13,74 @@ import (
 type UsersStore interface {
 	SignupSupplier(ctx *models.Context, s *pb.User, token *utils.Token) *models.DBError
 	SignupCustomer(ctx *models.Context, c *pb.User, token *utils.Token) *models.DBError
-	MarkEmailAsConfirmed(ctx *models.Context, tokenID string) *models.DBError
+	// MarkEmailAsConfirmed consumes the token and verifies the email of the user owning it
+	MarkEmailAsConfirmed(ctx *models.Context, tokenID, email string) *models.DBError
 	UsersGetByEmail(ctx *models.Context, email string) (*pb.User, *models.DBError)
+	// UsersGetByUsername matches the username case insensitively, preferring the exact match
+	UsersGetByUsername(ctx *models.Context, username string) (*pb.User, *models.DBError)
 	UsersGetByID(ctx *models.Context, userID string) (*pb.User, *models.DBError)
+	UsersGetLockedUntil(ctx *models.Context, userID string) (int64, *models.DBError)
+	// UsersFailedAttemptsIncrement returns the failed login attempts after incrementing them
+	UsersFailedAttemptsIncrement(ctx *models.Context, userID string) (int32, *models.DBError)
+	UsersLock(ctx *models.Context, userID string, until int64) *models.DBError
+	UsersLoginSucceeded(ctx *models.Context, userID string) *models.DBError
+	// UsersPasswordUpdate sets the new password and keeps historySize passwords (the new one included) in the history
+	UsersPasswordUpdate(ctx *models.Context, userID, password string, historySize int) *models.DBError
+	// UsersPasswordRehash replaces the hash of the same password, so last_password_update is kept
+	UsersPasswordRehash(ctx *models.Context, userID, password string) *models.DBError
+	// UsersPasswordReset sets the new password and consumes the reset token, removing the other reset tokens
+	UsersPasswordReset(ctx *models.Context, userID, tokenID, password string, historySize int) *models.DBError
+	// UsersPasswordHistoryGet returns the latest limit previous password hashes of the user, newest first
+	UsersPasswordHistoryGet(ctx *models.Context, userID string, limit int) ([]string, *models.DBError)
+	// UsersGetByIdentity returns the user owning the provider account
+	UsersGetByIdentity(ctx *models.Context, provider, subject string) (*pb.User, *models.DBError)
+	// UsersSocialCreate stores a user signed up through a social provider along with the identity
+	UsersSocialCreate(ctx *models.Context, u *pb.User, identity *intModels.Identity) *models.DBError
+	UsersMfaSecretSet(ctx *models.Context, userID string, secret string) *models.DBError
+	UsersMfaActivate(ctx *models.Context, userID string) *models.DBError
+	UsersMfaCounterAdvance(ctx *models.Context, userID string, counter int64) *models.DBError
+	UsersMfaDeactivate(ctx *models.Context, userID string) *models.DBError
 	TokensGet(ctx *models.Context, tokenID string) (*pb.Token, *models.DBError)
 	TokensGetAllByUserID(ctx *models.Context, userID string) ([]*pb.Token, *models.DBError)
+	TokensMarkUsed(ctx *models.Context, tokenID string) *models.DBError
+	// TokensGetUnusedByType returns the unused and unexpired tokens of the given type for a user
+	TokensGetUnusedByType(ctx *models.Context, userID string, tokenType intModels.TokenType) ([]*pb.Token, *models.DBError)
+	TokensMfaRecoveryCodesReplace(ctx *models.Context, userID string, tokens []*utils.Token) *models.DBError
 	TokensAdd(ctx *models.Context, userID string, token *utils.Token, tokenType intModels.TokenType, path string) *models.DBError
 	// TokensDeleteAllPasswordResetByUserID returns the number of deleted rows(or 0), error
 	TokensDeleteAllPasswordResetByUserID(ctx *models.Context, userID string) (int64, *models.DBError)
+	// TokensGetCreatedAtSince returns the creation times of the user's tokens of the given type created after since
+	TokensGetCreatedAtSince(ctx *models.Context, userID string, tokenType intModels.TokenType, since int64) ([]int64, *models.DBError)
+	// TokensRotate expires the outstanding tokens of the given type of the user and stores the new one
+	TokensRotate(ctx *models.Context, userID string, token *utils.Token, tokenType intModels.TokenType) *models.DBError
+	// TokensAttemptsIncrement returns the failed attempts of the token after incrementing them
+	TokensAttemptsIncrement(ctx *models.Context, tokenID string) (int32, *models.DBError)
+	// IdentitiesGetByUserID returns the identities of the user, oldest first
+	IdentitiesGetByUserID(ctx *models.Context, userID string) ([]*intModels.Identity, *models.DBError)
+	// IdentitiesAdd links the identity, DBErrorTypeUniqueViolation means the provider account is already linked
+	IdentitiesAdd(ctx *models.Context, i *intModels.Identity) *models.DBError
+	// IdentitiesDelete unlinks the identity of the user unless it's the last login method (false is returned),
+	// DBErrorTypeNoRows means the user has no such identity
+	IdentitiesDelete(ctx *models.Context, userID, identityID string) (bool, *models.DBError)
+	// UserSessionsAdd stores the login session, or refreshes its device details if it's already stored
+	UserSessionsAdd(ctx *models.Context, s *intModels.UserSession) *models.DBError
+	// UserSessionsGetByUserID returns the login sessions of the user, the most recently seen first
+	UserSessionsGetByUserID(ctx *models.Context, userID string) ([]*intModels.UserSession, *models.DBError)
+	// UserSessionsTouch updates the last seen time of the session, at most once per interval
+	UserSessionsTouch(ctx *models.Context, userID, sessionID string, at int64, interval time.Duration) *models.DBError
+	// UserSessionsDelete removes the session of the user, DBErrorTypeNoRows means the user has no such session
+	UserSessionsDelete(ctx *models.Context, userID, sessionID string) *models.DBError
+	// UserSessionsDeleteAll removes the sessions of the user, except the one of keepID (if not empty)
+	UserSessionsDeleteAll(ctx *models.Context, userID, keepID string) *models.DBError
+	// UserLoginDevicesAdd stores the login device, and returns what was known about it before
+	UserLoginDevicesAdd(ctx *models.Context, d *intModels.LoginDevice) (*intModels.LoginDeviceHistory, *models.DBError)
+	SocialLoginStatesAdd(ctx *models.Context, s *intModels.SocialLoginState) *models.DBError
+	// SocialLoginStatesTake deletes and returns the state, so a callback can only be handled once
+	SocialLoginStatesTake(ctx *models.Context, id string) (*intModels.SocialLoginState, *models.DBError)
+	WebauthnCredentialsGetByUserID(ctx *models.Context, userID string) ([]*intModels.WebauthnCredential, *models.DBError)
+	WebauthnCredentialsAdd(ctx *models.Context, c *intModels.WebauthnCredential) *models.DBError
+	WebauthnCredentialsUpdateUsage(ctx *models.Context, c *intModels.WebauthnCredential) *models.DBError
+	WebauthnSessionsAdd(ctx *models.Context, s *intModels.WebauthnSession) *models.DBError
+	// WebauthnSessionsTake deletes and returns the session, so a ceremony can only be finished once
+	WebauthnSessionsTake(ctx *models.Context, id string) (*intModels.WebauthnSession, *models.DBError)
 }
@@ -0,0 +1,57 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	intModels "github.com/ahmad-khatib0-org/megacommerce-user/pkg/models"
+	"github.com/hibiken/asynq"
+	"google.golang.org/grpc/codes"
+)
+
+// SendMfaRecoveryCodeUsedEmail implements TaskDistributor.
+func (atp *AsynqTaksDistributor) SendMfaRecoveryCodeUsedEmail(context context.Context, payload *intModels.TaskSendMfaRecoveryCodeUsedEmailPayload, opts ...asynq.Option) *models.AppError {
+	path := "user.worker.SendMfaRecoveryCodeUsedEmail"
+	ctx, Err := models.ContextGet(context)
+	if Err != nil {
+		return Err
+	}
+
+	pay, err := json.Marshal(payload)
+	if err != nil {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, fmt.Sprintf("failed to marshal json payload, err: %v", err), int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	task := asynq.NewTask(string(intModels.TaskNameSendMfaRecoveryCodeUsedEmail), pay, opts...)
+	info, err := atp.cli.EnqueueContext(context, task)
+	if err != nil {
+		return models.NewAppError(ctx, path, models.ErrMsgInternal, nil, fmt.Sprintf("failed to enqueue a task , err: %v", err), int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	if atp.config().Main.GetEnv() == "dev" {
+		atp.log.Infof("enqueued task: %v", info)
+	}
+
+	return nil
+}
+
+// ProcessSendMfaRecoveryCodeUsedEmail implements TaskProcessor.
+func (atp *AsynqTaksProcessor) ProcessSendMfaRecoveryCodeUsedEmail(context context.Context, task *asynq.Task) error {
+	path := "user.worker.ProcessSendMfaRecoveryCodeUsedEmail"
+	var pay intModels.TaskSendMfaRecoveryCodeUsedEmailPayload
+	if err := json.Unmarshal(task.Payload(), &pay); err != nil {
+		return models.NewAppError(pay.Ctx, path, models.ErrMsgInternal, nil, fmt.Sprintf("failed to unmarshal json payload, err: %v", err), int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	if err := atp.mailer.SendMfaRecoveryCodeUsedEmail(pay.Ctx.GetAcceptLanguage(), pay.Email, pay.Remaining); err != nil {
+		return models.NewAppError(pay.Ctx, path, models.ErrMsgInternal, nil, fmt.Sprintf("failed to send an email, err: %v", err), int(codes.Internal), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	if atp.config().Main.GetEnv() == "dev" {
+		atp.log.Infof("processed: %s task successfully", intModels.TaskNameSendMfaRecoveryCodeUsedEmail)
+	}
+
+	return nil
+}
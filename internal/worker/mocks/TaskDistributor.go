@@ -40,6 +40,450 @@ func (_m *MockTaskDistributor) EXPECT() *MockTaskDistributor_Expecter {
 	return &MockTaskDistributor_Expecter{mock: &_m.Mock}
 }
 
+// SendAccountLockedEmail provides a mock function for the type MockTaskDistributor
+func (_mock *MockTaskDistributor) SendAccountLockedEmail(ctx context.Context, pay *models.TaskSendAccountLockedEmailPayload, opts ...asynq.Option) *models0.AppError {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, pay, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, pay)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendAccountLockedEmail")
+	}
+
+	var r0 *models0.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.TaskSendAccountLockedEmailPayload, ...asynq.Option) *models0.AppError); ok {
+		r0 = returnFunc(ctx, pay, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models0.AppError)
+		}
+	}
+	return r0
+}
+
+// MockTaskDistributor_SendAccountLockedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendAccountLockedEmail'
+type MockTaskDistributor_SendAccountLockedEmail_Call struct {
+	*mock.Call
+}
+
+// SendAccountLockedEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pay *models.TaskSendAccountLockedEmailPayload
+//   - opts ...asynq.Option
+func (_e *MockTaskDistributor_Expecter) SendAccountLockedEmail(ctx interface{}, pay interface{}, opts ...interface{}) *MockTaskDistributor_SendAccountLockedEmail_Call {
+	return &MockTaskDistributor_SendAccountLockedEmail_Call{Call: _e.mock.On("SendAccountLockedEmail",
+		append([]interface{}{ctx, pay}, opts...)...)}
+}
+
+func (_c *MockTaskDistributor_SendAccountLockedEmail_Call) Run(run func(ctx context.Context, pay *models.TaskSendAccountLockedEmailPayload, opts ...asynq.Option)) *MockTaskDistributor_SendAccountLockedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.TaskSendAccountLockedEmailPayload
+		if args[1] != nil {
+			arg1 = args[1].(*models.TaskSendAccountLockedEmailPayload)
+		}
+		var arg2 []asynq.Option
+		var variadicArgs []asynq.Option
+		if len(args) > 2 {
+			variadicArgs = args[2].([]asynq.Option)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendAccountLockedEmail_Call) Return(appError *models0.AppError) *MockTaskDistributor_SendAccountLockedEmail_Call {
+	_c.Call.Return(appError)
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendAccountLockedEmail_Call) RunAndReturn(run func(ctx context.Context, pay *models.TaskSendAccountLockedEmailPayload, opts ...asynq.Option) *models0.AppError) *MockTaskDistributor_SendAccountLockedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendEmailLoginEmail provides a mock function for the type MockTaskDistributor
+func (_mock *MockTaskDistributor) SendEmailLoginEmail(ctx context.Context, pay *models.TaskSendEmailLoginEmailPayload, opts ...asynq.Option) *models0.AppError {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, pay, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, pay)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendEmailLoginEmail")
+	}
+
+	var r0 *models0.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.TaskSendEmailLoginEmailPayload, ...asynq.Option) *models0.AppError); ok {
+		r0 = returnFunc(ctx, pay, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models0.AppError)
+		}
+	}
+	return r0
+}
+
+// MockTaskDistributor_SendEmailLoginEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendEmailLoginEmail'
+type MockTaskDistributor_SendEmailLoginEmail_Call struct {
+	*mock.Call
+}
+
+// SendEmailLoginEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pay *models.TaskSendEmailLoginEmailPayload
+//   - opts ...asynq.Option
+func (_e *MockTaskDistributor_Expecter) SendEmailLoginEmail(ctx interface{}, pay interface{}, opts ...interface{}) *MockTaskDistributor_SendEmailLoginEmail_Call {
+	return &MockTaskDistributor_SendEmailLoginEmail_Call{Call: _e.mock.On("SendEmailLoginEmail",
+		append([]interface{}{ctx, pay}, opts...)...)}
+}
+
+func (_c *MockTaskDistributor_SendEmailLoginEmail_Call) Run(run func(ctx context.Context, pay *models.TaskSendEmailLoginEmailPayload, opts ...asynq.Option)) *MockTaskDistributor_SendEmailLoginEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.TaskSendEmailLoginEmailPayload
+		if args[1] != nil {
+			arg1 = args[1].(*models.TaskSendEmailLoginEmailPayload)
+		}
+		var arg2 []asynq.Option
+		var variadicArgs []asynq.Option
+		if len(args) > 2 {
+			variadicArgs = args[2].([]asynq.Option)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendEmailLoginEmail_Call) Return(appError *models0.AppError) *MockTaskDistributor_SendEmailLoginEmail_Call {
+	_c.Call.Return(appError)
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendEmailLoginEmail_Call) RunAndReturn(run func(ctx context.Context, pay *models.TaskSendEmailLoginEmailPayload, opts ...asynq.Option) *models0.AppError) *MockTaskDistributor_SendEmailLoginEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendMfaRecoveryCodeUsedEmail provides a mock function for the type MockTaskDistributor
+func (_mock *MockTaskDistributor) SendMfaRecoveryCodeUsedEmail(ctx context.Context, pay *models.TaskSendMfaRecoveryCodeUsedEmailPayload, opts ...asynq.Option) *models0.AppError {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, pay, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, pay)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMfaRecoveryCodeUsedEmail")
+	}
+
+	var r0 *models0.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.TaskSendMfaRecoveryCodeUsedEmailPayload, ...asynq.Option) *models0.AppError); ok {
+		r0 = returnFunc(ctx, pay, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models0.AppError)
+		}
+	}
+	return r0
+}
+
+// MockTaskDistributor_SendMfaRecoveryCodeUsedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendMfaRecoveryCodeUsedEmail'
+type MockTaskDistributor_SendMfaRecoveryCodeUsedEmail_Call struct {
+	*mock.Call
+}
+
+// SendMfaRecoveryCodeUsedEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pay *models.TaskSendMfaRecoveryCodeUsedEmailPayload
+//   - opts ...asynq.Option
+func (_e *MockTaskDistributor_Expecter) SendMfaRecoveryCodeUsedEmail(ctx interface{}, pay interface{}, opts ...interface{}) *MockTaskDistributor_SendMfaRecoveryCodeUsedEmail_Call {
+	return &MockTaskDistributor_SendMfaRecoveryCodeUsedEmail_Call{Call: _e.mock.On("SendMfaRecoveryCodeUsedEmail",
+		append([]interface{}{ctx, pay}, opts...)...)}
+}
+
+func (_c *MockTaskDistributor_SendMfaRecoveryCodeUsedEmail_Call) Run(run func(ctx context.Context, pay *models.TaskSendMfaRecoveryCodeUsedEmailPayload, opts ...asynq.Option)) *MockTaskDistributor_SendMfaRecoveryCodeUsedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.TaskSendMfaRecoveryCodeUsedEmailPayload
+		if args[1] != nil {
+			arg1 = args[1].(*models.TaskSendMfaRecoveryCodeUsedEmailPayload)
+		}
+		var arg2 []asynq.Option
+		var variadicArgs []asynq.Option
+		if len(args) > 2 {
+			variadicArgs = args[2].([]asynq.Option)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendMfaRecoveryCodeUsedEmail_Call) Return(appError *models0.AppError) *MockTaskDistributor_SendMfaRecoveryCodeUsedEmail_Call {
+	_c.Call.Return(appError)
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendMfaRecoveryCodeUsedEmail_Call) RunAndReturn(run func(ctx context.Context, pay *models.TaskSendMfaRecoveryCodeUsedEmailPayload, opts ...asynq.Option) *models0.AppError) *MockTaskDistributor_SendMfaRecoveryCodeUsedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendNewDeviceLoginEmail provides a mock function for the type MockTaskDistributor
+func (_mock *MockTaskDistributor) SendNewDeviceLoginEmail(ctx context.Context, pay *models.TaskSendNewDeviceLoginEmailPayload, opts ...asynq.Option) *models0.AppError {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, pay, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, pay)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendNewDeviceLoginEmail")
+	}
+
+	var r0 *models0.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.TaskSendNewDeviceLoginEmailPayload, ...asynq.Option) *models0.AppError); ok {
+		r0 = returnFunc(ctx, pay, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models0.AppError)
+		}
+	}
+	return r0
+}
+
+// MockTaskDistributor_SendNewDeviceLoginEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendNewDeviceLoginEmail'
+type MockTaskDistributor_SendNewDeviceLoginEmail_Call struct {
+	*mock.Call
+}
+
+// SendNewDeviceLoginEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pay *models.TaskSendNewDeviceLoginEmailPayload
+//   - opts ...asynq.Option
+func (_e *MockTaskDistributor_Expecter) SendNewDeviceLoginEmail(ctx interface{}, pay interface{}, opts ...interface{}) *MockTaskDistributor_SendNewDeviceLoginEmail_Call {
+	return &MockTaskDistributor_SendNewDeviceLoginEmail_Call{Call: _e.mock.On("SendNewDeviceLoginEmail",
+		append([]interface{}{ctx, pay}, opts...)...)}
+}
+
+func (_c *MockTaskDistributor_SendNewDeviceLoginEmail_Call) Run(run func(ctx context.Context, pay *models.TaskSendNewDeviceLoginEmailPayload, opts ...asynq.Option)) *MockTaskDistributor_SendNewDeviceLoginEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.TaskSendNewDeviceLoginEmailPayload
+		if args[1] != nil {
+			arg1 = args[1].(*models.TaskSendNewDeviceLoginEmailPayload)
+		}
+		var arg2 []asynq.Option
+		var variadicArgs []asynq.Option
+		if len(args) > 2 {
+			variadicArgs = args[2].([]asynq.Option)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendNewDeviceLoginEmail_Call) Return(appError *models0.AppError) *MockTaskDistributor_SendNewDeviceLoginEmail_Call {
+	_c.Call.Return(appError)
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendNewDeviceLoginEmail_Call) RunAndReturn(run func(ctx context.Context, pay *models.TaskSendNewDeviceLoginEmailPayload, opts ...asynq.Option) *models0.AppError) *MockTaskDistributor_SendNewDeviceLoginEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendPasswordChangedEmail provides a mock function for the type MockTaskDistributor
+func (_mock *MockTaskDistributor) SendPasswordChangedEmail(ctx context.Context, pay *models.TaskSendPasswordChangedEmailPayload, opts ...asynq.Option) *models0.AppError {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, pay, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, pay)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendPasswordChangedEmail")
+	}
+
+	var r0 *models0.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.TaskSendPasswordChangedEmailPayload, ...asynq.Option) *models0.AppError); ok {
+		r0 = returnFunc(ctx, pay, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models0.AppError)
+		}
+	}
+	return r0
+}
+
+// MockTaskDistributor_SendPasswordChangedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendPasswordChangedEmail'
+type MockTaskDistributor_SendPasswordChangedEmail_Call struct {
+	*mock.Call
+}
+
+// SendPasswordChangedEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pay *models.TaskSendPasswordChangedEmailPayload
+//   - opts ...asynq.Option
+func (_e *MockTaskDistributor_Expecter) SendPasswordChangedEmail(ctx interface{}, pay interface{}, opts ...interface{}) *MockTaskDistributor_SendPasswordChangedEmail_Call {
+	return &MockTaskDistributor_SendPasswordChangedEmail_Call{Call: _e.mock.On("SendPasswordChangedEmail",
+		append([]interface{}{ctx, pay}, opts...)...)}
+}
+
+func (_c *MockTaskDistributor_SendPasswordChangedEmail_Call) Run(run func(ctx context.Context, pay *models.TaskSendPasswordChangedEmailPayload, opts ...asynq.Option)) *MockTaskDistributor_SendPasswordChangedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.TaskSendPasswordChangedEmailPayload
+		if args[1] != nil {
+			arg1 = args[1].(*models.TaskSendPasswordChangedEmailPayload)
+		}
+		var arg2 []asynq.Option
+		var variadicArgs []asynq.Option
+		if len(args) > 2 {
+			variadicArgs = args[2].([]asynq.Option)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendPasswordChangedEmail_Call) Return(appError *models0.AppError) *MockTaskDistributor_SendPasswordChangedEmail_Call {
+	_c.Call.Return(appError)
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendPasswordChangedEmail_Call) RunAndReturn(run func(ctx context.Context, pay *models.TaskSendPasswordChangedEmailPayload, opts ...asynq.Option) *models0.AppError) *MockTaskDistributor_SendPasswordChangedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendPasswordForgotNoticeEmail provides a mock function for the type MockTaskDistributor
+func (_mock *MockTaskDistributor) SendPasswordForgotNoticeEmail(ctx context.Context, pay *models.TaskSendPasswordForgotNoticeEmailPayload, opts ...asynq.Option) *models0.AppError {
+	var tmpRet mock.Arguments
+	if len(opts) > 0 {
+		tmpRet = _mock.Called(ctx, pay, opts)
+	} else {
+		tmpRet = _mock.Called(ctx, pay)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendPasswordForgotNoticeEmail")
+	}
+
+	var r0 *models0.AppError
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.TaskSendPasswordForgotNoticeEmailPayload, ...asynq.Option) *models0.AppError); ok {
+		r0 = returnFunc(ctx, pay, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models0.AppError)
+		}
+	}
+	return r0
+}
+
+// MockTaskDistributor_SendPasswordForgotNoticeEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendPasswordForgotNoticeEmail'
+type MockTaskDistributor_SendPasswordForgotNoticeEmail_Call struct {
+	*mock.Call
+}
+
+// SendPasswordForgotNoticeEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pay *models.TaskSendPasswordForgotNoticeEmailPayload
+//   - opts ...asynq.Option
+func (_e *MockTaskDistributor_Expecter) SendPasswordForgotNoticeEmail(ctx interface{}, pay interface{}, opts ...interface{}) *MockTaskDistributor_SendPasswordForgotNoticeEmail_Call {
+	return &MockTaskDistributor_SendPasswordForgotNoticeEmail_Call{Call: _e.mock.On("SendPasswordForgotNoticeEmail",
+		append([]interface{}{ctx, pay}, opts...)...)}
+}
+
+func (_c *MockTaskDistributor_SendPasswordForgotNoticeEmail_Call) Run(run func(ctx context.Context, pay *models.TaskSendPasswordForgotNoticeEmailPayload, opts ...asynq.Option)) *MockTaskDistributor_SendPasswordForgotNoticeEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.TaskSendPasswordForgotNoticeEmailPayload
+		if args[1] != nil {
+			arg1 = args[1].(*models.TaskSendPasswordForgotNoticeEmailPayload)
+		}
+		var arg2 []asynq.Option
+		var variadicArgs []asynq.Option
+		if len(args) > 2 {
+			variadicArgs = args[2].([]asynq.Option)
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendPasswordForgotNoticeEmail_Call) Return(appError *models0.AppError) *MockTaskDistributor_SendPasswordForgotNoticeEmail_Call {
+	_c.Call.Return(appError)
+	return _c
+}
+
+func (_c *MockTaskDistributor_SendPasswordForgotNoticeEmail_Call) RunAndReturn(run func(ctx context.Context, pay *models.TaskSendPasswordForgotNoticeEmailPayload, opts ...asynq.Option) *models0.AppError) *MockTaskDistributor_SendPasswordForgotNoticeEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendPasswordResetEmail provides a mock function for the type MockTaskDistributor
 func (_mock *MockTaskDistributor) SendPasswordResetEmail(ctx context.Context, pay *models.TaskSendPasswordResetEmailPayload, opts ...asynq.Option) *models0.AppError {
 	var tmpRet mock.Arguments
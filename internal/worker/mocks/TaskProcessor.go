@@ -38,6 +38,348 @@ func (_m *MockTaskProcessor) EXPECT() *MockTaskProcessor_Expecter {
 	return &MockTaskProcessor_Expecter{mock: &_m.Mock}
 }
 
+// ProcessSendAccountLockedEmail provides a mock function for the type MockTaskProcessor
+func (_mock *MockTaskProcessor) ProcessSendAccountLockedEmail(ctx context.Context, task *asynq.Task) error {
+	ret := _mock.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessSendAccountLockedEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *asynq.Task) error); ok {
+		r0 = returnFunc(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTaskProcessor_ProcessSendAccountLockedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessSendAccountLockedEmail'
+type MockTaskProcessor_ProcessSendAccountLockedEmail_Call struct {
+	*mock.Call
+}
+
+// ProcessSendAccountLockedEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *asynq.Task
+func (_e *MockTaskProcessor_Expecter) ProcessSendAccountLockedEmail(ctx interface{}, task interface{}) *MockTaskProcessor_ProcessSendAccountLockedEmail_Call {
+	return &MockTaskProcessor_ProcessSendAccountLockedEmail_Call{Call: _e.mock.On("ProcessSendAccountLockedEmail", ctx, task)}
+}
+
+func (_c *MockTaskProcessor_ProcessSendAccountLockedEmail_Call) Run(run func(ctx context.Context, task *asynq.Task)) *MockTaskProcessor_ProcessSendAccountLockedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *asynq.Task
+		if args[1] != nil {
+			arg1 = args[1].(*asynq.Task)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendAccountLockedEmail_Call) Return(err error) *MockTaskProcessor_ProcessSendAccountLockedEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendAccountLockedEmail_Call) RunAndReturn(run func(ctx context.Context, task *asynq.Task) error) *MockTaskProcessor_ProcessSendAccountLockedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessSendEmailLoginEmail provides a mock function for the type MockTaskProcessor
+func (_mock *MockTaskProcessor) ProcessSendEmailLoginEmail(ctx context.Context, task *asynq.Task) error {
+	ret := _mock.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessSendEmailLoginEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *asynq.Task) error); ok {
+		r0 = returnFunc(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTaskProcessor_ProcessSendEmailLoginEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessSendEmailLoginEmail'
+type MockTaskProcessor_ProcessSendEmailLoginEmail_Call struct {
+	*mock.Call
+}
+
+// ProcessSendEmailLoginEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *asynq.Task
+func (_e *MockTaskProcessor_Expecter) ProcessSendEmailLoginEmail(ctx interface{}, task interface{}) *MockTaskProcessor_ProcessSendEmailLoginEmail_Call {
+	return &MockTaskProcessor_ProcessSendEmailLoginEmail_Call{Call: _e.mock.On("ProcessSendEmailLoginEmail", ctx, task)}
+}
+
+func (_c *MockTaskProcessor_ProcessSendEmailLoginEmail_Call) Run(run func(ctx context.Context, task *asynq.Task)) *MockTaskProcessor_ProcessSendEmailLoginEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *asynq.Task
+		if args[1] != nil {
+			arg1 = args[1].(*asynq.Task)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendEmailLoginEmail_Call) Return(err error) *MockTaskProcessor_ProcessSendEmailLoginEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendEmailLoginEmail_Call) RunAndReturn(run func(ctx context.Context, task *asynq.Task) error) *MockTaskProcessor_ProcessSendEmailLoginEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessSendMfaRecoveryCodeUsedEmail provides a mock function for the type MockTaskProcessor
+func (_mock *MockTaskProcessor) ProcessSendMfaRecoveryCodeUsedEmail(ctx context.Context, task *asynq.Task) error {
+	ret := _mock.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessSendMfaRecoveryCodeUsedEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *asynq.Task) error); ok {
+		r0 = returnFunc(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTaskProcessor_ProcessSendMfaRecoveryCodeUsedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessSendMfaRecoveryCodeUsedEmail'
+type MockTaskProcessor_ProcessSendMfaRecoveryCodeUsedEmail_Call struct {
+	*mock.Call
+}
+
+// ProcessSendMfaRecoveryCodeUsedEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *asynq.Task
+func (_e *MockTaskProcessor_Expecter) ProcessSendMfaRecoveryCodeUsedEmail(ctx interface{}, task interface{}) *MockTaskProcessor_ProcessSendMfaRecoveryCodeUsedEmail_Call {
+	return &MockTaskProcessor_ProcessSendMfaRecoveryCodeUsedEmail_Call{Call: _e.mock.On("ProcessSendMfaRecoveryCodeUsedEmail", ctx, task)}
+}
+
+func (_c *MockTaskProcessor_ProcessSendMfaRecoveryCodeUsedEmail_Call) Run(run func(ctx context.Context, task *asynq.Task)) *MockTaskProcessor_ProcessSendMfaRecoveryCodeUsedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *asynq.Task
+		if args[1] != nil {
+			arg1 = args[1].(*asynq.Task)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendMfaRecoveryCodeUsedEmail_Call) Return(err error) *MockTaskProcessor_ProcessSendMfaRecoveryCodeUsedEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendMfaRecoveryCodeUsedEmail_Call) RunAndReturn(run func(ctx context.Context, task *asynq.Task) error) *MockTaskProcessor_ProcessSendMfaRecoveryCodeUsedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessSendNewDeviceLoginEmail provides a mock function for the type MockTaskProcessor
+func (_mock *MockTaskProcessor) ProcessSendNewDeviceLoginEmail(ctx context.Context, task *asynq.Task) error {
+	ret := _mock.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessSendNewDeviceLoginEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *asynq.Task) error); ok {
+		r0 = returnFunc(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTaskProcessor_ProcessSendNewDeviceLoginEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessSendNewDeviceLoginEmail'
+type MockTaskProcessor_ProcessSendNewDeviceLoginEmail_Call struct {
+	*mock.Call
+}
+
+// ProcessSendNewDeviceLoginEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *asynq.Task
+func (_e *MockTaskProcessor_Expecter) ProcessSendNewDeviceLoginEmail(ctx interface{}, task interface{}) *MockTaskProcessor_ProcessSendNewDeviceLoginEmail_Call {
+	return &MockTaskProcessor_ProcessSendNewDeviceLoginEmail_Call{Call: _e.mock.On("ProcessSendNewDeviceLoginEmail", ctx, task)}
+}
+
+func (_c *MockTaskProcessor_ProcessSendNewDeviceLoginEmail_Call) Run(run func(ctx context.Context, task *asynq.Task)) *MockTaskProcessor_ProcessSendNewDeviceLoginEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *asynq.Task
+		if args[1] != nil {
+			arg1 = args[1].(*asynq.Task)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendNewDeviceLoginEmail_Call) Return(err error) *MockTaskProcessor_ProcessSendNewDeviceLoginEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendNewDeviceLoginEmail_Call) RunAndReturn(run func(ctx context.Context, task *asynq.Task) error) *MockTaskProcessor_ProcessSendNewDeviceLoginEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessSendPasswordChangedEmail provides a mock function for the type MockTaskProcessor
+func (_mock *MockTaskProcessor) ProcessSendPasswordChangedEmail(ctx context.Context, task *asynq.Task) error {
+	ret := _mock.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessSendPasswordChangedEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *asynq.Task) error); ok {
+		r0 = returnFunc(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTaskProcessor_ProcessSendPasswordChangedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessSendPasswordChangedEmail'
+type MockTaskProcessor_ProcessSendPasswordChangedEmail_Call struct {
+	*mock.Call
+}
+
+// ProcessSendPasswordChangedEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *asynq.Task
+func (_e *MockTaskProcessor_Expecter) ProcessSendPasswordChangedEmail(ctx interface{}, task interface{}) *MockTaskProcessor_ProcessSendPasswordChangedEmail_Call {
+	return &MockTaskProcessor_ProcessSendPasswordChangedEmail_Call{Call: _e.mock.On("ProcessSendPasswordChangedEmail", ctx, task)}
+}
+
+func (_c *MockTaskProcessor_ProcessSendPasswordChangedEmail_Call) Run(run func(ctx context.Context, task *asynq.Task)) *MockTaskProcessor_ProcessSendPasswordChangedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *asynq.Task
+		if args[1] != nil {
+			arg1 = args[1].(*asynq.Task)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendPasswordChangedEmail_Call) Return(err error) *MockTaskProcessor_ProcessSendPasswordChangedEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendPasswordChangedEmail_Call) RunAndReturn(run func(ctx context.Context, task *asynq.Task) error) *MockTaskProcessor_ProcessSendPasswordChangedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessSendPasswordForgotNoticeEmail provides a mock function for the type MockTaskProcessor
+func (_mock *MockTaskProcessor) ProcessSendPasswordForgotNoticeEmail(ctx context.Context, task *asynq.Task) error {
+	ret := _mock.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessSendPasswordForgotNoticeEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *asynq.Task) error); ok {
+		r0 = returnFunc(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTaskProcessor_ProcessSendPasswordForgotNoticeEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessSendPasswordForgotNoticeEmail'
+type MockTaskProcessor_ProcessSendPasswordForgotNoticeEmail_Call struct {
+	*mock.Call
+}
+
+// ProcessSendPasswordForgotNoticeEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *asynq.Task
+func (_e *MockTaskProcessor_Expecter) ProcessSendPasswordForgotNoticeEmail(ctx interface{}, task interface{}) *MockTaskProcessor_ProcessSendPasswordForgotNoticeEmail_Call {
+	return &MockTaskProcessor_ProcessSendPasswordForgotNoticeEmail_Call{Call: _e.mock.On("ProcessSendPasswordForgotNoticeEmail", ctx, task)}
+}
+
+func (_c *MockTaskProcessor_ProcessSendPasswordForgotNoticeEmail_Call) Run(run func(ctx context.Context, task *asynq.Task)) *MockTaskProcessor_ProcessSendPasswordForgotNoticeEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *asynq.Task
+		if args[1] != nil {
+			arg1 = args[1].(*asynq.Task)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendPasswordForgotNoticeEmail_Call) Return(err error) *MockTaskProcessor_ProcessSendPasswordForgotNoticeEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTaskProcessor_ProcessSendPasswordForgotNoticeEmail_Call) RunAndReturn(run func(ctx context.Context, task *asynq.Task) error) *MockTaskProcessor_ProcessSendPasswordForgotNoticeEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ProcessSendPasswordResetEmail provides a mock function for the type MockTaskProcessor
 func (_mock *MockTaskProcessor) ProcessSendPasswordResetEmail(ctx context.Context, task *asynq.Task) error {
 	ret := _mock.Called(ctx, task)
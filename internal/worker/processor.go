@@ -16,6 +16,12 @@ type TaskProcessor interface {
 	Start() error
 	ProcessSendVerifyEmail(ctx context.Context, task *asynq.Task) error
 	ProcessSendPasswordResetEmail(ctx context.Context, task *asynq.Task) error
+	ProcessSendAccountLockedEmail(ctx context.Context, task *asynq.Task) error
+	ProcessSendMfaRecoveryCodeUsedEmail(ctx context.Context, task *asynq.Task) error
+	ProcessSendPasswordChangedEmail(ctx context.Context, task *asynq.Task) error
+	ProcessSendEmailLoginEmail(ctx context.Context, task *asynq.Task) error
+	ProcessSendPasswordForgotNoticeEmail(ctx context.Context, task *asynq.Task) error
+	ProcessSendNewDeviceLoginEmail(ctx context.Context, task *asynq.Task) error
 }
 
 const (
@@ -66,5 +72,11 @@ func (atp *AsynqTaksProcessor) Start() error {
 
 	mux.HandleFunc(string(models.TaskNameSendVerifyEmail), atp.ProcessSendVerifyEmail)
 	mux.HandleFunc(string(models.TaskNameSendPasswordResetEmail), atp.ProcessSendPasswordResetEmail)
+	mux.HandleFunc(string(models.TaskNameSendAccountLockedEmail), atp.ProcessSendAccountLockedEmail)
+	mux.HandleFunc(string(models.TaskNameSendMfaRecoveryCodeUsedEmail), atp.ProcessSendMfaRecoveryCodeUsedEmail)
+	mux.HandleFunc(string(models.TaskNameSendPasswordChangedEmail), atp.ProcessSendPasswordChangedEmail)
+	mux.HandleFunc(string(models.TaskNameSendEmailLoginEmail), atp.ProcessSendEmailLoginEmail)
+	mux.HandleFunc(string(models.TaskNameSendPasswordForgotNoticeEmail), atp.ProcessSendPasswordForgotNoticeEmail)
+	mux.HandleFunc(string(models.TaskNameSendNewDeviceLoginEmail), atp.ProcessSendNewDeviceLoginEmail)
 	return atp.server.Start(mux)
 }
@@ -14,6 +14,12 @@ import (
 type TaskDistributor interface {
 	SendVerifyEmail(ctx context.Context, pay *intModels.TaskSendVerifyEmailPayload, opts ...asynq.Option) *models.AppError
 	SendPasswordResetEmail(ctx context.Context, pay *intModels.TaskSendPasswordResetEmailPayload, opts ...asynq.Option) *models.AppError
+	SendAccountLockedEmail(ctx context.Context, pay *intModels.TaskSendAccountLockedEmailPayload, opts ...asynq.Option) *models.AppError
+	SendMfaRecoveryCodeUsedEmail(ctx context.Context, pay *intModels.TaskSendMfaRecoveryCodeUsedEmailPayload, opts ...asynq.Option) *models.AppError
+	SendPasswordChangedEmail(ctx context.Context, pay *intModels.TaskSendPasswordChangedEmailPayload, opts ...asynq.Option) *models.AppError
+	SendEmailLoginEmail(ctx context.Context, pay *intModels.TaskSendEmailLoginEmailPayload, opts ...asynq.Option) *models.AppError
+	SendPasswordForgotNoticeEmail(ctx context.Context, pay *intModels.TaskSendPasswordForgotNoticeEmailPayload, opts ...asynq.Option) *models.AppError
+	SendNewDeviceLoginEmail(ctx context.Context, pay *intModels.TaskSendNewDeviceLoginEmailPayload, opts ...asynq.Option) *models.AppError
 }
 
 type TaskDistributorArgs struct {
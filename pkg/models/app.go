@@ -3,14 +3,37 @@ package models
 type EventName string
 
 const (
-	EventNameSupplierCreate     = "supplier_create"
-	EventNameCustomerCreate     = "customer_create"
-	EventNameEmailConfirmation  = "email_confirmation"
-	EventNamePasswordForgot     = "password_forgot"
-	EventNameLogin              = "login"
-	EventNameCustomerProfileGet = "customer_profile_get"
-	EventNameSupplierProfileGet = "supplier_profile_get"
-	EventNameDashboardGet       = "dashboard_get"
+	EventNameSupplierCreate             = "supplier_create"
+	EventNameCustomerCreate             = "customer_create"
+	EventNameEmailConfirmation          = "email_confirmation"
+	EventNamePasswordForgot             = "password_forgot"
+	EventNameLogin                      = "login"
+	EventNameCustomerProfileGet         = "customer_profile_get"
+	EventNameSupplierProfileGet         = "supplier_profile_get"
+	EventNameDashboardGet               = "dashboard_get"
+	EventNameMfaEnroll                  = "mfa_enroll"
+	EventNameMfaConfirm                 = "mfa_confirm"
+	EventNameMfaDisable                 = "mfa_disable"
+	EventNameMfaRecoveryCodesRegenerate = "mfa_recovery_codes_regenerate"
+	EventNameMfaRecoveryCodeRedeem      = "mfa_recovery_code_redeem"
+	EventNameWebauthnRegister           = "webauthn_register"
+	EventNameWebauthnLogin              = "webauthn_login"
+	EventNamePasswordReset              = "password_reset"
+	EventNamePasswordChange             = "password_change"
+	EventNameResendVerificationEmail    = "resend_verification_email"
+	EventNameEmailLoginBegin            = "email_login_begin"
+	EventNameEmailLogin                 = "email_login"
+	EventNameLogout                     = "logout"
+	EventNameConsentAccept              = "consent_accept"
+	EventNameConsentReject              = "consent_reject"
+	EventNameIdentitiesList             = "identities_list"
+	EventNameIdentityLinkBegin          = "identity_link_begin"
+	EventNameIdentityLinkConfirm        = "identity_link_confirm"
+	EventNameIdentityUnlink             = "identity_unlink"
+	EventNameSessionsList               = "sessions_list"
+	EventNameSessionRevoke              = "session_revoke"
+	EventNameSessionsRevokeOthers       = "sessions_revoke_others"
+	EventNameLoginNotMe                 = "login_not_me"
 )
 
 type TokenType string
@@ -18,4 +41,9 @@ type TokenType string
 const (
 	TokenTypePasswordReset     TokenType = "password_reset"
 	TokenTypeEmailConfirmation TokenType = "email_confirmation"
+	TokenTypeMfaChallenge      TokenType = "mfa_challenge"
+	TokenTypeMfaRecoveryCode   TokenType = "mfa_recovery_code"
+	TokenTypeEmailLogin        TokenType = "email_login"
+	// TokenTypeLoginNotMe is the token of the "this wasn't me" link of the new sign-in alerts
+	TokenTypeLoginNotMe TokenType = "login_not_me"
 )
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+)
+
+// breachedPasswordsLineMax is larger than any line of the corpus (40 hex chars, a colon and the count)
+const breachedPasswordsLineMax = 128
+
+// BreachedPasswords looks up passwords in a local copy of the HIBP Pwned Passwords
+// corpus (the "ordered by hash" SHA-1 download, one HASH:COUNT per line), using a
+// binary search on the file, so the corpus is never loaded into memory
+type BreachedPasswords struct {
+	file *os.File
+	size int64
+}
+
+// BreachedPasswordsOpen opens the sorted corpus file at path
+func BreachedPasswordsOpen(path string) (*BreachedPasswords, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &BreachedPasswords{file: f, size: info.Size()}, nil
+}
+
+func (b *BreachedPasswords) Close() error {
+	return b.file.Close()
+}
+
+// Contains reports whether the password is in the corpus
+func (b *BreachedPasswords) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	target := bytes.ToUpper([]byte(hex.EncodeToString(sum[:])))
+
+	// the smallest offset whose (first line starting at or after it) hash is >= target
+	lo, hi := int64(0), b.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		hash, err := b.hashAt(mid)
+		if err != nil {
+			return false, err
+		}
+		if hash == nil || bytes.Compare(hash, target) >= 0 {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	hash, err := b.hashAt(lo)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(hash, target), nil
+}
+
+// hashAt returns the upper cased hash of the first line starting at or after off, nil at the end of the file
+func (b *BreachedPasswords) hashAt(off int64) ([]byte, error) {
+	start := off
+	if off > 0 {
+		// the line starts after the first newline found from the previous byte
+		buf := make([]byte, breachedPasswordsLineMax)
+		n, err := b.file.ReadAt(buf, off-1)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		i := bytes.IndexByte(buf[:n], '\n')
+		if i < 0 {
+			return nil, nil
+		}
+		start = off + int64(i)
+	}
+	if start >= b.size {
+		return nil, nil
+	}
+
+	buf := make([]byte, breachedPasswordsLineMax)
+	n, err := b.file.ReadAt(buf, start)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	line := buf[:n]
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	if i := bytes.IndexByte(line, ':'); i >= 0 {
+		line = line[:i]
+	}
+	return bytes.ToUpper(bytes.TrimSpace(line)), nil
+}
+
+// PasswordIsBreached reports whether the password is in the breached passwords corpus, it's
+// always false if there is no corpus (b is nil), or the lookup failed
+func PasswordIsBreached(b *BreachedPasswords, password string) bool {
+	if b == nil {
+		return false
+	}
+
+	found, err := b.Contains(password)
+	return err == nil && found
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreachedPasswordsContains(t *testing.T) {
+	breached := []string{"password", "123456", "qwerty", "letmein", "Passw0rd!"}
+	for i := range 500 {
+		breached = append(breached, fmt.Sprintf("filler-%d", i))
+	}
+
+	lines := make([]string, 0, len(breached))
+	for i, p := range breached {
+		sum := sha1.Sum([]byte(p))
+		lines = append(lines, fmt.Sprintf("%s:%d", strings.ToUpper(hex.EncodeToString(sum[:])), i+1))
+	}
+	slices.Sort(lines)
+
+	path := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\r\n")+"\r\n"), 0o600))
+
+	b, err := BreachedPasswordsOpen(path)
+	require.NoError(t, err)
+	defer b.Close()
+
+	for _, p := range breached {
+		found, err := b.Contains(p)
+		require.NoError(t, err)
+		require.True(t, found, p)
+	}
+
+	for _, p := range []string{"correct horse battery staple", "Passw0rd!!", "", "filler-500"} {
+		found, err := b.Contains(p)
+		require.NoError(t, err)
+		require.False(t, found, p)
+	}
+
+	require.True(t, PasswordIsBreached(b, "letmein"))
+	require.False(t, PasswordIsBreached(b, "not-in-the-corpus"))
+	require.False(t, PasswordIsBreached(nil, "letmein"), "no corpus accepts any password")
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// CaptchaTokenHeader is the grpc metadata key of the solved bot challenge token, the
+// requests of the methods requiring a challenge (see Captcha.Methods) must carry it
+const CaptchaTokenHeader = "x-captcha-token"
+
+// CaptchaTokenGet reads the challenge token from the incoming grpc metadata
+func CaptchaTokenGet(md metadata.MD) string {
+	if v := md.Get(CaptchaTokenHeader); len(v) > 0 {
+		return strings.TrimSpace(v[0])
+	}
+	return ""
+}
+
+// CaptchaMethodRequired tells whether the method (E,g CreateCustomer) is one of the
+// configured methods requiring a challenge
+func CaptchaMethodRequired(methods []string, method string) bool {
+	for _, m := range methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	common "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/common/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"google.golang.org/grpc/codes"
+)
+
+func ChangePasswordRequestIsValid(ctx *models.Context, req *pbAcc.ChangePasswordRequest, passCfg *common.ConfigPassword, hasher PasswordHasher, breached *BreachedPasswords) *models.AppError {
+	path := "user.models.ChangePasswordRequestIsValid"
+	if req.GetCurrentPassword() == "" || len(req.GetCurrentPassword()) > UserPasswordMaxLength {
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"current_password": {ID: "user.login.password.error"}}}
+		return models.NewAppError(ctx, path, "user.login.password.error", nil, "", int(codes.InvalidArgument), errors)
+	}
+
+	// the password (which err.Err contains too) is kept out of the error, it ends up in the logs
+	if err := PasswordIsValid(req.GetNewPassword(), passCfg, hasher, breached); err != nil {
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"new_password": {ID: err.ID, Params: err.Params}}}
+		return models.NewAppError(ctx, path, err.ID, err.Params, "", int(codes.InvalidArgument), errors)
+	}
+
+	if req.GetCurrentPassword() == req.GetNewPassword() {
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"new_password": {ID: "password_change.same_password.error"}}}
+		return models.NewAppError(ctx, path, "password_change.same_password.error", nil, "", int(codes.InvalidArgument), errors)
+	}
+
+	return nil
+}
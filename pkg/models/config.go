@@ -1,11 +1,180 @@
 package models
 
 type Config struct {
-	Service Service `mapstructure:"service"`
+	Service     Service     `mapstructure:"service"`
+	Auth        Auth        `mapstructure:"auth"`
+	WebAuthn    WebAuthn    `mapstructure:"webauthn"`
+	OAuth       OAuth       `mapstructure:"oauth"`
+	RateLimit   RateLimit   `mapstructure:"rate_limit"`
+	Captcha     Captcha     `mapstructure:"captcha"`
+	EmailDomain EmailDomain `mapstructure:"email_domain"`
 }
 
 type Service struct {
 	Env                  string `mapstructure:"env"`
 	GrpcURL              string `mapstructure:"grpc_url"`
 	CommonServiceGrpcURL string `mapstructure:"common_service_grpc_url"`
+	// TrustedProxies is the number of proxies in front of the gateway appending to the
+	// X-Forwarded-For header, the entries left of them are set by the client and ignored
+	TrustedProxies int `mapstructure:"trusted_proxies"`
+}
+
+// Auth holds the authentication settings that are owned by this service
+// (the shared ones E,g MaximumLoginAttempts live in the common service config)
+type Auth struct {
+	LockoutBaseMinutes int `mapstructure:"lockout_base_minutes"`
+	LockoutMaxMinutes  int `mapstructure:"lockout_max_minutes"`
+	// MfaChallengeMinutes is the life time of the token issued by the first login step
+	// for users with an active MFA
+	MfaChallengeMinutes int `mapstructure:"mfa_challenge_minutes"`
+	// EmailVerificationRequired tells per user type (E,g supplier, customer) whether
+	// login is refused until the user verifies the email
+	EmailVerificationRequired map[string]bool `mapstructure:"email_verification_required"`
+	// VerificationResendCooldownSeconds is the minimum time between two verification emails
+	VerificationResendCooldownSeconds int `mapstructure:"verification_resend_cooldown_seconds"`
+	// VerificationResendDailyCap is the maximum verification emails sent to a user in 24 hours
+	VerificationResendDailyCap int `mapstructure:"verification_resend_daily_cap"`
+	// EmailLoginURL is the frontend page that redeems the passwordless login links
+	EmailLoginURL             string `mapstructure:"email_login_url"`
+	EmailLoginLinkMinutes     int    `mapstructure:"email_login_link_minutes"`
+	EmailLoginCodeMinutes     int    `mapstructure:"email_login_code_minutes"`
+	EmailLoginCodeMaxAttempts int32  `mapstructure:"email_login_code_max_attempts"`
+	// EmailLoginCooldownSeconds and EmailLoginDailyCap limit the login emails sent to a
+	// user, like the verification resend limits
+	EmailLoginCooldownSeconds int `mapstructure:"email_login_cooldown_seconds"`
+	EmailLoginDailyCap        int `mapstructure:"email_login_daily_cap"`
+	// PrivacyMode makes Login and PasswordForgot respond the same way whether the
+	// email is registered or not, the login challenge (if enabled) is then asked on
+	// every login instead of after the failed attempts of the account
+	PrivacyMode bool `mapstructure:"privacy_mode"`
+	// PasswordHasher is the algorithm of the new password hashes (argon2id or bcrypt), the
+	// existing hashes of the other algorithm are upgraded on the next successful login, note that
+	// bcrypt caps the new passwords at 72 bytes whatever the shared password config allows
+	PasswordHasher      string `mapstructure:"password_hasher"`
+	Argon2idMemoryKiB   uint32 `mapstructure:"argon2id_memory_kib"`
+	Argon2idIterations  uint32 `mapstructure:"argon2id_iterations"`
+	Argon2idParallelism uint8  `mapstructure:"argon2id_parallelism"`
+	// BreachedPasswordsFile is the HIBP "ordered by hash" SHA-1 corpus used to reject breached
+	// passwords, empty disables the screening
+	BreachedPasswordsFile string `mapstructure:"breached_passwords_file"`
+	// PasswordHistorySize is the number of the latest passwords (the current one included)
+	// that can't be reused, per user type, 0 or 1 only forbids the current password
+	PasswordHistorySize map[string]int `mapstructure:"password_history_size"`
+	// ReauthMinutes is how recent the session of a user without a password must be to
+	// count as a re-authentication (E,g before linking an identity)
+	ReauthMinutes int `mapstructure:"reauth_minutes"`
+	// SessionTouchSeconds is how often the last seen time of a session is written, the
+	// authenticated requests within it don't touch the database, 0 uses UserSessionTouchInterval
+	SessionTouchSeconds int `mapstructure:"session_touch_seconds"`
+	// NewDeviceAlerts emails the user when a login comes from an unknown device or network
+	NewDeviceAlerts bool `mapstructure:"new_device_alerts"`
+	// LoginNotMeURL is the frontend page that redeems the "this wasn't me" link of the alerts
+	LoginNotMeURL       string `mapstructure:"login_not_me_url"`
+	LoginNotMeLinkHours int    `mapstructure:"login_not_me_link_hours"`
+}
+
+// WebAuthn holds the relying party settings used for passkeys
+type WebAuthn struct {
+	RPID           string   `mapstructure:"rp_id"`
+	RPDisplayName  string   `mapstructure:"rp_display_name"`
+	RPOrigins      []string `mapstructure:"rp_origins"`
+	SessionMinutes int      `mapstructure:"session_minutes"`
+	// PrivacySecret derives the credential ids offered for the unknown emails in the
+	// privacy mode, they must be the same on every request and every instance
+	PrivacySecret string `mapstructure:"privacy_secret"`
+}
+
+// OAuth holds the OAuth server settings that are owned by this service
+type OAuth struct {
+	// FrontendLogoutURL is where the user lands if the logout can't be completed by Hydra
+	FrontendLogoutURL string `mapstructure:"frontend_logout_url"`
+	// FrontendConsentURL is the page that asks the user to grant the scopes of third party clients
+	FrontendConsentURL string `mapstructure:"frontend_consent_url"`
+	// TrustedClients are the first party client ids, their consent is granted without asking the user
+	TrustedClients []string `mapstructure:"trusted_clients"`
+	// SocialProviders are the upstream identity providers (E,g google, apple, github) keyed
+	// by the name used in the social login routes and stored as the identities' provider
+	SocialProviders map[string]SocialProvider `mapstructure:"social_providers"`
+	// FrontendIdentitiesURL is the account page that lands the users after the provider sign in
+	// of the link flow, it confirms the link_id query param with the IdentityLinkConfirm RPC
+	FrontendIdentitiesURL string `mapstructure:"frontend_identities_url"`
+	// SocialStateMinutes is the time the user has to sign in at the upstream provider
+	SocialStateMinutes int `mapstructure:"social_state_minutes"`
+}
+
+// SocialProvider configures an upstream OIDC or plain OAuth2 identity provider
+type SocialProvider struct {
+	// Issuer enables the OIDC discovery and the id token verification, it is empty for
+	// the plain OAuth2 providers (E,g GitHub), which need the endpoints below instead
+	Issuer      string `mapstructure:"issuer"`
+	AuthURL     string `mapstructure:"auth_url"`
+	TokenURL    string `mapstructure:"token_url"`
+	UserInfoURL string `mapstructure:"userinfo_url"`
+	// EmailsURL lists the user's emails with their verification state, for the providers
+	// whose userinfo doesn't tell whether the email is verified
+	EmailsURL string `mapstructure:"emails_url"`
+	ClientID  string `mapstructure:"client_id"`
+	// ClientSecret is, for Apple, the client secret JWT signed with the team's private key
+	ClientSecret string   `mapstructure:"client_secret"`
+	Scopes       []string `mapstructure:"scopes"`
+	// ResponseMode is sent as response_mode, Apple requires form_post to return the email
+	ResponseMode string `mapstructure:"response_mode"`
+}
+
+// RateLimit holds the rate limits of the grpc methods, the state is kept in Redis (shared
+// by the instances of the service), and in memory while Redis is unreachable
+type RateLimit struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	KeyPrefix string `mapstructure:"key_prefix"`
+	// MemoryMaxKeys bounds the in memory fallback store, the least recently used keys are evicted
+	MemoryMaxKeys int `mapstructure:"memory_max_keys"`
+	// Policies are keyed by the lowercased method name (E,g login, passwordforgot), a
+	// request must pass every policy of its method
+	Policies map[string][]RateLimitPolicy `mapstructure:"policies"`
+}
+
+// RateLimitPolicy allows Requests per PeriodSeconds (plus a Burst) for every value of the
+// key, which is one of ip, email (of the request) or user (the authenticated user id)
+type RateLimitPolicy struct {
+	Key           string `mapstructure:"key"`
+	Requests      int    `mapstructure:"requests"`
+	PeriodSeconds int    `mapstructure:"period_seconds"`
+	Burst         int    `mapstructure:"burst"`
+}
+
+// Captcha holds the bot challenge settings, the clients send the solved challenge token in
+// the CaptchaTokenHeader metadata
+type Captcha struct {
+	// Provider is one of turnstile, hcaptcha, recaptcha or stub, empty disables the challenges
+	Provider string `mapstructure:"provider"`
+	Secret   string `mapstructure:"secret"`
+	// VerifyURL overrides the siteverify endpoint of the provider
+	VerifyURL string `mapstructure:"verify_url"`
+	// MinScore is the lowest accepted score of the providers returning one (reCAPTCHA v3)
+	MinScore float64 `mapstructure:"min_score"`
+	// StubToken is the only token accepted by the stub provider
+	StubToken string `mapstructure:"stub_token"`
+	// Methods are the lowercased method names requiring a challenge (E,g createcustomer,
+	// createsupplier, passwordforgot, login)
+	Methods []string `mapstructure:"methods"`
+	// LoginFailedAttempts is the failed login attempts of the account after which Login
+	// requires a challenge, if login is one of Methods
+	LoginFailedAttempts int32 `mapstructure:"login_failed_attempts"`
+}
+
+// EmailDomain holds the signup email domains policy, a listed domain covers its subdomains
+type EmailDomain struct {
+	// DisposableFile lists the disposable email domains, one per line, empty disables the check
+	DisposableFile string `mapstructure:"disposable_file"`
+	// Deny are refused, Allow are accepted regardless of the other rules, the section is
+	// reloaded when the config file changes
+	Deny  []string `mapstructure:"deny"`
+	Allow []string `mapstructure:"allow"`
+	// FreeMail are the public email providers (E,g gmail.com) refused for the suppliers if
+	// SupplierBusinessEmailRequired is set
+	FreeMail                      []string `mapstructure:"free_mail"`
+	SupplierBusinessEmailRequired bool     `mapstructure:"supplier_business_email_required"`
+	// MXCheck refuses the domains without mail servers, MXTimeoutMillis bounds the lookup
+	MXCheck         bool `mapstructure:"mx_check"`
+	MXTimeoutMillis int  `mapstructure:"mx_timeout_millis"`
 }
@@ -0,0 +1,187 @@
+package models
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// the reasons of a rejected email domain, they complete the ids of the signup errors
+// (E,g user.create.email.disposable.error)
+const (
+	EmailDomainReasonDenied     = "denied"
+	EmailDomainReasonDisposable = "disposable"
+	EmailDomainReasonFreeMail   = "free_mail"
+	EmailDomainReasonNoMX       = "no_mx"
+)
+
+// EmailDomainResolver looks up the mail servers of a domain, and its addresses which receive
+// the emails if it has no mail servers, net.Resolver implements it
+type EmailDomainResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// EmailDomainPolicy decides which email domains can sign up, the allow list wins over the
+// other rules, then the deny list, the disposable domains, the free mail domains (for the
+// suppliers only) and the MX records are checked. A listed domain covers its subdomains too
+type EmailDomainPolicy struct {
+	allow      map[string]struct{}
+	deny       map[string]struct{}
+	disposable map[string]struct{}
+	freeMail   map[string]struct{}
+	// supplierBusinessOnly refuses the free mail domains for the suppliers
+	supplierBusinessOnly bool
+	// resolver is nil if the MX check is disabled
+	resolver  EmailDomainResolver
+	mxTimeout time.Duration
+}
+
+type EmailDomainPolicyArgs struct {
+	Config     *EmailDomain
+	Disposable []string
+	// Resolver is used if Config.MXCheck is set
+	Resolver EmailDomainResolver
+}
+
+func EmailDomainPolicyNew(args *EmailDomainPolicyArgs) *EmailDomainPolicy {
+	cfg := args.Config
+	p := &EmailDomainPolicy{
+		allow:                emailDomainSet(cfg.Allow),
+		deny:                 emailDomainSet(cfg.Deny),
+		disposable:           emailDomainSet(args.Disposable),
+		freeMail:             emailDomainSet(cfg.FreeMail),
+		supplierBusinessOnly: cfg.SupplierBusinessEmailRequired,
+		mxTimeout:            time.Duration(cfg.MXTimeoutMillis) * time.Millisecond,
+	}
+	if cfg.MXCheck {
+		p.resolver = args.Resolver
+	}
+	if p.mxTimeout <= 0 {
+		p.mxTimeout = time.Second * 3
+	}
+	return p
+}
+
+// EmailDomainListOpen reads a domains list file (E,g the disposable domains), see EmailDomainListRead
+func EmailDomainListOpen(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return EmailDomainListRead(f)
+}
+
+// EmailDomainListRead reads one domain per line, the empty lines and the comments (#) are skipped
+func EmailDomainListRead(r io.Reader) ([]string, error) {
+	domains := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			domains = append(domains, line)
+		}
+	}
+	return domains, scanner.Err()
+}
+
+// Check returns the reason the email of a user of the given type is refused, or an empty
+// string if it's accepted. A failed MX lookup (E,g a DNS timeout) accepts the email, only
+// the domains that surely can't receive emails are refused. A nil policy accepts any email
+func (p *EmailDomainPolicy) Check(ctx context.Context, email string, userType UserType) string {
+	if p == nil {
+		return ""
+	}
+
+	_, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return ""
+	}
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+
+	if emailDomainMatches(p.allow, domain) {
+		return ""
+	}
+	if emailDomainMatches(p.deny, domain) {
+		return EmailDomainReasonDenied
+	}
+	if emailDomainMatches(p.disposable, domain) {
+		return EmailDomainReasonDisposable
+	}
+	if userType == UserTypeSupplier && p.supplierBusinessOnly && emailDomainMatches(p.freeMail, domain) {
+		return EmailDomainReasonFreeMail
+	}
+
+	if p.resolver != nil && !p.mxExists(ctx, domain) {
+		return EmailDomainReasonNoMX
+	}
+	return ""
+}
+
+// mxExists tells whether the domain can receive emails, only a null MX (RFC 7505) or a
+// domain without MX and address records (E,g NXDOMAIN) is refused, a domain without MX
+// records receives the emails on its A/AAAA addresses (RFC 5321 5.1)
+func (p *EmailDomainPolicy) mxExists(ctx context.Context, domain string) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.mxTimeout)
+	defer cancel()
+
+	records, err := p.resolver.LookupMX(ctx, domain)
+	if err != nil && !emailDomainNotFound(err) {
+		return true
+	}
+
+	if len(records) > 0 {
+		// a single "." record is the null MX, the domain accepts no email
+		for _, r := range records {
+			if r.Host != "." && r.Host != "" {
+				return true
+			}
+		}
+		return false
+	}
+
+	addrs, err := p.resolver.LookupIPAddr(ctx, domain)
+	if err != nil {
+		return !emailDomainNotFound(err)
+	}
+	return len(addrs) > 0
+}
+
+func emailDomainNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+func emailDomainSet(domains []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(domains))
+	for _, d := range domains {
+		if d = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(d)), "."); d != "" {
+			set[d] = struct{}{}
+		}
+	}
+	return set
+}
+
+// emailDomainMatches tells whether the domain or one of its parents is in the set
+func emailDomainMatches(set map[string]struct{}, domain string) bool {
+	for {
+		if _, ok := set[domain]; ok {
+			return true
+		}
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok || !strings.Contains(parent, ".") {
+			return false
+		}
+		domain = parent
+	}
+}
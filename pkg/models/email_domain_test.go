@@ -0,0 +1,115 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMXResolver serves the MX records of the domains, the domains of fakeHostsResolved
+// have no MX records but an address, the others don't exist
+type fakeMXResolver map[string][]*net.MX
+
+var fakeHostsResolved = map[string]bool{"a-only.example": true, "a-dns-down.example": true}
+
+func (r fakeMXResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	switch name {
+	case "dns-down.example":
+		return nil, &net.DNSError{Err: "i/o timeout", Name: name, IsTimeout: true}
+	case "broken.example":
+		return nil, errors.New("connection refused")
+	}
+	records, ok := r[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func (r fakeMXResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	switch {
+	case host == "a-dns-down.example":
+		return nil, &net.DNSError{Err: "i/o timeout", Name: host, IsTimeout: true}
+	case fakeHostsResolved[host]:
+		return []net.IPAddr{{IP: net.ParseIP("192.0.2.1")}}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestEmailDomainPolicyCheck(t *testing.T) {
+	resolver := fakeMXResolver{
+		"acme.example":    {{Host: "mx.acme.example.", Pref: 10}},
+		"gmail.com":       {{Host: "gmail-smtp-in.l.google.com.", Pref: 5}},
+		"nomail.example":  {{Host: ".", Pref: 0}},
+		"trusted.example": {{Host: ".", Pref: 0}},
+	}
+	p := EmailDomainPolicyNew(&EmailDomainPolicyArgs{
+		Config: &EmailDomain{
+			Deny:                          []string{"Spam.Example"},
+			Allow:                         []string{"trusted.example", "partner.mailinator.com"},
+			FreeMail:                      []string{"gmail.com"},
+			SupplierBusinessEmailRequired: true,
+			MXCheck:                       true,
+		},
+		Disposable: []string{"mailinator.com", "10minutemail.com"},
+		Resolver:   resolver,
+	})
+
+	tests := map[string]struct {
+		email    string
+		userType UserType
+		reason   string
+	}{
+		"business domain":          {email: "a@acme.example", userType: UserTypeSupplier},
+		"free mail customer":       {email: "a@gmail.com", userType: UserTypeCustomer},
+		"free mail supplier":       {email: "a@gmail.com", userType: UserTypeSupplier, reason: EmailDomainReasonFreeMail},
+		"disposable":               {email: "a@mailinator.com", userType: UserTypeCustomer, reason: EmailDomainReasonDisposable},
+		"disposable subdomain":     {email: "a@x.mailinator.com", userType: UserTypeCustomer, reason: EmailDomainReasonDisposable},
+		"disposable uppercase":     {email: "a@10MinuteMail.com", userType: UserTypeCustomer, reason: EmailDomainReasonDisposable},
+		"denied":                   {email: "a@spam.example", userType: UserTypeCustomer, reason: EmailDomainReasonDenied},
+		"allowed over disposable":  {email: "a@partner.mailinator.com", userType: UserTypeCustomer},
+		"allowed skips the mx":     {email: "a@trusted.example", userType: UserTypeCustomer},
+		"unknown domain":           {email: "a@nowhere.example", userType: UserTypeCustomer, reason: EmailDomainReasonNoMX},
+		"address without mx":       {email: "a@a-only.example", userType: UserTypeCustomer},
+		"address lookup failure":   {email: "a@a-dns-down.example", userType: UserTypeCustomer},
+		"null mx":                  {email: "a@nomail.example", userType: UserTypeCustomer, reason: EmailDomainReasonNoMX},
+		"dns timeout accepts":      {email: "a@dns-down.example", userType: UserTypeCustomer},
+		"resolver failure accepts": {email: "a@broken.example", userType: UserTypeCustomer},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.reason, p.Check(context.Background(), tc.email, tc.userType))
+		})
+	}
+}
+
+func TestEmailDomainPolicyOptional(t *testing.T) {
+	p := EmailDomainPolicyNew(&EmailDomainPolicyArgs{
+		Config:   &EmailDomain{FreeMail: []string{"gmail.com"}},
+		Resolver: fakeMXResolver{},
+	})
+
+	require.Empty(t, p.Check(context.Background(), "a@gmail.com", UserTypeSupplier), "free mail is allowed unless required")
+	require.Empty(t, p.Check(context.Background(), "a@nowhere.example", UserTypeCustomer), "the mx check is disabled")
+
+	var none *EmailDomainPolicy
+	require.Empty(t, none.Check(context.Background(), "a@mailinator.com", UserTypeCustomer), "no policy accepts any domain")
+}
+
+func TestEmailDomainListOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "disposable_email_domains.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# disposable domains\nmailinator.com\n\n  10minutemail.com  # comment\n"), 0o600))
+
+	domains, err := EmailDomainListOpen(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mailinator.com", "10minutemail.com"}, domains)
+
+	_, err = EmailDomainListOpen(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/grpc/codes"
+)
+
+// EmailLoginMode is how the passwordless login secret is delivered to the user
+type EmailLoginMode string
+
+const (
+	EmailLoginModeLink EmailLoginMode = "link"
+	EmailLoginModeCode EmailLoginMode = "code"
+)
+
+const EmailLoginCodeDigits = 6
+
+func EmailLoginBeginRequestIsValid(ctx *models.Context, req *pbAcc.EmailLoginBeginRequest) *models.AppError {
+	path := "users.models.EmailLoginBeginRequestIsValid"
+	if err := emailLoginIsValid(ctx, path, req.GetEmail(), req.GetLoginChallenge()); err != nil {
+		return err
+	}
+
+	if mode := EmailLoginMode(req.GetMode()); mode != EmailLoginModeLink && mode != EmailLoginModeCode {
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"mode": {ID: "user.login.email.mode.invalid"}}}
+		return models.NewAppError(ctx, path, "user.login.email.mode.invalid", nil, fmt.Sprintf("invalid mode=%s", req.GetMode()), int(codes.InvalidArgument), errors)
+	}
+
+	return nil
+}
+
+func EmailLoginFinishRequestIsValid(ctx *models.Context, req *pbAcc.EmailLoginFinishRequest) *models.AppError {
+	path := "users.models.EmailLoginFinishRequestIsValid"
+	if err := emailLoginIsValid(ctx, path, req.GetEmail(), req.GetLoginChallenge()); err != nil {
+		return err
+	}
+
+	if req.GetCode() != "" {
+		if !EmailLoginIsValidCode(req.GetCode()) {
+			errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"code": {ID: "user.login.email.code.invalid"}}}
+			return models.NewAppError(ctx, path, "user.login.email.code.invalid", nil, "", int(codes.InvalidArgument), errors)
+		}
+	} else {
+		if _, err := ulid.ParseStrict(req.GetTokenId()); err != nil || req.GetToken() == "" {
+			return models.NewAppError(ctx, path, "user.login.email.link.invalid", nil, "", int(codes.InvalidArgument), &models.AppErrorErrorsArgs{Err: err})
+		}
+	}
+
+	if req.GetMfa() != "" && !MfaIsValidCode(req.GetMfa()) && !MfaIsValidRecoveryCode(req.GetMfa()) {
+		return models.NewAppError(ctx, path, "user.mfa.code.invalid", nil, "", int(codes.InvalidArgument), &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"mfa": {ID: "user.mfa.code.invalid"}}})
+	}
+
+	return nil
+}
+
+func emailLoginIsValid(ctx *models.Context, path, email, challenge string) *models.AppError {
+	if !utils.IsValidEmail(email) {
+		return models.NewAppError(ctx, path, "email.invalid", nil, fmt.Sprintf("invalid email=%s", email), int(codes.InvalidArgument), &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"email": {ID: "email.invalid"}}})
+	}
+
+	if len(challenge) == 0 {
+		return models.NewAppError(ctx, path, "oauth.login_challenge.missing", nil, "", int(codes.InvalidArgument), nil)
+	}
+
+	return nil
+}
+
+// EmailLoginIsValidCode checks that the code has the shape of an email login code
+func EmailLoginIsValidCode(code string) bool {
+	if len(code) != EmailLoginCodeDigits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// EmailLoginGenerateCode returns a random zero padded numeric code of EmailLoginCodeDigits
+func EmailLoginGenerateCode() (string, error) {
+	max := big.NewInt(1)
+	for range EmailLoginCodeDigits {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", EmailLoginCodeDigits, n.Int64()), nil
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailLoginGenerateCode(t *testing.T) {
+	seen := map[string]struct{}{}
+	for range 100 {
+		code, err := EmailLoginGenerateCode()
+		require.NoError(t, err)
+		require.True(t, EmailLoginIsValidCode(code), code)
+		seen[code] = struct{}{}
+	}
+
+	require.Greater(t, len(seen), 90)
+}
+
+func TestEmailLoginIsValidCode(t *testing.T) {
+	tests := map[string]struct {
+		code    string
+		expects bool
+	}{
+		"valid":         {code: "012345", expects: true},
+		"too short":     {code: "12345", expects: false},
+		"too long":      {code: "1234567", expects: false},
+		"not numeric":   {code: "12a456", expects: false},
+		"empty":         {code: "", expects: false},
+		"leading space": {code: " 12345", expects: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expects, EmailLoginIsValidCode(tc.code))
+		})
+	}
+}
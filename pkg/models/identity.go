@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/grpc/codes"
+)
+
+// Identity is an upstream provider account (E,g a Google account) that the user signs in
+// with, a user can have several, one per provider
+type Identity struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	Provider  string `json:"provider"`
+	Subject   string `json:"subject"`
+	Email     string `json:"email"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func (i *Identity) ToProto() *pbAcc.Identity {
+	return &pbAcc.Identity{Id: i.ID, Provider: i.Provider, Email: i.Email, CreatedAt: i.CreatedAt}
+}
+
+func IdentityLinkBeginRequestIsValid(ctx *models.Context, req *pbAcc.IdentityLinkBeginRequest) *models.AppError {
+	if req.GetProvider() == "" {
+		return identityErrorBuilder(ctx, "provider", "user.identity.provider.invalid")
+	}
+	if len(req.GetPassword()) > UserPasswordMaxLength {
+		return identityErrorBuilder(ctx, "password", "user.login.password.error")
+	}
+	if req.GetMfa() != "" && !MfaIsValidCode(req.GetMfa()) {
+		return identityErrorBuilder(ctx, "mfa", "user.mfa.code.invalid")
+	}
+	return nil
+}
+
+func IdentityLinkConfirmRequestIsValid(ctx *models.Context, req *pbAcc.IdentityLinkConfirmRequest) *models.AppError {
+	if req.GetLinkId() == "" || len(req.GetLinkId()) > 128 {
+		return identityErrorBuilder(ctx, "link_id", "user.identity.link.invalid")
+	}
+	return nil
+}
+
+func IdentityUnlinkRequestIsValid(ctx *models.Context, req *pbAcc.IdentityUnlinkRequest) *models.AppError {
+	if _, err := ulid.ParseStrict(req.GetIdentityId()); err != nil {
+		return identityErrorBuilder(ctx, "identity_id", "user.identity.id.invalid")
+	}
+	return nil
+}
+
+// IdentityCanUnlink tells whether the user keeps a way to sign in after removing one of
+// the identities, the password and the passkeys count as login methods too
+func IdentityCanUnlink(hasPassword bool, identities, passkeys int) bool {
+	methods := identities + passkeys
+	if hasPassword {
+		methods++
+	}
+	return methods > 1
+}
+
+// IdentityReauthIsRecent tells whether a session created at sessionCreatedAt (in millis) is
+// recent enough to count as a re-authentication, it is used by the users without a password
+func IdentityReauthIsRecent(sessionCreatedAt int64, now time.Time, window time.Duration) bool {
+	if sessionCreatedAt <= 0 {
+		return false
+	}
+	return now.Sub(time.UnixMilli(sessionCreatedAt)) <= window
+}
+
+func identityErrorBuilder(ctx *models.Context, field, id string) *models.AppError {
+	errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{field: {ID: id}}}
+	return models.NewAppError(ctx, "users.models.IdentityRequestIsValid", id, nil, "", int(codes.InvalidArgument), errors)
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityCanUnlink(t *testing.T) {
+	tests := map[string]struct {
+		hasPassword bool
+		identities  int
+		passkeys    int
+		expects     bool
+	}{
+		"the only identity":        {identities: 1, expects: false},
+		"identity and password":    {hasPassword: true, identities: 1, expects: true},
+		"two identities":           {identities: 2, expects: true},
+		"identity and passkey":     {identities: 1, passkeys: 1, expects: true},
+		"no identity left to drop": {hasPassword: true, expects: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expects, IdentityCanUnlink(tc.hasPassword, tc.identities, tc.passkeys))
+		})
+	}
+}
+
+func TestIdentityReauthIsRecent(t *testing.T) {
+	now := time.Now()
+	window := time.Minute * 10
+
+	require.True(t, IdentityReauthIsRecent(now.Add(-time.Minute).UnixMilli(), now, window))
+	require.False(t, IdentityReauthIsRecent(now.Add(-time.Hour).UnixMilli(), now, window))
+	require.False(t, IdentityReauthIsRecent(0, now, window))
+}
@@ -2,6 +2,9 @@ package models
 
 import (
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
 	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
@@ -16,14 +19,38 @@ type OAuthErrorResponse struct {
 	ErrorDebug       string `json:"error_debug,omitempty"`
 }
 
+// LoginIdentifierType is the kind of the identifier sent in the email field of the LoginRequest
+type LoginIdentifierType string
+
+const (
+	LoginIdentifierEmail    LoginIdentifierType = "email"
+	LoginIdentifierUsername LoginIdentifierType = "username"
+)
+
+// LoginIdentifierTypeGet tells whether the login identifier is an email or a username,
+// usernames can't contain @, so anything containing it is treated as an email
+func LoginIdentifierTypeGet(identifier string) LoginIdentifierType {
+	if strings.Contains(identifier, "@") {
+		return LoginIdentifierEmail
+	}
+	return LoginIdentifierUsername
+}
+
 func LoginRequestIsValid(ctx *models.Context, req *pb.LoginRequest) *models.AppError {
-	email := req.GetEmail()
+	identifier := req.GetEmail()
 	password := req.GetPassword()
 	challenge := req.GetLoginChallenge()
 
 	path := "users.models.LoginRequestIsValid"
-	if !utils.IsValidEmail(email) {
-		return models.NewAppError(ctx, path, "email.invalid", nil, fmt.Sprintf("invalid email=%s", email), int(codes.InvalidArgument), &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"email": {ID: "email.invalid"}}})
+	if LoginIdentifierTypeGet(identifier) == LoginIdentifierEmail {
+		if !utils.IsValidEmail(identifier) {
+			return models.NewAppError(ctx, path, "email.invalid", nil, fmt.Sprintf("invalid email=%s", identifier), int(codes.InvalidArgument), &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"email": {ID: "email.invalid"}}})
+		}
+	} else {
+		count := utf8.RuneCountInString(identifier)
+		if count < UserNameMinLength || count > UserNameMaxLength || !utils.IsValidUsernameChars(identifier) {
+			return models.NewAppError(ctx, path, "user.login.identifier.invalid", nil, fmt.Sprintf("invalid username=%s", identifier), int(codes.InvalidArgument), &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"email": {ID: "user.login.identifier.invalid"}}})
+		}
 	}
 
 	if len(password) < UserPasswordMinLength {
@@ -36,6 +63,10 @@ func LoginRequestIsValid(ctx *models.Context, req *pb.LoginRequest) *models.AppE
 		return models.NewAppError(ctx, path, "password.max_length", params, "", int(codes.InvalidArgument), &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"password": {ID: "password.min_length", Params: params}}})
 	}
 
+	if mfa := req.GetMfa(); mfa != "" && !MfaIsValidCode(mfa) && !MfaIsValidRecoveryCode(mfa) {
+		return models.NewAppError(ctx, path, "user.mfa.code.invalid", nil, "", int(codes.InvalidArgument), &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"mfa": {ID: "user.mfa.code.invalid"}}})
+	}
+
 	if len(challenge) == 0 {
 		return models.NewAppError(ctx, path, "oauth.login_challenge.missing", nil, "", int(codes.InvalidArgument), nil)
 	}
@@ -43,6 +74,28 @@ func LoginRequestIsValid(ctx *models.Context, req *pb.LoginRequest) *models.AppE
 	return nil
 }
 
+// LoginLockoutDuration returns how long an account must be locked after reaching
+// the given failed attempts, the duration doubles for every attempt beyond the
+// threshold and never exceeds max, zero means the account should not be locked
+func LoginLockoutDuration(attempts, threshold int32, base, max time.Duration) time.Duration {
+	if threshold <= 0 || attempts < threshold || base <= 0 {
+		return 0
+	}
+
+	lock := base
+	for i := threshold; i < attempts; i++ {
+		lock *= 2
+		if max > 0 && lock >= max {
+			return max
+		}
+	}
+
+	if max > 0 && lock > max {
+		return max
+	}
+	return lock
+}
+
 func GetOAuthRequestErrMsg(lang, code, desc string) string {
 	tr := func(id string) string {
 		return models.Tr(lang, id, nil)
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/grpc/codes"
+)
+
+// LoginDevice is a device (and the network it was on) that the user logged in from, the
+// logins from an unknown fingerprint or IP range trigger the new sign-in alert
+type LoginDevice struct {
+	UserID      string `json:"user_id"`
+	Fingerprint string `json:"fingerprint"`
+	IPRange     string `json:"ip_range"`
+	CreatedAt   int64  `json:"created_at"`
+	LastSeenAt  int64  `json:"last_seen_at"`
+}
+
+// LoginDeviceHistory tells what was already known about a login device before storing it
+type LoginDeviceHistory struct {
+	// Any is false on the first recorded login of the user
+	Any         bool
+	Fingerprint bool
+	IPRange     bool
+}
+
+// IsNew tells whether the login should be reported to the user, the very first login
+// isn't reported, the user just signed up
+func (h LoginDeviceHistory) IsNew() bool {
+	return h.Any && (!h.Fingerprint || !h.IPRange)
+}
+
+func LoginNotMeRequestIsValid(ctx *models.Context, req *pbAcc.LoginNotMeRequest) *models.AppError {
+	if _, err := ulid.ParseStrict(req.GetTokenId()); err != nil || req.GetToken() == "" {
+		return models.NewAppError(ctx, "users.models.LoginNotMeRequestIsValid", "user.login.not_me.invalid", nil, "", int(codes.InvalidArgument), &models.AppErrorErrorsArgs{Err: err})
+	}
+	return nil
+}
+
+// LoginDeviceNew builds the device of the given login session
+func LoginDeviceNew(s *UserSession) *LoginDevice {
+	return &LoginDevice{
+		UserID:      s.UserID,
+		Fingerprint: LoginDeviceFingerprint(s.DeviceID, s.UserAgent),
+		IPRange:     LoginDeviceIPRange(s.IPAddress),
+		CreatedAt:   s.CreatedAt,
+		LastSeenAt:  s.LastSeenAt,
+	}
+}
+
+// LoginDeviceFingerprint identifies the device by the id the client generated for it, or
+// by the user agent for the clients that don't send one
+func LoginDeviceFingerprint(deviceID, userAgent string) string {
+	value := "ua:" + userAgent
+	if deviceID != "" {
+		value = "id:" + deviceID
+	}
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoginDeviceIPRange returns the network of the address (a /24 for IPv4, a /48 for IPv6),
+// so moving between the addresses of the same provider isn't reported as a new location
+func LoginDeviceIPRange(address string) string {
+	ip := net.ParseIP(strings.TrimSpace(address))
+	if ip == nil {
+		return address
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return (&net.IPNet{IP: v4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	return (&net.IPNet{IP: ip.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}).String()
+}
+
+// LoginDeviceTimeFormat formats the login time for the alert email, in the timezone of the
+// user if it's known, to the minute since the time is only approximate
+func LoginDeviceTimeFormat(millis int64, timezone string) string {
+	at := time.UnixMilli(millis).UTC()
+	if loc, err := time.LoadLocation(timezone); timezone != "" && err == nil {
+		at = at.In(loc)
+	}
+	return at.Format("2006-01-02 15:04 MST")
+}
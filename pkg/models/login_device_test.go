@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginDeviceHistoryIsNew(t *testing.T) {
+	tests := map[string]struct {
+		history LoginDeviceHistory
+		expects bool
+	}{
+		"first login":           {history: LoginDeviceHistory{}, expects: false},
+		"known device and ip":   {history: LoginDeviceHistory{Any: true, Fingerprint: true, IPRange: true}, expects: false},
+		"new device":            {history: LoginDeviceHistory{Any: true, IPRange: true}, expects: true},
+		"known device, new ip":  {history: LoginDeviceHistory{Any: true, Fingerprint: true}, expects: true},
+		"new device and new ip": {history: LoginDeviceHistory{Any: true}, expects: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expects, tc.history.IsNew())
+		})
+	}
+}
+
+func TestLoginDeviceIPRange(t *testing.T) {
+	tests := map[string]struct {
+		address string
+		expects string
+	}{
+		"ipv4":        {address: "203.0.113.77", expects: "203.0.113.0/24"},
+		"ipv6":        {address: "2001:db8:1234:5678::1", expects: "2001:db8:1234::/48"},
+		"not an ip":   {address: "unknown", expects: "unknown"},
+		"ipv4 mapped": {address: "::ffff:203.0.113.77", expects: "203.0.113.0/24"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expects, LoginDeviceIPRange(tc.address))
+		})
+	}
+}
+
+func TestLoginDeviceFingerprint(t *testing.T) {
+	require.Equal(t, LoginDeviceFingerprint("device", "agent 1"), LoginDeviceFingerprint("device", "agent 2"))
+	require.NotEqual(t, LoginDeviceFingerprint("", "agent 1"), LoginDeviceFingerprint("", "agent 2"))
+	require.NotEqual(t, LoginDeviceFingerprint("x", ""), LoginDeviceFingerprint("", "x"))
+}
+
+func TestLoginDeviceTimeFormat(t *testing.T) {
+	at := time.Date(2026, 3, 1, 10, 30, 45, 0, time.UTC).UnixMilli()
+	require.Equal(t, "2026-03-01 10:30 UTC", LoginDeviceTimeFormat(at, ""))
+	require.Equal(t, "2026-03-01 10:30 UTC", LoginDeviceTimeFormat(at, "Not/AZone"))
+	require.Equal(t, "2026-03-01 11:30 CET", LoginDeviceTimeFormat(at, "Europe/Berlin"))
+}
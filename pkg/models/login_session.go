@@ -0,0 +1,97 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ClientPlatform is the kind of client that the user signs in from, it selects the
+// configured session lifetime, the mobile apps keep their sessions longer than browsers
+type ClientPlatform string
+
+const (
+	ClientPlatformWeb    ClientPlatform = "web"
+	ClientPlatformMobile ClientPlatform = "mobile"
+)
+
+// the login steps read the client platform and the remember me choice from these grpc
+// metadata keys, the LoginRequest carries the credentials only
+const (
+	LoginClientPlatformHeader = "x-client-platform"
+	LoginRememberMeHeader     = "x-remember-me"
+)
+
+// the keys of the platform and the remember me choice in the context of the accepted
+// login request, Hydra hands the context over to the consent request
+const (
+	loginContextPlatform = "platform"
+	loginContextRemember = "remember"
+)
+
+// LoginSessionOptions describes how long the session of a login should be kept
+type LoginSessionOptions struct {
+	Platform ClientPlatform
+	// Remember keeps the session across browser restarts, it is off unless the user
+	// explicitly asked for it, so shared computers don't keep sessions alive for days
+	Remember bool
+}
+
+// ClientPlatformParse returns the platform named by the given value, anything unknown
+// is treated as web, which has the shorter session lifetime
+func ClientPlatformParse(value string) ClientPlatform {
+	if ClientPlatform(strings.ToLower(strings.TrimSpace(value))) == ClientPlatformMobile {
+		return ClientPlatformMobile
+	}
+	return ClientPlatformWeb
+}
+
+// LoginSessionOptionsGet reads the session options from the incoming grpc metadata
+func LoginSessionOptionsGet(md metadata.MD) LoginSessionOptions {
+	opts := LoginSessionOptions{Platform: ClientPlatformWeb}
+	if v := md.Get(LoginClientPlatformHeader); len(v) > 0 {
+		opts.Platform = ClientPlatformParse(v[0])
+	}
+	if v := md.Get(LoginRememberMeHeader); len(v) > 0 {
+		opts.Remember, _ = strconv.ParseBool(strings.TrimSpace(v[0]))
+	}
+	return opts
+}
+
+// LoginSessionOptionsFromContext reads the session options stored by
+// LoginSessionOptions.Context in the context of an OAuth login or consent request
+func LoginSessionOptionsFromContext(c map[string]any) LoginSessionOptions {
+	opts := LoginSessionOptions{Platform: ClientPlatformWeb}
+	if v, ok := c[loginContextPlatform].(string); ok {
+		opts.Platform = ClientPlatformParse(v)
+	}
+	if v, ok := c[loginContextRemember].(bool); ok {
+		opts.Remember = v
+	}
+	return opts
+}
+
+// Context adds the session options to the given context of an OAuth login request
+func (o LoginSessionOptions) Context(c map[string]any) map[string]any {
+	c[loginContextPlatform] = string(o.Platform)
+	c[loginContextRemember] = o.Remember
+	return c
+}
+
+// ExpiryInHours picks the configured web or mobile session lifetime
+func (o LoginSessionOptions) ExpiryInHours(webHours, mobileHours int32) int32 {
+	if o.Platform == ClientPlatformMobile {
+		return mobileHours
+	}
+	return webHours
+}
+
+// RememberFor returns the remember_for seconds sent to Hydra, zero when the session
+// isn't remembered, Hydra then keeps it for the browser session only
+func (o LoginSessionOptions) RememberFor(webHours, mobileHours int32) int32 {
+	if !o.Remember {
+		return 0
+	}
+	return o.ExpiryInHours(webHours, mobileHours) * 60 * 60
+}
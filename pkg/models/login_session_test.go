@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestLoginSessionOptionsGet(t *testing.T) {
+	tests := map[string]struct {
+		md      metadata.MD
+		expects LoginSessionOptions
+	}{
+		"no metadata":       {md: metadata.MD{}, expects: LoginSessionOptions{Platform: ClientPlatformWeb}},
+		"mobile":            {md: metadata.Pairs(LoginClientPlatformHeader, "mobile"), expects: LoginSessionOptions{Platform: ClientPlatformMobile}},
+		"mixed case mobile": {md: metadata.Pairs(LoginClientPlatformHeader, " Mobile "), expects: LoginSessionOptions{Platform: ClientPlatformMobile}},
+		"unknown platform":  {md: metadata.Pairs(LoginClientPlatformHeader, "tv"), expects: LoginSessionOptions{Platform: ClientPlatformWeb}},
+		"remember":          {md: metadata.Pairs(LoginRememberMeHeader, "true"), expects: LoginSessionOptions{Platform: ClientPlatformWeb, Remember: true}},
+		"invalid remember":  {md: metadata.Pairs(LoginRememberMeHeader, "yes please"), expects: LoginSessionOptions{Platform: ClientPlatformWeb}},
+		"mobile remembered": {
+			md:      metadata.Pairs(LoginClientPlatformHeader, "mobile", LoginRememberMeHeader, "1"),
+			expects: LoginSessionOptions{Platform: ClientPlatformMobile, Remember: true},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expects, LoginSessionOptionsGet(tc.md))
+		})
+	}
+}
+
+func TestLoginSessionOptionsContext(t *testing.T) {
+	opts := LoginSessionOptions{Platform: ClientPlatformMobile, Remember: true}
+	c := opts.Context(map[string]any{"lang": "en"})
+	require.Equal(t, "en", c["lang"])
+	require.Equal(t, opts, LoginSessionOptionsFromContext(c))
+
+	require.Equal(t, LoginSessionOptions{Platform: ClientPlatformWeb}, LoginSessionOptionsFromContext(nil))
+}
+
+func TestLoginSessionOptionsRememberFor(t *testing.T) {
+	tests := map[string]struct {
+		opts    LoginSessionOptions
+		expects int32
+	}{
+		"web not remembered":    {opts: LoginSessionOptions{Platform: ClientPlatformWeb}, expects: 0},
+		"mobile not remembered": {opts: LoginSessionOptions{Platform: ClientPlatformMobile}, expects: 0},
+		"web remembered":        {opts: LoginSessionOptions{Platform: ClientPlatformWeb, Remember: true}, expects: 24 * 3600},
+		"mobile remembered":     {opts: LoginSessionOptions{Platform: ClientPlatformMobile, Remember: true}, expects: 720 * 3600},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expects, tc.opts.RememberFor(24, 720))
+		})
+	}
+}
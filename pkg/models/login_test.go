@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginLockoutDuration(t *testing.T) {
+	base := time.Minute * 5
+	max := time.Hour
+
+	tests := map[string]struct {
+		attempts  int32
+		threshold int32
+		expects   time.Duration
+	}{
+		"below threshold":          {attempts: 2, threshold: 5, expects: 0},
+		"lockout disabled":         {attempts: 10, threshold: 0, expects: 0},
+		"reaching the threshold":   {attempts: 5, threshold: 5, expects: base},
+		"one attempt beyond":       {attempts: 6, threshold: 5, expects: base * 2},
+		"two attempts beyond":      {attempts: 7, threshold: 5, expects: base * 4},
+		"capped by the max":        {attempts: 9, threshold: 5, expects: max},
+		"far beyond the threshold": {attempts: 200, threshold: 5, expects: max},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expects, LoginLockoutDuration(tc.attempts, tc.threshold, base, max))
+		})
+	}
+}
+
+func TestLoginIdentifierTypeGet(t *testing.T) {
+	tests := map[string]struct {
+		identifier string
+		expects    LoginIdentifierType
+	}{
+		"email":                {identifier: "user@example.com", expects: LoginIdentifierEmail},
+		"username":             {identifier: "john_doe", expects: LoginIdentifierUsername},
+		"username with a dot":  {identifier: "john.doe", expects: LoginIdentifierUsername},
+		"malformed email":      {identifier: "john@", expects: LoginIdentifierEmail},
+		"upper cased username": {identifier: "JohnDoe", expects: LoginIdentifierUsername},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expects, LoginIdentifierTypeGet(tc.identifier))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	MfaTOTPDigits      = 6
+	MfaTOTPPeriod      = 30
+	MfaTOTPSkew        = 1
+	MfaSecretSizeBytes = 20
+
+	MfaRecoveryCodesCount = 10
+	// MfaRecoveryCodeLength is the number of characters of a recovery code, they are
+	// displayed to users in two dash separated groups E,g abcde-fghij
+	MfaRecoveryCodeLength = 10
+	// recovery codes don't expire on their own, they are replaced on regeneration
+	MfaRecoveryCodeValidity = time.Hour * 24 * 365 * 10
+)
+
+const mfaRecoveryCodeAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+
+// the mfa challenge token issued by the first login step is sent back
+// on the second step via these grpc metadata keys
+const (
+	MfaTokenIDHeader = "x-mfa-token-id"
+	MfaTokenHeader   = "x-mfa-token"
+)
+
+func MfaConfirmRequestIsValid(ctx *models.Context, req *pbAcc.MfaConfirmRequest) *models.AppError {
+	if !MfaIsValidCode(req.GetCode()) {
+		return mfaErrorBuilder(ctx, "code", "user.mfa.code.invalid")
+	}
+	return nil
+}
+
+func MfaDisableRequestIsValid(ctx *models.Context, req *pbAcc.MfaDisableRequest) *models.AppError {
+	if req.GetPassword() == "" || len(req.GetPassword()) > UserPasswordMaxLength {
+		return mfaErrorBuilder(ctx, "password", "user.login.password.error")
+	}
+	return nil
+}
+
+func MfaRecoveryCodesRegenerateRequestIsValid(ctx *models.Context, req *pbAcc.MfaRecoveryCodesRegenerateRequest) *models.AppError {
+	if req.GetPassword() == "" || len(req.GetPassword()) > UserPasswordMaxLength {
+		return mfaErrorBuilder(ctx, "password", "user.login.password.error")
+	}
+	return nil
+}
+
+func mfaErrorBuilder(ctx *models.Context, field, id string) *models.AppError {
+	path := "users.models.MfaRequestIsValid"
+	errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{field: {ID: id}}}
+	return models.NewAppError(ctx, path, id, nil, "", int(codes.InvalidArgument), errors)
+}
+
+// MfaIsValidCode checks that the code has the shape of a TOTP code
+func MfaIsValidCode(code string) bool {
+	if len(code) != MfaTOTPDigits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MfaGenerateSecret returns a new random base32 encoded (without padding) TOTP secret
+func MfaGenerateSecret() (string, error) {
+	b := make([]byte, MfaSecretSizeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
+
+// MfaOtpAuthURL builds the otpauth:// URI that authenticator apps read from a QR code
+func MfaOtpAuthURL(issuer, account, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(MfaTOTPDigits))
+	q.Set("period", fmt.Sprint(MfaTOTPPeriod))
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, q.Encode())
+}
+
+// MfaValidateCode checks the code against the secret at the given time, allowing
+// for MfaTOTPSkew periods of clock drift in both directions. It returns the time step
+// (counter) of the matched code, the callers must store it and refuse any code of the
+// same or an earlier step, so an accepted code can't be replayed within its window
+func MfaValidateCode(secret, code string, at time.Time) (int64, bool) {
+	if !MfaIsValidCode(code) {
+		return 0, false
+	}
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(key) == 0 {
+		return 0, false
+	}
+
+	counter := at.Unix() / MfaTOTPPeriod
+	for i := -MfaTOTPSkew; i <= MfaTOTPSkew; i++ {
+		expected := mfaHOTP(key, uint64(counter+int64(i)))
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return counter + int64(i), true
+		}
+	}
+	return 0, false
+}
+
+// mfaHOTP implements the HOTP algorithm of RFC 4226
+func mfaHOTP(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for range MfaTOTPDigits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", MfaTOTPDigits, value%mod)
+}
+
+// MfaGenerateRecoveryCodes returns n random recovery codes in their displayed form
+func MfaGenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+	b := make([]byte, MfaRecoveryCodeLength)
+	for range n {
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+
+		var sb strings.Builder
+		for i, v := range b {
+			if i == MfaRecoveryCodeLength/2 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(mfaRecoveryCodeAlphabet[int(v)%len(mfaRecoveryCodeAlphabet)])
+		}
+		codes = append(codes, sb.String())
+	}
+	return codes, nil
+}
+
+// MfaNormalizeRecoveryCode drops the separators and the case of a recovery code typed by a user,
+// the normalized form is the one that gets hashed and compared
+func MfaNormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// MfaIsValidRecoveryCode checks that the code has the shape of a recovery code
+func MfaIsValidRecoveryCode(code string) bool {
+	code = MfaNormalizeRecoveryCode(code)
+	if len(code) != MfaRecoveryCodeLength {
+		return false
+	}
+	for _, c := range code {
+		if !strings.ContainsRune(mfaRecoveryCodeAlphabet, c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMfaHOTP(t *testing.T) {
+	// test vectors from RFC 4226 appendix D
+	key := []byte("12345678901234567890")
+	expected := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+
+	for counter, code := range expected {
+		require.Equal(t, code, mfaHOTP(key, uint64(counter)))
+	}
+}
+
+func TestMfaValidateCode(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	at := time.Unix(MfaTOTPPeriod*5, 0)
+
+	tests := map[string]struct {
+		code    string
+		at      time.Time
+		expects bool
+		counter int64
+	}{
+		"current period":       {code: "254676", at: at, expects: true, counter: 5},
+		"previous period":      {code: "338314", at: at, expects: true, counter: 4},
+		"next period":          {code: "287922", at: at, expects: true, counter: 6},
+		"outside allowed skew": {code: "969429", at: at, expects: false},
+		"wrong code":           {code: "000000", at: at, expects: false},
+		"non numeric code":     {code: "25467a", at: at, expects: false},
+		"short code":           {code: "25467", at: at, expects: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			counter, ok := MfaValidateCode(secret, tc.code, tc.at)
+			require.Equal(t, tc.expects, ok)
+			require.Equal(t, tc.counter, counter)
+		})
+	}
+
+	t.Run("invalid secret", func(t *testing.T) {
+		_, ok := MfaValidateCode("not base32!", "254676", at)
+		require.False(t, ok)
+	})
+}
+
+func TestMfaGenerateSecret(t *testing.T) {
+	secret, err := MfaGenerateSecret()
+	require.Nil(t, err)
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	require.Nil(t, err)
+	require.Len(t, key, MfaSecretSizeBytes)
+
+	code := mfaHOTP(key, uint64(time.Now().Unix()/MfaTOTPPeriod))
+	_, ok := MfaValidateCode(secret, code, time.Now())
+	require.True(t, ok)
+}
+
+func TestMfaOtpAuthURL(t *testing.T) {
+	u, err := url.Parse(MfaOtpAuthURL("Megacommerce", "user@email.com", "SECRET"))
+	require.Nil(t, err)
+	require.Equal(t, "otpauth", u.Scheme)
+	require.Equal(t, "totp", u.Host)
+	require.Equal(t, "/Megacommerce:user@email.com", u.Path)
+	require.Equal(t, "SECRET", u.Query().Get("secret"))
+	require.Equal(t, "Megacommerce", u.Query().Get("issuer"))
+}
+
+func TestMfaGenerateRecoveryCodes(t *testing.T) {
+	codes, err := MfaGenerateRecoveryCodes(MfaRecoveryCodesCount)
+	require.Nil(t, err)
+	require.Len(t, codes, MfaRecoveryCodesCount)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		require.Len(t, code, MfaRecoveryCodeLength+1)
+		require.Equal(t, byte('-'), code[MfaRecoveryCodeLength/2])
+		require.True(t, MfaIsValidRecoveryCode(code))
+		require.False(t, seen[code])
+		seen[code] = true
+	}
+}
+
+func TestMfaIsValidRecoveryCode(t *testing.T) {
+	tests := map[string]struct {
+		code    string
+		expects bool
+	}{
+		"displayed form":     {code: "abcde-fghij", expects: true},
+		"without separator":  {code: "abcdefghij", expects: true},
+		"upper case":         {code: "ABCDE-FGHIJ", expects: true},
+		"surrounding spaces": {code: " abcde fghij ", expects: true},
+		"too short":          {code: "abcde-fghi", expects: false},
+		"ambiguous chars":    {code: "abcde-fgh10", expects: false},
+		"totp code":          {code: "123456", expects: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expects, MfaIsValidRecoveryCode(tc.code))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"slices"
+	"strings"
+
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"google.golang.org/grpc/codes"
+)
+
+// OAuthConsentRequest is the consent request returned by the Hydra admin API
+type OAuthConsentRequest struct {
+	Challenge                    string             `json:"challenge"`
+	Subject                      string             `json:"subject"`
+	Skip                         bool               `json:"skip"`
+	RequestedScope               []string           `json:"requested_scope"`
+	RequestedAccessTokenAudience []string           `json:"requested_access_token_audience"`
+	Client                       OAuthConsentClient `json:"client"`
+	Context                      map[string]any     `json:"context"`
+}
+
+type OAuthConsentClient struct {
+	ClientID   string `json:"client_id"`
+	ClientName string `json:"client_name"`
+}
+
+// OAuthLoginRequest is the login request returned by the Hydra admin API
+type OAuthLoginRequest struct {
+	Challenge string `json:"challenge"`
+	Subject   string `json:"subject"`
+	Skip      bool   `json:"skip"`
+}
+
+// OAuthScopesAreSubset reports whether every granted scope was requested
+func OAuthScopesAreSubset(requested, granted []string) bool {
+	for _, s := range granted {
+		if !slices.Contains(requested, s) {
+			return false
+		}
+	}
+	return true
+}
+
+func OAuthConsentChallengeIsValid(ctx *models.Context, challenge string) *models.AppError {
+	if challenge == "" {
+		return models.NewAppError(ctx, "users.models.OAuthConsentChallengeIsValid", "oauth.consent_challenge.missing", nil, "", int(codes.InvalidArgument), nil)
+	}
+	return nil
+}
+
+const (
+	OAuthScopeOpenID  = "openid"
+	OAuthScopeProfile = "profile"
+	OAuthScopeEmail   = "email"
+	OAuthScopeRoles   = "roles"
+	// OAuthScopeMegacommercePrefix prefixes the custom scopes, any of them adds the
+	// megacommerce claims to the access token
+	OAuthScopeMegacommercePrefix = "megacommerce:"
+)
+
+// OAuthConsentSession maps the granted scopes to the user's claims, the id_token claims are
+// meant for the client, the access_token claims for the resource servers (so they don't
+// need to call the users service on every request)
+func OAuthConsentSession(user *pb.User, grantScope []string) map[string]any {
+	idToken := map[string]any{}
+	accessToken := map[string]any{}
+
+	for _, scope := range grantScope {
+		switch {
+		case scope == OAuthScopeProfile:
+			idToken["name"] = strings.TrimSpace(user.GetFirstName() + " " + user.GetLastName())
+			idToken["given_name"] = user.GetFirstName()
+			idToken["first_name"] = user.GetFirstName()
+			idToken["family_name"] = user.GetLastName()
+			idToken["preferred_username"] = user.GetUsername()
+			idToken["locale"] = user.GetLocale()
+			if user.GetImage() != "" {
+				idToken["picture"] = user.GetImage()
+			}
+			if user.GetUpdatedAt() > 0 {
+				idToken["updated_at"] = user.GetUpdatedAt() / 1000
+			}
+		case scope == OAuthScopeEmail:
+			idToken["email"] = user.GetEmail()
+			idToken["email_verified"] = user.GetIsEmailVerified()
+		case scope == OAuthScopeRoles:
+			roles := user.GetRoles()
+			if roles == nil {
+				roles = []string{}
+			}
+			idToken["roles"] = roles
+			accessToken["roles"] = roles
+		case strings.HasPrefix(scope, OAuthScopeMegacommercePrefix):
+			accessToken["user_type"] = user.GetUserType()
+			accessToken["membership"] = user.GetMembership()
+			accessToken["locale"] = user.GetLocale()
+			accessToken["email_verified"] = user.GetIsEmailVerified()
+		}
+	}
+
+	return map[string]any{"id_token": idToken, "access_token": accessToken}
+}
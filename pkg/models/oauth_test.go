@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthScopesAreSubset(t *testing.T) {
+	requested := []string{"openid", "email", "profile"}
+	require.True(t, OAuthScopesAreSubset(requested, []string{"openid", "email"}))
+	require.True(t, OAuthScopesAreSubset(requested, nil))
+	require.False(t, OAuthScopesAreSubset(requested, []string{"openid", "offline_access"}))
+}
+
+func TestOAuthConsentSession(t *testing.T) {
+	str := func(s string) *string { return &s }
+	verified := true
+	user := &pb.User{
+		Id:              str("01HZX3Y5N8M2Q7R4T6V9W0A1B2"),
+		Username:        str("jane"),
+		FirstName:       str("Jane"),
+		LastName:        str("Doe"),
+		Email:           str("jane@example.com"),
+		IsEmailVerified: &verified,
+		UserType:        str(string(UserTypeSupplier)),
+		Membership:      str("pro"),
+		Locale:          str("en"),
+		Roles:           []string{"supplier_admin"},
+	}
+
+	tests := map[string]struct {
+		scopes      []string
+		idToken     map[string]any
+		accessToken map[string]any
+	}{
+		"openid only": {
+			scopes:      []string{"openid"},
+			idToken:     map[string]any{},
+			accessToken: map[string]any{},
+		},
+		"email": {
+			scopes:      []string{"openid", "email"},
+			idToken:     map[string]any{"email": "jane@example.com", "email_verified": true},
+			accessToken: map[string]any{},
+		},
+		"roles": {
+			scopes:      []string{"roles"},
+			idToken:     map[string]any{"roles": []string{"supplier_admin"}},
+			accessToken: map[string]any{"roles": []string{"supplier_admin"}},
+		},
+		"custom scope": {
+			scopes:      []string{"megacommerce:orders"},
+			idToken:     map[string]any{},
+			accessToken: map[string]any{"user_type": "supplier", "membership": "pro", "locale": "en", "email_verified": true},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			session := OAuthConsentSession(user, tc.scopes)
+			require.Equal(t, tc.idToken, session["id_token"])
+			require.Equal(t, tc.accessToken, session["access_token"])
+		})
+	}
+
+	session := OAuthConsentSession(user, []string{"profile"})
+	idToken := session["id_token"].(map[string]any)
+	require.Equal(t, "Jane Doe", idToken["name"])
+	require.Equal(t, "jane", idToken["preferred_username"])
+	require.Equal(t, "en", idToken["locale"])
+}
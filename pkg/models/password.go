@@ -0,0 +1,255 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	common "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/common/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordHasherID identifies a hashing algorithm, it's also the prefix of the stored hashes
+type PasswordHasherID string
+
+const (
+	PasswordHasherArgon2id PasswordHasherID = "argon2id"
+	PasswordHasherBcrypt   PasswordHasherID = "bcrypt"
+)
+
+// BcryptPasswordMaxLength is the longest password in bytes that bcrypt accepts
+const BcryptPasswordMaxLength = 72
+
+var (
+	ErrPasswordMismatch    = errors.New("password doesn't match the hash")
+	ErrPasswordHashUnknown = errors.New("unknown password hash format")
+)
+
+// PasswordHasher hashes passwords into self describing strings, the algorithm
+// and its parameters are encoded in the hash, so they can be changed later
+type PasswordHasher interface {
+	ID() PasswordHasherID
+	Hash(password string) (string, error)
+	// Check returns ErrPasswordMismatch if the password doesn't match the hash
+	Check(hash, password string) error
+	// Owns reports whether the hash was produced by this algorithm
+	Owns(hash string) bool
+	// NeedsRehash reports whether the hash was produced with other parameters than the current ones
+	NeedsRehash(hash string) bool
+	// MaxLength is the longest password in bytes that the algorithm can hash
+	MaxLength() int
+}
+
+// PasswordHasherNew returns the hasher of the new password hashes configured by auth
+func PasswordHasherNew(auth *Auth) (PasswordHasher, error) {
+	switch PasswordHasherID(auth.PasswordHasher) {
+	case PasswordHasherBcrypt:
+		return NewBcryptHasher(), nil
+	case PasswordHasherArgon2id, "":
+		h := NewArgon2idHasher()
+		if auth.Argon2idMemoryKiB > 0 {
+			h.MemoryKiB = auth.Argon2idMemoryKiB
+		}
+		if auth.Argon2idIterations > 0 {
+			h.Iterations = auth.Argon2idIterations
+		}
+		if auth.Argon2idParallelism > 0 {
+			h.Parallelism = auth.Argon2idParallelism
+		}
+		return h, nil
+	default:
+		return nil, fmt.Errorf("unknown password hasher: %s", auth.PasswordHasher)
+	}
+}
+
+// Argon2idHasher produces PHC formatted hashes, E,g $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>
+type Argon2idHasher struct {
+	MemoryKiB   uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// NewArgon2idHasher returns an Argon2idHasher with the RFC 9106 recommended
+// parameters for memory constrained environments
+func NewArgon2idHasher() *Argon2idHasher {
+	return &Argon2idHasher{MemoryKiB: 64 * 1024, Iterations: 3, Parallelism: 4, SaltLength: 16, KeyLength: 32}
+}
+
+func (h *Argon2idHasher) ID() PasswordHasherID {
+	return PasswordHasherArgon2id
+}
+
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Iterations, h.MemoryKiB, h.Parallelism, h.KeyLength)
+	b64 := base64.RawStdEncoding
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.MemoryKiB, h.Iterations, h.Parallelism, b64.EncodeToString(salt), b64.EncodeToString(key)), nil
+}
+
+func (h *Argon2idHasher) Check(hash, password string) error {
+	params, salt, key, err := argon2idDecode(hash)
+	if err != nil {
+		return err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.Iterations, params.MemoryKiB, params.Parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+func (h *Argon2idHasher) Owns(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
+
+func (h *Argon2idHasher) NeedsRehash(hash string) bool {
+	params, salt, key, err := argon2idDecode(hash)
+	if err != nil {
+		return true
+	}
+
+	return params.MemoryKiB != h.MemoryKiB ||
+		params.Iterations != h.Iterations ||
+		params.Parallelism != h.Parallelism ||
+		uint32(len(salt)) != h.SaltLength ||
+		uint32(len(key)) != h.KeyLength
+}
+
+func (h *Argon2idHasher) MaxLength() int {
+	return UserPasswordMaxLength
+}
+
+func argon2idDecode(hash string) (*Argon2idHasher, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != string(PasswordHasherArgon2id) {
+		return nil, nil, nil, ErrPasswordHashUnknown
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, ErrPasswordHashUnknown
+	}
+
+	params := &Argon2idHasher{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.MemoryKiB, &params.Iterations, &params.Parallelism); err != nil {
+		return nil, nil, nil, ErrPasswordHashUnknown
+	}
+
+	b64 := base64.RawStdEncoding
+	salt, err := b64.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, ErrPasswordHashUnknown
+	}
+	key, err := b64.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, ErrPasswordHashUnknown
+	}
+
+	return params, salt, key, nil
+}
+
+// BcryptHasher is the legacy hasher, note that bcrypt ignores (newer versions refuse)
+// passwords longer than 72 bytes
+type BcryptHasher struct {
+	Cost int
+}
+
+func NewBcryptHasher() *BcryptHasher {
+	return &BcryptHasher{Cost: bcrypt.DefaultCost}
+}
+
+func (h *BcryptHasher) ID() PasswordHasherID {
+	return PasswordHasherBcrypt
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+func (h *BcryptHasher) Check(hash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrPasswordMismatch
+	}
+	return err
+}
+
+func (h *BcryptHasher) Owns(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func (h *BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.Cost
+}
+
+func (h *BcryptHasher) MaxLength() int {
+	return BcryptPasswordMaxLength
+}
+
+// PasswordHash hashes the password with the current hasher
+func PasswordHash(current PasswordHasher, password string) (string, error) {
+	if password == "" {
+		return "", errors.New("empty password")
+	}
+	return current.Hash(password)
+}
+
+// PasswordCheck checks the password against a hash made by any of the supported hashers,
+// rehash is true if the password matches but the hash should be replaced by a new one
+// (E,g a legacy bcrypt hash, or argon2id hash with outdated parameters)
+func PasswordCheck(current PasswordHasher, hash, password string) (rehash bool, err error) {
+	if hash == "" || password == "" {
+		return false, errors.New("empty password or hash")
+	}
+
+	for _, h := range []PasswordHasher{current, NewArgon2idHasher(), NewBcryptHasher()} {
+		if !h.Owns(hash) {
+			continue
+		}
+		if err := h.Check(hash, password); err != nil {
+			return false, err
+		}
+		if h.ID() != current.ID() {
+			return true, nil
+		}
+		return current.NeedsRehash(hash), nil
+	}
+
+	return false, ErrPasswordHashUnknown
+}
+
+// PasswordIsValid validates a new password against the password policy, and the maximum
+// length of the current hasher (E,g bcrypt can't hash more than 72 bytes), it rejects the
+// passwords found in the breached corpus (if any) with the "password.breached" id
+func PasswordIsValid(pass string, passCfg *common.ConfigPassword, current PasswordHasher, breached *BreachedPasswords) *utils.InvalidPassword {
+	if err := utils.IsValidPassword(pass, passCfg, ""); err != nil {
+		return err
+	}
+
+	if max := current.MaxLength(); len(pass) > max {
+		return &utils.InvalidPassword{ID: "password.max_length", Err: "the password is longer than the hasher accepts", Params: map[string]any{"Max": max}}
+	}
+
+	if PasswordIsBreached(breached, pass) {
+		return &utils.InvalidPassword{ID: "password.breached", Err: "the password is found in the breached passwords corpus"}
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	common "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/common/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/grpc/codes"
+)
+
+func PasswordResetRequestIsValid(ctx *models.Context, req *pbAcc.PasswordResetRequest, passCfg *common.ConfigPassword, hasher PasswordHasher, breached *BreachedPasswords) *models.AppError {
+	path := "user.models.PasswordResetRequestIsValid"
+	if req.GetToken() == "" {
+		return models.NewAppError(ctx, path, "password_reset.token.error", nil, "", int(codes.InvalidArgument), nil)
+	}
+
+	if _, err := ulid.ParseStrict(req.GetTokenId()); err != nil {
+		return models.NewAppError(ctx, path, "password_reset.token_id.error", nil, "", int(codes.InvalidArgument), &models.AppErrorErrorsArgs{Err: err})
+	}
+
+	// the password (which err.Err contains too) is kept out of the error, it ends up in the logs
+	if err := PasswordIsValid(req.GetPassword(), passCfg, hasher, breached); err != nil {
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"password": {ID: err.ID, Params: err.Params}}}
+		return models.NewAppError(ctx, path, err.ID, err.Params, "", int(codes.InvalidArgument), errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	common "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/common/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// cheap parameters, so the tests don't spend seconds on hashing
+func testArgon2idHasher() *Argon2idHasher {
+	return &Argon2idHasher{MemoryKiB: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+}
+
+func TestPasswordHashAndCheck(t *testing.T) {
+	h := testArgon2idHasher()
+
+	hash, err := PasswordHash(h, "correct horse battery staple")
+	require.NoError(t, err)
+	require.Contains(t, hash, "$argon2id$v=19$m=1024,t=1,p=1$")
+
+	rehash, err := PasswordCheck(h, hash, "correct horse battery staple")
+	require.NoError(t, err)
+	require.False(t, rehash)
+
+	_, err = PasswordCheck(h, hash, "wrong password")
+	require.ErrorIs(t, err, ErrPasswordMismatch)
+
+	long := string(make([]byte, 200))
+	hash, err = PasswordHash(h, long+"x")
+	require.NoError(t, err)
+	_, err = PasswordCheck(h, hash, long+"y")
+	require.ErrorIs(t, err, ErrPasswordMismatch)
+}
+
+func TestPasswordCheckRehash(t *testing.T) {
+	h := testArgon2idHasher()
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("legacy-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	rehash, err := PasswordCheck(h, string(legacy), "legacy-password")
+	require.NoError(t, err)
+	require.True(t, rehash, "bcrypt hashes should be upgraded")
+
+	_, err = PasswordCheck(h, string(legacy), "wrong-password")
+	require.ErrorIs(t, err, ErrPasswordMismatch)
+
+	weaker := &Argon2idHasher{MemoryKiB: 512, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	old, err := weaker.Hash("some-password")
+	require.NoError(t, err)
+
+	rehash, err = PasswordCheck(h, old, "some-password")
+	require.NoError(t, err)
+	require.True(t, rehash, "outdated argon2id parameters should be upgraded")
+
+	_, err = PasswordCheck(h, "plaintext", "plaintext")
+	require.ErrorIs(t, err, ErrPasswordHashUnknown)
+}
+
+func TestPasswordIsValidHasherMaxLength(t *testing.T) {
+	passCfg := &common.ConfigPassword{MinimumLength: utils.NewPointer(int32(8)), MaximumLength: utils.NewPointer(int32(UserPasswordMaxLength))}
+	long := strings.Repeat("a", BcryptPasswordMaxLength+1)
+
+	require.Nil(t, PasswordIsValid(long, passCfg, testArgon2idHasher(), nil))
+
+	err := PasswordIsValid(long, passCfg, NewBcryptHasher(), nil)
+	require.NotNil(t, err)
+	require.Equal(t, "password.max_length", err.ID)
+	require.Equal(t, BcryptPasswordMaxLength, err.Params["Max"])
+}
+
+func TestPasswordHasherNew(t *testing.T) {
+	h, err := PasswordHasherNew(&Auth{PasswordHasher: "bcrypt"})
+	require.NoError(t, err)
+	require.Equal(t, PasswordHasherBcrypt, h.ID())
+
+	h, err = PasswordHasherNew(&Auth{Argon2idIterations: 5})
+	require.NoError(t, err)
+	require.Equal(t, uint32(5), h.(*Argon2idHasher).Iterations)
+
+	_, err = PasswordHasherNew(&Auth{PasswordHasher: "md5"})
+	require.Error(t, err)
+}
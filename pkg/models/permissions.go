@@ -1,5 +1,7 @@
 package models
 
+import "github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+
 // Permission represents a single permission with metadata
 type Permission struct {
 	ID          string
@@ -366,3 +368,32 @@ var (
 		Category:    "support",
 	}
 )
+
+// permissionsNormal are the normal role permissions, every user role is granted them
+var permissionsNormal = []*Permission{
+	PermissionProfileView, PermissionProfileEdit, PermissionPasswordUpdate, PermissionAccountDelete,
+	PermissionPreferencesSet, PermissionOrderPlace, PermissionOrderCancel, PermissionOrderTrack,
+	PermissionOrderHistoryView, PermissionCardAdd, PermissionCardRemove, PermissionTransactionsView,
+	PermissionCouponsApply, PermissionWalletSave, PermissionReviewWrite, PermissionProductRate,
+	PermissionWishlistAdd, PermissionWishlistRemove, PermissionWishlistView, PermissionSuppliersFollow,
+	PermissionTicketCreate, PermissionTicketHistoryView, PermissionTicketClose,
+}
+
+// PermissionHas tells whether one of the roles (the roles of a user) grants the permission,
+// an unknown role grants nothing
+func PermissionHas(roles []string, p *Permission) bool {
+	for _, role := range roles {
+		switch models.RoleID(role) {
+		case models.RoleIDSystemAdmin:
+			return true
+		case models.RoleIDSystemUser, models.RoleIDCustomer, models.RoleIDSupplierAdmin,
+			models.RoleIDSupplierVendorManager, models.RoleIDSupplierModerator:
+			for _, np := range permissionsNormal {
+				if np.ID == p.ID {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
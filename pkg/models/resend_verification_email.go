@@ -0,0 +1,20 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"google.golang.org/grpc/codes"
+)
+
+func ResendVerificationEmailRequestIsValid(ctx *models.Context, req *pbAcc.ResendVerificationEmailRequest) *models.AppError {
+	path := "user.models.ResendVerificationEmailRequestIsValid"
+	if !utils.IsValidEmail(req.GetEmail()) {
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"email": {ID: "email.invalid"}}}
+		return models.NewAppError(ctx, path, "email.invalid", nil, fmt.Sprintf("invalid email=%s", req.GetEmail()), int(codes.InvalidArgument), errors)
+	}
+
+	return nil
+}
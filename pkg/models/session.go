@@ -0,0 +1,109 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// LoginDeviceIDHeader is the grpc metadata key of the id that the client generated for
+// the device, it lets the user tell the sessions of the same device apart from the others
+const LoginDeviceIDHeader = "x-device-id"
+
+// userSessionFieldMaxLength bounds the client supplied values (E,g the user agent), the
+// list of sessions is shown to the user, so it shouldn't store arbitrarily large values
+const userSessionFieldMaxLength = 512
+
+// UserSession is a login session of the user on a device, its id is the OAuth login
+// session id (the sid claim), so revoking it on the OAuth server logs the device out
+type UserSession struct {
+	ID         string         `json:"id"`
+	UserID     string         `json:"user_id"`
+	DeviceID   string         `json:"device_id"`
+	Platform   ClientPlatform `json:"platform"`
+	UserAgent  string         `json:"user_agent"`
+	IPAddress  string         `json:"ip_address"`
+	CreatedAt  int64          `json:"created_at"`
+	LastSeenAt int64          `json:"last_seen_at"`
+	// Current is set when listing, for the session that the request was sent with
+	Current bool `json:"current"`
+}
+
+func (s *UserSession) ToProto() *pbAcc.Session {
+	return &pbAcc.Session{
+		Id:         s.ID,
+		DeviceId:   s.DeviceID,
+		Platform:   string(s.Platform),
+		UserAgent:  s.UserAgent,
+		IpAddress:  s.IPAddress,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		Current:    s.Current,
+	}
+}
+
+// UserSessionTouchInterval is how often the last seen time of a session is updated if
+// Auth.SessionTouchSeconds isn't set, the authenticated requests within the interval
+// don't write it again
+const UserSessionTouchInterval = time.Minute
+
+func SessionRevokeRequestIsValid(ctx *models.Context, req *pbAcc.SessionRevokeRequest) *models.AppError {
+	if req.GetSessionId() == "" || len(req.GetSessionId()) > 128 {
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"session_id": {ID: "user.session.id.invalid"}}}
+		return models.NewAppError(ctx, "users.models.SessionRevokeRequestIsValid", "user.session.id.invalid", nil, "", int(codes.InvalidArgument), errors)
+	}
+	return nil
+}
+
+// UserSessionNew builds the session of a login accepted from the device described by the
+// request context and metadata, trustedProxies is Service.TrustedProxies
+func UserSessionNew(ctx *models.Context, md metadata.MD, sessionID, userID string, trustedProxies int) *UserSession {
+	now := utils.TimeGetMillis()
+	s := &UserSession{
+		ID:         sessionID,
+		UserID:     userID,
+		Platform:   LoginSessionOptionsGet(md).Platform,
+		UserAgent:  userSessionTruncate(ctx.UserAgent),
+		IPAddress:  userSessionTruncate(ClientIPAddress(ctx, trustedProxies)),
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	if v := md.Get(LoginDeviceIDHeader); len(v) > 0 {
+		s.DeviceID = userSessionTruncate(strings.TrimSpace(v[0]))
+	}
+	return s
+}
+
+// ClientIPAddress returns the client address of the request, ctx.IPAddress is the peer of
+// the gateway, and every one of the trustedProxies in front of it appended the address it
+// got the request from to X-Forwarded-For, so the client is the right-most entry that no
+// trusted proxy is behind, the entries left of it are set by the client and can't be trusted
+func ClientIPAddress(ctx *models.Context, trustedProxies int) string {
+	if trustedProxies <= 0 || ctx.XForwardedFor == "" {
+		return ctx.IPAddress
+	}
+
+	hops := strings.Split(ctx.XForwardedFor, ",")
+	// the peer is the last trusted proxy, the others are the right-most entries
+	i := len(hops) - trustedProxies
+	if i < 0 {
+		// less hops than proxies, the left-most one was still appended by a trusted proxy
+		i = 0
+	}
+	if ip := strings.TrimSpace(hops[i]); ip != "" {
+		return ip
+	}
+	return ctx.IPAddress
+}
+
+func userSessionTruncate(v string) string {
+	if len(v) > userSessionFieldMaxLength {
+		return v[:userSessionFieldMaxLength]
+	}
+	return v
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestClientIPAddress(t *testing.T) {
+	tests := map[string]struct {
+		xff            string
+		trustedProxies int
+		expects        string
+	}{
+		"direct client":                       {expects: "10.0.0.1"},
+		"forwarded for isn't trusted alone":   {xff: "203.0.113.7", expects: "10.0.0.1"},
+		"behind a proxy":                      {xff: "203.0.113.7", trustedProxies: 1, expects: "203.0.113.7"},
+		"a spoofed entry is ignored":          {xff: "198.51.100.9, 203.0.113.7", trustedProxies: 1, expects: "203.0.113.7"},
+		"behind many proxies":                 {xff: "198.51.100.9, 203.0.113.7, 10.0.0.2", trustedProxies: 2, expects: "203.0.113.7"},
+		"less hops than proxies":              {xff: "203.0.113.7", trustedProxies: 3, expects: "203.0.113.7"},
+		"an empty hop falls back to the peer": {xff: "203.0.113.7, ", trustedProxies: 1, expects: "10.0.0.1"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := &models.Context{IPAddress: "10.0.0.1", XForwardedFor: tc.xff}
+			require.Equal(t, tc.expects, ClientIPAddress(ctx, tc.trustedProxies))
+		})
+	}
+}
+
+func TestUserSessionNew(t *testing.T) {
+	ctx := models.ContextForTesting()
+	ctx.UserAgent = strings.Repeat("a", 1000)
+	md := metadata.Pairs(LoginDeviceIDHeader, "device-1", LoginClientPlatformHeader, "mobile")
+
+	s := UserSessionNew(ctx, md, "sid", "user", 0)
+	require.Equal(t, "sid", s.ID)
+	require.Equal(t, "user", s.UserID)
+	require.Equal(t, "device-1", s.DeviceID)
+	require.Equal(t, ClientPlatformMobile, s.Platform)
+	require.Len(t, s.UserAgent, userSessionFieldMaxLength)
+	require.Equal(t, s.CreatedAt, s.LastSeenAt)
+
+	s = UserSessionNew(ctx, metadata.MD{}, "sid", "user", 0)
+	require.Empty(t, s.DeviceID)
+	require.Equal(t, ClientPlatformWeb, s.Platform)
+}
@@ -23,7 +23,7 @@ func SignupCustomerRequestSanitize(c *user.CustomerCreateRequest) *user.Customer
 	}
 }
 
-func SignupCustomerRequestIsValid(ctx *models.Context, c *user.CustomerCreateRequest, passCfg *common.ConfigPassword) *models.AppError {
+func SignupCustomerRequestIsValid(ctx *models.Context, c *user.CustomerCreateRequest, passCfg *common.ConfigPassword, hasher PasswordHasher, breached *BreachedPasswords, domains *EmailDomainPolicy) *models.AppError {
 	un := c.GetUsername()
 	email := c.GetEmail()
 	fn := c.GetFirstName()
@@ -42,6 +42,12 @@ func SignupCustomerRequestIsValid(ctx *models.Context, c *user.CustomerCreateReq
 		return signupCustomerRequestErrorBuilder(ctx, "email", email, nil)
 	}
 
+	if reason := domains.Check(ctx.Context, email, UserTypeCustomer); reason != "" {
+		id := fmt.Sprintf("user.create.email.%s.error", reason)
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"email": {ID: id}}}
+		return models.NewAppError(ctx, "user.models.CustomerCreateRequest.SignupCustomerRequestIsValid", id, nil, fmt.Sprintf(" email=%s ", email), int(codes.InvalidArgument), errors)
+	}
+
 	if utf8.RuneCountInString(fn) > UserFirstNameMaxRunes || utf8.RuneCountInString(fn) < UserFirstNameMinRunes {
 		return signupCustomerRequestErrorBuilder(ctx, "first_name", fn, map[string]any{"Min": UserFirstNameMinRunes, "Max": UserFirstNameMaxRunes})
 	}
@@ -50,7 +56,7 @@ func SignupCustomerRequestIsValid(ctx *models.Context, c *user.CustomerCreateReq
 		return signupCustomerRequestErrorBuilder(ctx, "last_name", ln, map[string]any{"Min": UserLastNameMinRunes, "Max": UserLastNameMaxRunes})
 	}
 
-	if err := utils.IsValidPassword(pass, passCfg, ""); err != nil {
+	if err := PasswordIsValid(pass, passCfg, hasher, breached); err != nil {
 		errors := &models.AppErrorErrorsArgs{Err: err, ErrorsInternal: map[string]*models.AppErrorError{"password": {ID: err.ID, Params: err.Params}}}
 		e := models.NewAppError(ctx, "user.models.CustomerCreateRequest.SignupCustomerRequestIsValid", err.ID, err.Params, fmt.Sprintf("invalid password %s ", pass), int(codes.InvalidArgument), errors)
 		return e
@@ -79,8 +85,8 @@ func SignupCustomerRequestAuditable(c *user.CustomerCreateRequest) map[string]st
 
 // SignupCustomerRequestPreSave convert CustomerCreateRequest to User
 // and populate the necessary fields with values to be stored in db
-func SignupCustomerRequestPreSave(ctx *models.Context, c *user.User) (*user.User, *models.AppError) {
-	pass, err := utils.PasswordHash(c.GetPassword())
+func SignupCustomerRequestPreSave(ctx *models.Context, c *user.User, hasher PasswordHasher) (*user.User, *models.AppError) {
+	pass, err := PasswordHash(hasher, c.GetPassword())
 	if err != nil {
 		return nil, models.NewAppError(ctx,
 			"user.models.SignupCustomerRequestPreSave", models.ErrMsgInternal, nil,
@@ -23,7 +23,7 @@ func SignupSupplierRequestSanitize(s *user.SupplierCreateRequest) *user.Supplier
 	}
 }
 
-func SignupSupplierRequestIsValid(ctx *models.Context, s *user.SupplierCreateRequest, passCfg *common.ConfigPassword) *models.AppError {
+func SignupSupplierRequestIsValid(ctx *models.Context, s *user.SupplierCreateRequest, passCfg *common.ConfigPassword, hasher PasswordHasher, breached *BreachedPasswords, domains *EmailDomainPolicy) *models.AppError {
 	un := s.GetUsername()
 	email := s.GetEmail()
 	fn := s.GetFirstName()
@@ -42,6 +42,12 @@ func SignupSupplierRequestIsValid(ctx *models.Context, s *user.SupplierCreateReq
 		return signupSupplierRequestErrorBuilder(ctx, "email", email, nil)
 	}
 
+	if reason := domains.Check(ctx.Context, email, UserTypeSupplier); reason != "" {
+		id := fmt.Sprintf("user.create.email.%s.error", reason)
+		errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{"email": {ID: id}}}
+		return models.NewAppError(ctx, "user.models.SupplierCreateRequest.SignupSupplierRequestIsValid", id, nil, fmt.Sprintf(" email=%s ", email), int(codes.InvalidArgument), errors)
+	}
+
 	if utf8.RuneCountInString(fn) > UserFirstNameMaxRunes || utf8.RuneCountInString(fn) < UserFirstNameMinRunes {
 		return signupSupplierRequestErrorBuilder(ctx, "first_name", fn, map[string]any{"Min": UserFirstNameMinRunes, "Max": UserFirstNameMaxRunes})
 	}
@@ -50,7 +56,7 @@ func SignupSupplierRequestIsValid(ctx *models.Context, s *user.SupplierCreateReq
 		return signupSupplierRequestErrorBuilder(ctx, "last_name", ln, map[string]any{"Min": UserLastNameMinRunes, "Max": UserLastNameMaxRunes})
 	}
 
-	if err := utils.IsValidPassword(pass, passCfg, ""); err != nil {
+	if err := PasswordIsValid(pass, passCfg, hasher, breached); err != nil {
 		errors := &models.AppErrorErrorsArgs{Err: err, ErrorsInternal: map[string]*models.AppErrorError{"password": {ID: err.ID, Params: err.Params}}}
 		e := models.NewAppError(ctx, "user.models.SupplierCreateRequest.SignupSupplierRequestIsValid", err.ID, err.Params, fmt.Sprintf("invalid password %s ", pass), int(codes.InvalidArgument), errors)
 		return e
@@ -80,8 +86,8 @@ func SignupSupplierRequestAuditable(s *user.SupplierCreateRequest) map[string]st
 
 // SignupSupplierRequestPreSave convert SupplierCreateRequest to User
 // and populate the necessary fields with values to be stored in db
-func SignupSupplierRequestPreSave(ctx *models.Context, s *user.User) (*user.User, *models.AppError) {
-	pass, err := utils.PasswordHash(s.GetPassword())
+func SignupSupplierRequestPreSave(ctx *models.Context, s *user.User, hasher PasswordHasher) (*user.User, *models.AppError) {
+	pass, err := PasswordHash(hasher, s.GetPassword())
 	if err != nil {
 		return nil, models.NewAppError(ctx,
 			"user.models.SignupSupplierRequestPreSave", models.ErrMsgInternal, nil,
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+)
+
+// SocialStateCookie binds the social login state to the browser that started it, so a
+// callback carrying someone else's state (login CSRF) is refused
+const SocialStateCookie = "megacommerce_social_state"
+
+// SocialLoginState holds what the callback of a social login needs, between the redirect
+// to the upstream provider and the callback, it is stored under the random state value
+type SocialLoginState struct {
+	ID       string `json:"id"`
+	Provider string `json:"provider"`
+	// LinkUserID is set when an authenticated user links the identity, there is no
+	// Hydra login request then
+	LinkUserID     string         `json:"link_user_id,omitempty"`
+	LoginChallenge string         `json:"login_challenge"`
+	CodeVerifier   string         `json:"code_verifier"`
+	Nonce          string         `json:"nonce"`
+	Platform       ClientPlatform `json:"platform"`
+	Remember       bool           `json:"remember"`
+	ExpiresAt      int64          `json:"expires_at"`
+	// LinkIdentity is the provider account of a pending link, saved by the callback of the
+	// link flow until the user confirms it with the IdentityLinkConfirm RPC
+	LinkIdentity *SocialUserInfo `json:"link_identity,omitempty"`
+}
+
+// SocialUserInfo is the identity returned by an upstream provider, gathered from the
+// id token claims of the OIDC providers, or the userinfo endpoint of the plain OAuth2 ones
+type SocialUserInfo struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+	Picture       string
+	Locale        string
+}
+
+// SocialEmail is an entry of the emails list of the plain OAuth2 providers (E,g GitHub),
+// which don't return the verification state of the email with the profile
+type SocialEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// SocialStateNewID returns a random, unguessable id for a social login state
+func SocialStateNewID() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// SocialUserInfoFromClaims reads the identity from the id token claims or the userinfo
+// response, the standard OIDC claims are preferred, the GitHub ones are the fallback
+func SocialUserInfoFromClaims(claims map[string]any) *SocialUserInfo {
+	info := &SocialUserInfo{
+		Subject:       socialClaimString(claims, "sub", "id"),
+		Email:         strings.ToLower(socialClaimString(claims, "email")),
+		EmailVerified: socialClaimBool(claims["email_verified"]),
+		FirstName:     socialClaimString(claims, "given_name", "first_name"),
+		LastName:      socialClaimString(claims, "family_name", "last_name"),
+		Picture:       socialClaimString(claims, "picture", "avatar_url"),
+		Locale:        socialClaimString(claims, "locale"),
+	}
+
+	if info.FirstName == "" && info.LastName == "" {
+		name := strings.Fields(socialClaimString(claims, "name"))
+		if len(name) > 0 {
+			info.FirstName = name[0]
+			info.LastName = strings.Join(name[1:], " ")
+		}
+	}
+
+	return info
+}
+
+// SocialPrimaryEmail picks the verified primary email of the list, or any verified one
+func SocialPrimaryEmail(emails []SocialEmail) (string, bool) {
+	verified := ""
+	for _, e := range emails {
+		if !e.Verified {
+			continue
+		}
+		if e.Primary {
+			return strings.ToLower(e.Email), true
+		}
+		if verified == "" {
+			verified = strings.ToLower(e.Email)
+		}
+	}
+	return verified, verified != ""
+}
+
+// SocialUsernameFromEmail derives a username from the local part of the email, suffix
+// keeps it unique, the characters that aren't allowed in usernames are dropped
+func SocialUsernameFromEmail(email, suffix string) string {
+	local, _, _ := strings.Cut(email, "@")
+
+	var b strings.Builder
+	for _, r := range strings.ToLower(local) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.') {
+			b.WriteRune(r)
+		}
+	}
+
+	name := b.String()
+	if max := UserNameMaxLength - len(suffix) - 1; len(name) > max {
+		name = name[:max]
+	}
+	if name == "" {
+		name = "user"
+	}
+	return fmt.Sprintf("%s_%s", name, suffix)
+}
+
+// SocialUserNew builds a customer signing in through a provider, the account has no
+// password, and the email is verified since the provider vouches for it. The provider
+// account is stored as an identity (see SocialIdentityNew), not in the legacy auth_service
+// and auth_data columns
+func SocialUserNew(info *SocialUserInfo, locale string) *pb.User {
+	id := utils.NewID()
+	suffix := strings.ToLower(id[len(id)-6:])
+	if info.Locale != "" {
+		locale = info.Locale
+	}
+
+	return &pb.User{
+		Id:              utils.NewPointer(id),
+		Username:        utils.NewPointer(SocialUsernameFromEmail(info.Email, suffix)),
+		FirstName:       utils.NewPointer(info.FirstName),
+		LastName:        utils.NewPointer(info.LastName),
+		Email:           utils.NewPointer(info.Email),
+		UserType:        utils.NewPointer(string(UserTypeCustomer)),
+		Membership:      utils.NewPointer("free"),
+		IsEmailVerified: utils.NewPointer(true),
+		Password:        utils.NewPointer(""),
+		Roles:           []string{string(models.RoleIDCustomer)},
+		Locale:          utils.NewPointer(locale),
+		MfaActive:       utils.NewPointer(false),
+		CreatedAt:       utils.NewPointer(utils.TimeGetMillis()),
+	}
+}
+
+// SocialIdentityNew builds the identity of the provider account for the user
+func SocialIdentityNew(userID, provider string, info *SocialUserInfo) *Identity {
+	return &Identity{
+		ID:        utils.NewID(),
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   info.Subject,
+		Email:     info.Email,
+		CreatedAt: utils.TimeGetMillis(),
+	}
+}
+
+func socialClaimString(claims map[string]any, keys ...string) string {
+	for _, k := range keys {
+		switch v := claims[k].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			// numeric ids (E,g GitHub's) are decoded as float64
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return ""
+}
+
+// socialClaimBool accepts the boolean claims sent as strings too (E,g Apple's email_verified)
+func socialClaimBool(v any) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		ok, _ := strconv.ParseBool(b)
+		return ok
+	}
+	return false
+}
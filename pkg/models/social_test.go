@@ -0,0 +1,73 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSocialUserInfoFromClaims(t *testing.T) {
+	tests := map[string]struct {
+		claims  map[string]any
+		expects SocialUserInfo
+	}{
+		"oidc claims": {
+			claims:  map[string]any{"sub": "123", "email": "Jane@Example.com", "email_verified": true, "given_name": "Jane", "family_name": "Doe"},
+			expects: SocialUserInfo{Subject: "123", Email: "jane@example.com", EmailVerified: true, FirstName: "Jane", LastName: "Doe"},
+		},
+		"string email_verified": {
+			claims:  map[string]any{"sub": "001.abc", "email": "jane@example.com", "email_verified": "true"},
+			expects: SocialUserInfo{Subject: "001.abc", Email: "jane@example.com", EmailVerified: true},
+		},
+		"numeric id and full name": {
+			claims:  map[string]any{"id": float64(583231), "name": "Jane Mary Doe", "avatar_url": "https://example.com/a.png"},
+			expects: SocialUserInfo{Subject: "583231", FirstName: "Jane", LastName: "Mary Doe", Picture: "https://example.com/a.png"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, &tc.expects, SocialUserInfoFromClaims(tc.claims))
+		})
+	}
+}
+
+func TestSocialPrimaryEmail(t *testing.T) {
+	email, ok := SocialPrimaryEmail([]SocialEmail{
+		{Email: "old@example.com", Verified: true},
+		{Email: "Main@example.com", Primary: true, Verified: true},
+	})
+	require.True(t, ok)
+	require.Equal(t, "main@example.com", email)
+
+	email, ok = SocialPrimaryEmail([]SocialEmail{{Email: "main@example.com", Primary: true}, {Email: "old@example.com", Verified: true}})
+	require.True(t, ok)
+	require.Equal(t, "old@example.com", email)
+
+	_, ok = SocialPrimaryEmail([]SocialEmail{{Email: "main@example.com", Primary: true}})
+	require.False(t, ok)
+}
+
+func TestSocialUsernameFromEmail(t *testing.T) {
+	tests := map[string]struct {
+		email   string
+		expects string
+	}{
+		"plain":            {email: "jane.doe@example.com", expects: "jane.doe_abc123"},
+		"invalid chars":    {email: "jane+shop@example.com", expects: "janeshop_abc123"},
+		"nothing left":     {email: "+++@example.com", expects: "user_abc123"},
+		"non ascii":        {email: "jöhn@example.com", expects: "jhn_abc123"},
+		"truncated length": {email: strings.Repeat("a", 100) + "@example.com", expects: strings.Repeat("a", UserNameMaxLength-7) + "_abc123"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			username := SocialUsernameFromEmail(tc.email, "abc123")
+			require.Equal(t, tc.expects, username)
+			require.True(t, utils.IsValidUsernameChars(username))
+			require.LessOrEqual(t, len(username), UserNameMaxLength)
+		})
+	}
+}
@@ -7,9 +7,15 @@ type TaskName string
 type TaskFunc func()
 
 const (
-	TaskNameEmailBatching          TaskName = "email_batching"
-	TaskNameSendVerifyEmail        TaskName = "send_verify_email"
-	TaskNameSendPasswordResetEmail TaskName = "send_password_reset_email"
+	TaskNameEmailBatching                 TaskName = "email_batching"
+	TaskNameSendVerifyEmail               TaskName = "send_verify_email"
+	TaskNameSendPasswordResetEmail        TaskName = "send_password_reset_email"
+	TaskNameSendAccountLockedEmail        TaskName = "send_account_locked_email"
+	TaskNameSendMfaRecoveryCodeUsedEmail  TaskName = "send_mfa_recovery_code_used_email"
+	TaskNameSendPasswordChangedEmail      TaskName = "send_password_changed_email"
+	TaskNameSendEmailLoginEmail           TaskName = "send_email_login_email"
+	TaskNameSendPasswordForgotNoticeEmail TaskName = "send_password_forgot_notice_email"
+	TaskNameSendNewDeviceLoginEmail       TaskName = "send_new_device_login_email"
 )
 
 type TaskSendVerifyEmailPayload struct {
@@ -27,3 +33,44 @@ type TaskSendPasswordResetEmailPayload struct {
 	TokenID string          `json:"token_id"`
 	Hours   int             `json:"hours"`
 }
+
+type TaskSendAccountLockedEmailPayload struct {
+	Ctx      *models.Context `json:"ctx"`
+	Email    string          `json:"email"`
+	Attempts int             `json:"attempts"`
+	Minutes  int             `json:"minutes"`
+}
+
+type TaskSendMfaRecoveryCodeUsedEmailPayload struct {
+	Ctx       *models.Context `json:"ctx"`
+	Email     string          `json:"email"`
+	Remaining int             `json:"remaining"`
+}
+
+type TaskSendPasswordChangedEmailPayload struct {
+	Ctx   *models.Context `json:"ctx"`
+	Email string          `json:"email"`
+}
+
+type TaskSendEmailLoginEmailPayload struct {
+	Ctx     *models.Context `json:"ctx"`
+	Email   string          `json:"email"`
+	Code    string          `json:"code"`
+	Url     string          `json:"url"`
+	Minutes int             `json:"minutes"`
+}
+
+type TaskSendPasswordForgotNoticeEmailPayload struct {
+	Ctx         *models.Context `json:"ctx"`
+	Email       string          `json:"email"`
+	AuthService string          `json:"auth_service"`
+}
+
+type TaskSendNewDeviceLoginEmailPayload struct {
+	Ctx       *models.Context `json:"ctx"`
+	Email     string          `json:"email"`
+	Device    string          `json:"device"`
+	IPAddress string          `json:"ip_address"`
+	Time      string          `json:"time"`
+	Url       string          `json:"url"`
+}
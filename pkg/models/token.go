@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// TokenIssueAllowed reports whether a new token (E,g a verification email, an email login
+// code) can be issued to a user, given the creation times (in millis) of the tokens of the
+// same type issued in the last 24 hours, a zero cooldown or cap disables the corresponding check
+func TokenIssueAllowed(issued []int64, now time.Time, cooldown time.Duration, dailyCap int) bool {
+	if dailyCap > 0 && len(issued) >= dailyCap {
+		return false
+	}
+
+	for _, at := range issued {
+		if now.Sub(time.UnixMilli(at)) < cooldown {
+			return false
+		}
+	}
+
+	return true
+}
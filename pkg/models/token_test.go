@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenIssueAllowed(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) int64 { return now.Add(-d).UnixMilli() }
+	cooldown := time.Minute * 2
+
+	tests := map[string]struct {
+		issued   []int64
+		dailyCap int
+		expects  bool
+	}{
+		"nothing issued":           {issued: nil, dailyCap: 5, expects: true},
+		"within the cooldown":      {issued: []int64{ago(time.Minute)}, dailyCap: 5, expects: false},
+		"after the cooldown":       {issued: []int64{ago(time.Minute * 3)}, dailyCap: 5, expects: true},
+		"daily cap reached":        {issued: []int64{ago(time.Hour), ago(time.Hour * 2)}, dailyCap: 2, expects: false},
+		"below the daily cap":      {issued: []int64{ago(time.Hour), ago(time.Hour * 2)}, dailyCap: 3, expects: true},
+		"daily cap disabled":       {issued: []int64{ago(time.Hour), ago(time.Hour * 2)}, dailyCap: 0, expects: true},
+		"latest token in cooldown": {issued: []int64{ago(time.Hour), ago(time.Second)}, dailyCap: 0, expects: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expects, TokenIssueAllowed(tc.issued, now, cooldown, tc.dailyCap))
+		})
+	}
+}
@@ -17,7 +17,7 @@ const (
 	UserLastNameMaxRunes  = 64
 	UserLastNameMinRunes  = 2
 	UserAuthDataMaxLength = 128
-	UserPasswordMaxLength = 72
+	UserPasswordMaxLength = 256
 	UserPasswordMinLength = 8
 	UserLocaleMaxLength   = 5
 	UserTimezoneMaxRunes  = 256
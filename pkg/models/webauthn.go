@@ -0,0 +1,142 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	pb "github.com/ahmad-khatib0-org/megacommerce-proto/gen/go/users/v1"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/models"
+	"github.com/ahmad-khatib0-org/megacommerce-shared-go/pkg/utils"
+	pbAcc "github.com/ahmad-khatib0-org/megacommerce-user/gen/go/users/v1"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/grpc/codes"
+)
+
+const WebauthnCredentialNameMaxLength = 64
+
+type WebauthnSessionType string
+
+const (
+	WebauthnSessionTypeRegistration WebauthnSessionType = "registration"
+	WebauthnSessionTypeLogin        WebauthnSessionType = "login"
+)
+
+// WebauthnFakeUser stands for an unknown email (or a user without passkeys) in the privacy
+// mode, it has one credential whose id is derived from the email with the secret, so the
+// login options of an email are the same on every request, like the ones of a real user
+func WebauthnFakeUser(secret, email string) *WebauthnUser {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(email))))
+	id := mac.Sum(nil)
+
+	user := &pb.User{Id: utils.NewPointer(WebauthnCredentialID(id)), Email: utils.NewPointer(email)}
+	cred := &WebauthnCredential{ID: WebauthnCredentialID(id), Credential: webauthn.Credential{ID: id}}
+	return &WebauthnUser{User: user, Credentials: []*WebauthnCredential{cred}}
+}
+
+// WebauthnCredential is a passkey (public key credential) registered by a user
+type WebauthnCredential struct {
+	ID         string              `json:"id"`
+	UserID     string              `json:"user_id"`
+	Name       string              `json:"name"`
+	Credential webauthn.Credential `json:"credential"`
+	CreatedAt  int64               `json:"created_at"`
+	LastUsedAt int64               `json:"last_used_at"`
+}
+
+// WebauthnSession holds the state of a registration or login ceremony between
+// its begin and finish steps
+type WebauthnSession struct {
+	ID        string               `json:"id"`
+	UserID    string               `json:"user_id"`
+	Type      WebauthnSessionType  `json:"type"`
+	Data      webauthn.SessionData `json:"data"`
+	ExpiresAt int64                `json:"expires_at"`
+}
+
+// WebauthnCredentialID encodes a raw credential id the way it's stored
+func WebauthnCredentialID(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
+
+func WebauthnRegisterFinishRequestIsValid(ctx *models.Context, req *pbAcc.WebauthnRegisterFinishRequest) *models.AppError {
+	if _, err := ulid.ParseStrict(req.GetSessionId()); err != nil {
+		return webauthnErrorBuilder(ctx, "session_id", "user.webauthn.session.invalid")
+	}
+	if name := strings.TrimSpace(req.GetName()); name == "" || len(name) > WebauthnCredentialNameMaxLength {
+		return webauthnErrorBuilder(ctx, "name", "user.webauthn.name.invalid")
+	}
+	if req.GetCredential() == "" {
+		return webauthnErrorBuilder(ctx, "credential", "user.webauthn.credential.invalid")
+	}
+	return nil
+}
+
+func WebauthnLoginBeginRequestIsValid(ctx *models.Context, req *pbAcc.WebauthnLoginBeginRequest) *models.AppError {
+	if !utils.IsValidEmail(req.GetEmail()) {
+		return webauthnErrorBuilder(ctx, "email", "email.invalid")
+	}
+	return nil
+}
+
+func WebauthnLoginFinishRequestIsValid(ctx *models.Context, req *pbAcc.WebauthnLoginFinishRequest) *models.AppError {
+	if _, err := ulid.ParseStrict(req.GetSessionId()); err != nil {
+		return webauthnErrorBuilder(ctx, "session_id", "user.webauthn.session.invalid")
+	}
+	if req.GetCredential() == "" {
+		return webauthnErrorBuilder(ctx, "credential", "user.webauthn.credential.invalid")
+	}
+	if req.GetLoginChallenge() == "" {
+		return models.NewAppError(ctx, "users.models.WebauthnLoginFinishRequestIsValid", "oauth.login_challenge.missing", nil, "", int(codes.InvalidArgument), nil)
+	}
+	return nil
+}
+
+func webauthnErrorBuilder(ctx *models.Context, field, id string) *models.AppError {
+	errors := &models.AppErrorErrorsArgs{ErrorsInternal: map[string]*models.AppErrorError{field: {ID: id}}}
+	return models.NewAppError(ctx, "users.models.WebauthnRequestIsValid", id, nil, "", int(codes.InvalidArgument), errors)
+}
+
+// WebauthnUser adapts a user and its registered credentials to webauthn.User
+type WebauthnUser struct {
+	User        *pb.User
+	Credentials []*WebauthnCredential
+}
+
+func (u *WebauthnUser) WebAuthnID() []byte {
+	return []byte(u.User.GetId())
+}
+
+func (u *WebauthnUser) WebAuthnName() string {
+	return u.User.GetEmail()
+}
+
+func (u *WebauthnUser) WebAuthnDisplayName() string {
+	return strings.TrimSpace(u.User.GetFirstName() + " " + u.User.GetLastName())
+}
+
+func (u *WebauthnUser) WebAuthnIcon() string {
+	return ""
+}
+
+func (u *WebauthnUser) WebAuthnCredentials() []webauthn.Credential {
+	creds := make([]webauthn.Credential, 0, len(u.Credentials))
+	for _, c := range u.Credentials {
+		creds = append(creds, c.Credential)
+	}
+	return creds
+}
+
+// CredentialByID returns the stored credential matching the raw credential id
+func (u *WebauthnUser) CredentialByID(id []byte) *WebauthnCredential {
+	encoded := WebauthnCredentialID(id)
+	for _, c := range u.Credentials {
+		if c.ID == encoded {
+			return c
+		}
+	}
+	return nil
+}